@@ -0,0 +1,48 @@
+package mail_template
+
+type ActivationData struct {
+	UserName string
+	Code     string
+}
+
+type ResetData struct {
+	Code string
+}
+
+type MagicLinkData struct {
+	UserName string
+	Link     string
+	Minutes  int
+}
+
+type EmailChangeData struct {
+	UserName string
+	NewEmail string
+	Code     string
+}
+
+type EmailChangeNoticeData struct {
+	UserName string
+	NewEmail string
+	Link     string
+}
+
+// SecurityAlertData describes a security event of one of the domain.SecurityEvent* types.
+type SecurityAlertData struct {
+	UserName  string
+	Event     string
+	Time      string
+	IpAddress string
+	UserAgent string
+	Link      string
+}
+
+// Samples holds example data for every template, used for previews.
+var Samples = map[string]interface{}{
+	Activation:        ActivationData{UserName: "user1", Code: "aB3dE5gH"},
+	Reset:             ResetData{Code: "aB3dE5gH"},
+	MagicLink:         MagicLinkData{UserName: "user1", Link: "https://localhost:8080/magic-login?token=sample", Minutes: 10},
+	EmailChange:       EmailChangeData{UserName: "user1", NewEmail: "new.address@gmail.com", Code: "aB3dE5gH"},
+	EmailChangeNotice: EmailChangeNoticeData{UserName: "user1", NewEmail: "new.address@gmail.com", Link: "https://localhost:8080/cancel-email-change?token=sample"},
+	SecurityAlert:     SecurityAlertData{UserName: "user1", Event: "new_device_login", Time: "19 Oct 2026 14:05 UTC", IpAddress: "203.0.113.7", UserAgent: "Mozilla/5.0 (X11; Linux x86_64) Firefox/128.0", Link: "https://localhost:8080/lock-account?token=sample"},
+}
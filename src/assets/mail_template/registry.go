@@ -0,0 +1,220 @@
+// Package mail_template holds the embedded mail templates and their registry.
+package mail_template
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+
+	"golang.org/x/text/language"
+)
+
+const DefaultLocale = "en"
+
+const (
+	Activation        = "activation"
+	Reset             = "reset"
+	MagicLink         = "magic_link"
+	EmailChange       = "email_change"
+	EmailChangeNotice = "email_change_notice"
+	SecurityAlert     = "security_alert"
+)
+
+//go:embed layout.html locales templates
+var files embed.FS
+
+type Rendered struct {
+	Name    string
+	Version int
+	Locale  string
+	Subject string
+	Text    string
+	Html    string
+}
+
+type localized struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+type Registry struct {
+	templates map[string]map[int]map[string]localized
+	locales   []string
+	matcher   language.Matcher
+}
+
+// NewRegistry parses every embedded template so broken ones fail at startup.
+func NewRegistry() (*Registry, error) {
+	layout, err := htmltemplate.ParseFS(files, "layout.html")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Registry{templates: map[string]map[int]map[string]localized{}}
+	localeSet := map[string]bool{}
+
+	err = fs.WalkDir(files, "templates", func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(file) != ".html" {
+			return err
+		}
+
+		parts := strings.Split(file, "/")
+		if len(parts) != 4 || !strings.HasPrefix(parts[2], "v") {
+			return fmt.Errorf("unexpected template path %v", file)
+		}
+		name, locale := parts[1], strings.TrimSuffix(parts[3], ".html")
+		version, err := strconv.Atoi(strings.TrimPrefix(parts[2], "v"))
+		if err != nil {
+			return fmt.Errorf("unexpected template version in %v", file)
+		}
+
+		html, err := layout.Clone()
+		if err != nil {
+			return err
+		}
+		if _, err := fs.Stat(files, "locales/"+locale+".html"); err == nil {
+			if html, err = html.ParseFS(files, "locales/"+locale+".html"); err != nil {
+				return err
+			}
+		}
+		if html, err = html.ParseFS(files, file); err != nil {
+			return err
+		}
+
+		text, err := texttemplate.ParseFS(files, strings.TrimSuffix(file, ".html")+".txt")
+		if err != nil {
+			return err
+		}
+		if text.Lookup("subject") == nil {
+			return fmt.Errorf("template %v does not define a subject", file)
+		}
+
+		if r.templates[name] == nil {
+			r.templates[name] = map[int]map[string]localized{}
+		}
+		if r.templates[name][version] == nil {
+			r.templates[name][version] = map[string]localized{}
+		}
+		r.templates[name][version][locale] = localized{html: html.Lookup("layout.html"), text: text}
+		localeSet[locale] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !localeSet[DefaultLocale] {
+		return nil, fmt.Errorf("no templates for default locale %v", DefaultLocale)
+	}
+
+	tags := []language.Tag{language.Make(DefaultLocale)}
+	r.locales = []string{DefaultLocale}
+	for locale := range localeSet {
+		if locale != DefaultLocale {
+			r.locales = append(r.locales, locale)
+		}
+	}
+	sort.Strings(r.locales[1:])
+	for _, locale := range r.locales[1:] {
+		tags = append(tags, language.Make(locale))
+	}
+	r.matcher = language.NewMatcher(tags)
+
+	return r, nil
+}
+
+// MatchLocale returns the best supported locale for locales or Accept-Language headers.
+func (r *Registry) MatchLocale(preferences ...string) string {
+	for _, preference := range preferences {
+		if preference == "" {
+			continue
+		}
+
+		tags, _, err := language.ParseAcceptLanguage(preference)
+		if err != nil || len(tags) == 0 {
+			continue
+		}
+
+		_, index, confidence := r.matcher.Match(tags...)
+		if confidence != language.No {
+			return r.locales[index]
+		}
+	}
+
+	return DefaultLocale
+}
+
+func (r *Registry) Names() []string {
+	var names []string
+	for name := range r.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *Registry) Versions(name string) []int {
+	var versions []int
+	for version := range r.templates[name] {
+		versions = append(versions, version)
+	}
+	sort.Ints(versions)
+	return versions
+}
+
+func (r *Registry) Locales() []string {
+	return append([]string(nil), r.locales...)
+}
+
+// Render renders the latest version of a template.
+func (r *Registry) Render(name, locale string, data interface{}) (*Rendered, error) {
+	versions := r.Versions(name)
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("unknown mail template %v", name)
+	}
+
+	return r.RenderVersion(name, versions[len(versions)-1], locale, data)
+}
+
+// RenderVersion renders a version of a template, falling back to the default locale.
+func (r *Registry) RenderVersion(name string, version int, locale string, data interface{}) (*Rendered, error) {
+	locales, ok := r.templates[name][version]
+	if !ok {
+		return nil, fmt.Errorf("unknown mail template %v version %v", name, version)
+	}
+
+	t, ok := locales[locale]
+	if !ok {
+		locale = DefaultLocale
+		if t, ok = locales[locale]; !ok {
+			return nil, fmt.Errorf("mail template %v version %v has no %v locale", name, version, DefaultLocale)
+		}
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := t.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := t.text.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	if err := t.html.Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	return &Rendered{
+		Name:    name,
+		Version: version,
+		Locale:  locale,
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimLeft(text.String(), "\n"),
+		Html:    html.String(),
+	}, nil
+}
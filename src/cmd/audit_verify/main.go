@@ -0,0 +1,104 @@
+// Command audit_verify checks the hash chain of an exported audit log.
+package main
+
+import (
+	"auth-service/domain"
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// chain is what verify learned about a valid export.
+type chain struct {
+	count      int
+	last       *domain.AuditEntry
+	unanchored uint
+}
+
+func main() {
+	anchor := flag.String("anchor", "", "hash of the entry before the first exported one")
+	flag.Parse()
+
+	var input io.Reader = os.Stdin
+	if flag.NArg() > 0 {
+		file, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fail("error while opening %v: %v", flag.Arg(0), err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	result, err := verify(input, *anchor)
+	if err != nil {
+		fail("%v", err)
+	}
+
+	if result.unanchored != 0 {
+		fmt.Fprintf(os.Stderr, "warning: export starts at entry %d without -anchor, its link to earlier entries is not checked\n", result.unanchored)
+	}
+	if result.last == nil {
+		fmt.Println("no entries")
+		return
+	}
+	fmt.Printf("ok: %d entries, chain ends at entry %d with hash %s\n", result.count, result.last.ID, result.last.Hash)
+}
+
+// verify checks that every entry of an export matches its hash and the one before it.
+func verify(input io.Reader, anchor string) (chain, error) {
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var result chain
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry domain.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return result, fmt.Errorf("line %d: not an audit entry: %v", line, err)
+		}
+
+		previous := result.last
+		switch {
+		case previous != nil:
+			if entry.ID != previous.ID+1 {
+				return result, fmt.Errorf("entry %d: follows entry %d, entries are missing", entry.ID, previous.ID)
+			}
+			if entry.PrevHash != previous.Hash {
+				return result, fmt.Errorf("entry %d: does not link to entry %d", entry.ID, previous.ID)
+			}
+		case entry.ID == 1:
+			if entry.PrevHash != domain.AuditGenesisHash {
+				return result, fmt.Errorf("entry 1: does not start from the genesis hash")
+			}
+		case anchor != "":
+			if entry.PrevHash != anchor {
+				return result, fmt.Errorf("entry %d: does not link to the anchor", entry.ID)
+			}
+		default:
+			result.unanchored = entry.ID
+		}
+
+		if entry.Hash != entry.ComputeHash() {
+			return result, fmt.Errorf("entry %d: content does not match its hash", entry.ID)
+		}
+
+		result.last = &entry
+		result.count++
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("error while reading the export: %v", err)
+	}
+
+	return result, nil
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"auth-service/domain"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func export(first uint, n int, prevHash string) []domain.AuditEntry {
+	var entries []domain.AuditEntry
+	for i := 0; i < n; i++ {
+		entry := domain.AuditEntry{
+			ID:        first + uint(i),
+			CreatedAt: time.Date(2021, 7, 1, 12, 0, i, 1000, time.UTC),
+			Actor:     "admin",
+			Target:    "jelena",
+			Action:    domain.AuditRoleChanged,
+			Outcome:   domain.AuditSuccess,
+			PrevHash:  prevHash,
+		}
+		entry.Hash = entry.ComputeHash()
+		prevHash = entry.Hash
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func lines(t *testing.T, entries []domain.AuditEntry) string {
+	var builder strings.Builder
+	for _, entry := range entries {
+		bytes, err := json.Marshal(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		builder.Write(bytes)
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+func TestVerify(t *testing.T) {
+	full := export(1, 5, domain.AuditGenesisHash)
+	partial := export(3, 3, full[1].Hash)
+
+	tests := []struct {
+		name           string
+		export         func() string
+		anchor         string
+		wantErr        string
+		wantCount      int
+		wantUnanchored uint
+	}{
+		{name: "empty export", export: func() string { return "" }},
+		{name: "full chain", export: func() string { return lines(t, full) }, wantCount: 5},
+		{name: "blank lines are skipped", export: func() string { return "\n" + lines(t, full) + "\n" }, wantCount: 5},
+		{name: "partial chain with its anchor", export: func() string { return lines(t, partial) }, anchor: full[1].Hash, wantCount: 3},
+		{name: "partial chain without anchor", export: func() string { return lines(t, partial) }, wantCount: 3, wantUnanchored: 3},
+		{name: "partial chain with another anchor", export: func() string { return lines(t, partial) }, anchor: full[0].Hash, wantErr: "entry 3: does not link to the anchor"},
+		{name: "first entry not from genesis", export: func() string {
+			return lines(t, export(1, 2, full[0].Hash))
+		}, wantErr: "entry 1: does not start from the genesis hash"},
+		{name: "changed content", export: func() string {
+			entries := append([]domain.AuditEntry{}, full...)
+			entries[2].Actor = "someone else"
+			return lines(t, entries)
+		}, wantErr: "entry 3: content does not match its hash"},
+		{name: "changed content with a recomputed hash", export: func() string {
+			entries := append([]domain.AuditEntry{}, full...)
+			entries[2].Outcome = domain.AuditFailure
+			entries[2].Hash = entries[2].ComputeHash()
+			return lines(t, entries)
+		}, wantErr: "entry 4: does not link to entry 3"},
+		{name: "removed entry", export: func() string {
+			return lines(t, append(append([]domain.AuditEntry{}, full[:2]...), full[3:]...))
+		}, wantErr: "entry 4: follows entry 2, entries are missing"},
+		{name: "not an entry", export: func() string { return lines(t, full[:1]) + "not json\n" }, wantErr: "line 2: not an audit entry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := verify(strings.NewReader(tt.export()), tt.anchor)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if result.count != tt.wantCount || result.unanchored != tt.wantUnanchored {
+				t.Errorf("count, unanchored = %v, %v, want %v, %v", result.count, result.unanchored, tt.wantCount, tt.wantUnanchored)
+			}
+		})
+	}
+}
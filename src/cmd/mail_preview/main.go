@@ -0,0 +1,95 @@
+// Command mail_preview renders an embedded mail template with sample data.
+package main
+
+import (
+	"auth-service/assets/mail_template"
+	"auth-service/infrastructure/mailer"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+)
+
+func main() {
+	list := flag.Bool("list", false, "list templates with their versions and locales")
+	name := flag.String("template", "", "template name")
+	locale := flag.String("locale", mail_template.DefaultLocale, "locale or Accept-Language header")
+	version := flag.Int("version", 0, "template version, latest when 0")
+	format := flag.String("format", "html", "output format: html, text or eml")
+	data := flag.String("data", "", "JSON file overriding the sample data")
+	flag.Parse()
+
+	registry, err := mail_template.NewRegistry()
+	if err != nil {
+		fail("error while loading mail templates: %v", err)
+	}
+
+	if *list {
+		for _, n := range registry.Names() {
+			fmt.Printf("%s\tversions %v\tlocales %s\n", n, registry.Versions(n), strings.Join(registry.Locales(), ", "))
+		}
+		return
+	}
+
+	sample, ok := mail_template.Samples[*name]
+	if !ok {
+		fail("unknown template %q, use -list to see the available ones", *name)
+	}
+	if *data != "" {
+		sample = loadData(*data, sample)
+	}
+
+	matched := registry.MatchLocale(*locale)
+	var rendered *mail_template.Rendered
+	if *version == 0 {
+		rendered, err = registry.Render(*name, matched, sample)
+	} else {
+		rendered, err = registry.RenderVersion(*name, *version, matched, sample)
+	}
+	if err != nil {
+		fail("error while rendering %v: %v", *name, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "%s v%d (%s): %s\n", rendered.Name, rendered.Version, rendered.Locale, rendered.Subject)
+	switch *format {
+	case "html":
+		fmt.Print(rendered.Html)
+	case "text":
+		fmt.Print(rendered.Text)
+	case "eml":
+		message := mailer.Message{
+			From:     "preview@localhost",
+			To:       []string{"recipient@localhost"},
+			Subject:  rendered.Subject,
+			TextBody: rendered.Text,
+			HtmlBody: rendered.Html,
+		}
+		os.Stdout.Write(message.Bytes())
+	default:
+		fail("unknown format %q", *format)
+	}
+}
+
+// loadData decodes a JSON file into a value of the same type as the sample.
+func loadData(file string, sample interface{}) interface{} {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		fail("error while reading %v: %v", file, err)
+	}
+
+	value := reflect.New(reflect.TypeOf(sample))
+	value.Elem().Set(reflect.ValueOf(sample))
+	if err := json.Unmarshal(content, value.Interface()); err != nil {
+		fail("error while decoding %v: %v", file, err)
+	}
+
+	return value.Elem().Interface()
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
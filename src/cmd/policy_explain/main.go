@@ -0,0 +1,164 @@
+// Command policy_explain explains and diffs policy files against the routes the service serves.
+package main
+
+import (
+	"auth-service/domain"
+	helper2 "auth-service/grpc/helper"
+	"auth-service/grpc/server/authentication_server"
+	router2 "auth-service/http/router"
+	"auth-service/infrastructure/policy"
+	interactor2 "auth-service/interactor"
+	"context"
+	"flag"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"google.golang.org/grpc"
+	"io"
+	"os"
+	"strings"
+)
+
+var models = map[string]string{
+	domain.PolicyTransportHttp: "http/middleware/rbac_model.conf",
+	domain.PolicyTransportGrpc: "grpc/interceptor/auth_interceptor/rbac_model.conf",
+}
+
+var seeds = map[string]string{
+	domain.PolicyTransportHttp: "http/middleware/rbac_policy.csv",
+	domain.PolicyTransportGrpc: "grpc/interceptor/auth_interceptor/rbac_policy.csv",
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fail("usage: policy_explain explain|diff [flags]")
+	}
+
+	switch os.Args[1] {
+	case "explain":
+		explain(os.Args[2:])
+	case "diff":
+		diff(os.Args[2:])
+	default:
+		fail("unknown command %v, use explain or diff", os.Args[1])
+	}
+}
+
+func explain(args []string) {
+	flags := flag.NewFlagSet("explain", flag.ExitOnError)
+	transport := flags.String("transport", domain.PolicyTransportHttp, "http or grpc")
+	file := flags.String("policy", "", "policy file, the seed file of the transport by default")
+	subject := flags.String("subject", "", "role or scope:<name>")
+	object := flags.String("object", "", "path or full gRPC method")
+	action := flags.String("action", "", "HTTP method, every served method by default")
+	_ = flags.Parse(args)
+
+	if *subject == "" || *object == "" {
+		fail("-subject and -object are required")
+	}
+	if *file == "" {
+		*file = seeds[*transport]
+	}
+	if *transport == domain.PolicyTransportGrpc {
+		*action = "*"
+	}
+
+	snapshot := load(*transport, *file)
+	explanations, err := policy.Explain(snapshot, domain.NormalizePolicySubject(*subject), *object, strings.ToUpper(*action), routes(*transport))
+	if err != nil {
+		fail("%v", err)
+	}
+
+	for _, explanation := range explanations {
+		decision := "denied"
+		if explanation.Allowed {
+			decision = "allowed by p, " + strings.Join(explanation.Rule, ", ")
+		}
+		fmt.Printf("%v %v %v: %v\n", explanation.Subject, explanation.Route.Action, explanation.Route.Object, decision)
+		if len(explanation.Roles) > 0 {
+			fmt.Printf("  inherits %v\n", strings.Join(explanation.Roles, ", "))
+		}
+		if !explanation.Routed {
+			fmt.Printf("  the %v transport doesn't serve this route\n", *transport)
+		}
+	}
+}
+
+func diff(args []string) {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	transport := flags.String("transport", domain.PolicyTransportHttp, "http or grpc")
+	_ = flags.Parse(args)
+
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		fail("usage: policy_explain diff [-transport http|grpc] before.csv|- [after.csv]")
+	}
+	after := seeds[*transport]
+	if flags.NArg() == 2 {
+		after = flags.Arg(1)
+	}
+
+	changes, err := policy.Diff(load(*transport, flags.Arg(0)), load(*transport, after), routes(*transport))
+	if err != nil {
+		fail("%v", err)
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("no access changes")
+		return
+	}
+	for _, change := range changes {
+		sign := "-"
+		if change.Gained {
+			sign = "+"
+		}
+		fmt.Printf("%v %v %v %v\n", sign, change.Subject, change.Route.Action, change.Route.Object)
+	}
+}
+
+func load(transport, file string) *policy.Snapshot {
+	model, ok := models[transport]
+	if !ok {
+		fail("unknown transport %v, use http or grpc", transport)
+	}
+
+	var rules io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			fail("error while opening %v: %v", file, err)
+		}
+		defer f.Close()
+		rules = f
+	}
+
+	snapshot, err := policy.LoadSnapshot(model, rules)
+	if err != nil {
+		fail("%v: %v", file, err)
+	}
+
+	return snapshot
+}
+
+// routes builds the servers the way main does and reads back their routes.
+func routes(transport string) []domain.Route {
+	var routes []domain.Route
+	if transport == domain.PolicyTransportGrpc {
+		server := grpc.NewServer()
+		authentication_server.RegisterAuthenticationServer(server, nil)
+		authentication_server.RegisterTotpServer(server, nil)
+		routes = helper2.Routes(server)
+	} else {
+		gin.SetMode(gin.ReleaseMode)
+		logger := logger.InitializeLogger("policy_explain", context.Background())
+		interactor := interactor2.NewInteractor(nil, logger, nil, nil, nil, domain.PasswordPolicy{}, nil, domain.MagicLinkConfig{}, domain.EmailChangeConfig{}, nil, domain.OutboxConfig{}, nil, domain.SecurityNotificationConfig{}, domain.BruteForceConfig{}, domain.RateLimitConfig{}, domain.ImpersonationConfig{}, domain.PdpConfig{}, nil, nil, nil, nil)
+		routes = router2.Routes(router2.NewRouter(interactor.NewAppHandler(), nil, nil, nil, logger))
+	}
+
+	policy.SortRoutes(routes)
+	return routes
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -14,6 +14,7 @@ type User struct {
 	Web string `json:"web"`
 	Bio string `json:"bio"`
 	Image string `json:"image"`
+	Locale string `json:"locale"`
 	ConfirmationCode string
 }
 
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Actions recorded in the audit log.
+const (
+	AuditRoleChanged      = "role_changed"
+	AuditAgentApproved    = "agent_approved"
+	AuditAgentDeclined    = "agent_declined"
+	AuditProfileDeleted   = "profile_deleted"
+	AuditTotpEnabled      = "totp_enabled"
+	AuditTotpDisabled     = "totp_disabled"
+	AuditPasswordReset    = "password_reset"
+	AuditPasswordChanged  = "password_changed"
+	AuditAccountLocked    = "account_locked"
+	AuditAccountSuspended = "account_suspended"
+	AuditAccountBanned    = "account_banned"
+	AuditAccountUnlocked  = "account_unlocked"
+	AuditSessionsRevoked  = "sessions_revoked"
+	AuditOutboxRedriven   = "outbox_redriven"
+	AuditLogExported      = "audit_log_exported"
+	AuditPolicyReloaded   = "policy_reloaded"
+	AuditPolicyAdded      = "policy_added"
+	AuditPolicyRemoved    = "policy_removed"
+
+	AuditRoleInheritanceAdded   = "role_inheritance_added"
+	AuditRoleInheritanceRemoved = "role_inheritance_removed"
+
+	AuditImpersonationStarted = "impersonation_started"
+	AuditImpersonationEnded   = "impersonation_ended"
+
+	AuditRoleScopeAdded     = "role_scope_added"
+	AuditRoleScopeRemoved   = "role_scope_removed"
+	AuditOAuthClientCreated = "oauth_client_created"
+	AuditOAuthClientDeleted = "oauth_client_deleted"
+
+	AuditEmailChangeNotReverted = "email_change_not_reverted"
+)
+
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
+// Actors that are not a signed in user.
+const (
+	AuditActorAnonymous = "anonymous"
+	AuditActorSystem    = "system"
+)
+
+// AuditGenesisHash is the previous hash of the first entry in the chain.
+var AuditGenesisHash = strings.Repeat("0", sha256.Size*2)
+
+// AuditEntry is one append-only record of the audit log, chained by hash.
+type AuditEntry struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	Actor     string    `json:"actor" gorm:"index"`
+	Target    string    `json:"target" gorm:"index"`
+	Action    string    `json:"action" gorm:"index"`
+	Outcome   string    `json:"outcome"`
+	Details   string    `json:"details,omitempty"`
+	RequestId string    `json:"request_id"`
+	IpAddress string    `json:"ip_address"`
+	PrevHash  string    `json:"prev_hash"`
+	Hash      string    `json:"hash" gorm:"uniqueIndex"`
+}
+
+// ComputeHash hashes every field of the entry except Hash itself.
+func (a AuditEntry) ComputeHash() string {
+	bytes, _ := json.Marshal([]interface{}{
+		a.ID,
+		a.CreatedAt.UTC().Format(time.RFC3339Nano),
+		a.Actor,
+		a.Target,
+		a.Action,
+		a.Outcome,
+		a.Details,
+		a.RequestId,
+		a.IpAddress,
+		a.PrevHash,
+	})
+
+	sum := sha256.Sum256(bytes)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestAuditEntryComputeHash(t *testing.T) {
+	entry := AuditEntry{
+		ID:        7,
+		CreatedAt: time.Date(2021, 7, 1, 12, 0, 0, 123456000, time.UTC),
+		Actor:     "admin",
+		Target:    "jelena",
+		Action:    AuditRoleChanged,
+		Outcome:   AuditSuccess,
+		Details:   "USER -> AGENT",
+		RequestId: "request",
+		IpAddress: "10.0.0.1",
+		PrevHash:  AuditGenesisHash,
+	}
+	hash := entry.ComputeHash()
+
+	tests := []struct {
+		name   string
+		change func(entry *AuditEntry)
+		same   bool
+	}{
+		{name: "hash itself is not hashed", change: func(entry *AuditEntry) { entry.Hash = "anything" }, same: true},
+		{name: "time zone does not matter", change: func(entry *AuditEntry) { entry.CreatedAt = entry.CreatedAt.In(time.FixedZone("CEST", 2*60*60)) }, same: true},
+		{name: "id", change: func(entry *AuditEntry) { entry.ID++ }},
+		{name: "created at", change: func(entry *AuditEntry) { entry.CreatedAt = entry.CreatedAt.Add(time.Microsecond) }},
+		{name: "actor", change: func(entry *AuditEntry) { entry.Actor = "someone else" }},
+		{name: "target", change: func(entry *AuditEntry) { entry.Target = "someone else" }},
+		{name: "action", change: func(entry *AuditEntry) { entry.Action = AuditProfileDeleted }},
+		{name: "outcome", change: func(entry *AuditEntry) { entry.Outcome = AuditFailure }},
+		{name: "details", change: func(entry *AuditEntry) { entry.Details = "" }},
+		{name: "request id", change: func(entry *AuditEntry) { entry.RequestId = "other" }},
+		{name: "ip address", change: func(entry *AuditEntry) { entry.IpAddress = "10.0.0.2" }},
+		{name: "previous hash", change: func(entry *AuditEntry) { entry.PrevHash = hash }},
+		{name: "fields do not run into each other", change: func(entry *AuditEntry) { entry.Actor, entry.Target = "admi", "njelena" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := entry
+			tt.change(&changed)
+			if got := changed.ComputeHash(); (got == hash) != tt.same {
+				t.Errorf("hash changed = %v, want %v", got != hash, !tt.same)
+			}
+		})
+	}
+}
+
+func TestAuditEntryHashSurvivesExport(t *testing.T) {
+	entry := AuditEntry{ID: 1, CreatedAt: time.Now().UTC().Truncate(time.Microsecond), Actor: "admin", PrevHash: AuditGenesisHash}
+	entry.Hash = entry.ComputeHash()
+
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var exported AuditEntry
+	if err := json.Unmarshal(bytes, &exported); err != nil {
+		t.Fatal(err)
+	}
+
+	if exported.ComputeHash() != entry.Hash {
+		t.Errorf("hash of the exported entry does not match")
+	}
+}
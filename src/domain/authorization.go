@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// Reasons of a policy decision.
+const (
+	AuthorizationAllowed       = "allowed"
+	AuthorizationNoRule        = "no rule matched"
+	AuthorizationInvalidToken  = "invalid, expired or revoked token"
+	AuthorizationImpersonation = "forbidden while impersonating"
+)
+
+type PdpConfig struct {
+	// DecisionTtl is how long callers may cache a decision.
+	DecisionTtl  time.Duration
+	MaxBatchSize int
+}
+
+// AuthorizationCheck asks whether a token may perform an action on an object.
+type AuthorizationCheck struct {
+	Object string
+	Action string
+}
+
+type AuthorizationDecision struct {
+	Object      string
+	Action      string
+	Allowed     bool
+	Subject     string
+	MatchedRule []string
+	Reason      string
+}
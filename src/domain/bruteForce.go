@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// BruteForceConfig limits failed attempts per subject and per source IP address.
+type BruteForceConfig struct {
+	MaxAttempts   int
+	MaxIpAttempts int
+	Window        time.Duration
+	BaseDelay     time.Duration
+	MaxDelay      time.Duration
+	Lockout       time.Duration
+}
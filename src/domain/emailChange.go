@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+type EmailChangeConfig struct {
+	Ttl       time.Duration
+	CancelUrl string
+}
+
+type EmailChangeRequest struct {
+	NewEmail        string `json:"new_email"`
+	CodeHash        string `json:"code_hash"`
+	CancelTokenHash string `json:"cancel_token_hash"`
+}
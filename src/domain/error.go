@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Error kinds, each with its own HTTP status and gRPC code.
+const (
+	ErrorInvalidArgument   = "invalid_argument"
+	ErrorUnauthenticated   = "unauthenticated"
+	ErrorPermissionDenied  = "permission_denied"
+	ErrorNotFound          = "not_found"
+	ErrorResourceExhausted = "resource_exhausted"
+	ErrorInternal          = "internal"
+)
+
+// ErrorDomain is the domain of the reasons below.
+const ErrorDomain = "auth-service"
+
+// Reasons are stable identifiers clients can rely on, unlike messages.
+const (
+	ReasonInvalidArgument    = "INVALID_ARGUMENT"
+	ReasonNotFound           = "NOT_FOUND"
+	ReasonInternal           = "INTERNAL"
+	ReasonMissingToken       = "MISSING_TOKEN"
+	ReasonInvalidToken       = "INVALID_TOKEN"
+	ReasonInvalidCredentials = "INVALID_CREDENTIALS"
+	ReasonInvalidPasscode    = "INVALID_PASSCODE"
+	ReasonInvalidLink        = "INVALID_LINK"
+	ReasonForbidden          = "FORBIDDEN"
+	ReasonImpersonation      = "IMPERSONATION_FORBIDDEN"
+	ReasonAccessDenied       = "ACCESS_DENIED"
+	ReasonAccountSuspended   = "ACCOUNT_SUSPENDED"
+	ReasonAccountBanned      = "ACCOUNT_BANNED"
+	ReasonAccountLocked      = "ACCOUNT_LOCKED"
+	ReasonPasswordPolicy     = "PASSWORD_POLICY"
+	ReasonTooManyAttempts    = "TOO_MANY_ATTEMPTS"
+	ReasonRateLimited        = "RATE_LIMITED"
+)
+
+// FieldViolation tells which field of a request is invalid and why.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Error is an error both transports report the same way.
+type Error struct {
+	Kind    string
+	Reason  string
+	Message string
+	// Metadata is added to the reason, like the end of a suspension.
+	Metadata   map[string]string
+	Violations []FieldViolation
+	// RetryAfter is set for resource_exhausted errors.
+	RetryAfter time.Duration
+	cause      error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// ErrorDescriber is implemented by errors that describe themselves as an Error.
+type ErrorDescriber interface {
+	DescribeError() *Error
+}
+
+func InvalidArgument(message string, violations ...FieldViolation) *Error {
+	return &Error{Kind: ErrorInvalidArgument, Reason: ReasonInvalidArgument, Message: message, Violations: violations}
+}
+
+func NotFound(message string) *Error {
+	return &Error{Kind: ErrorNotFound, Reason: ReasonNotFound, Message: message}
+}
+
+func Unauthenticated(reason, message string) *Error {
+	return &Error{Kind: ErrorUnauthenticated, Reason: reason, Message: message}
+}
+
+func PermissionDenied(reason, message string) *Error {
+	return &Error{Kind: ErrorPermissionDenied, Reason: reason, Message: message}
+}
+
+func ResourceExhausted(reason, message string, retryAfter time.Duration) *Error {
+	return &Error{Kind: ErrorResourceExhausted, Reason: reason, Message: message, RetryAfter: retryAfter}
+}
+
+// Internal hides the cause from clients, it is only logged.
+func Internal(cause error) *Error {
+	return &Error{Kind: ErrorInternal, Reason: ReasonInternal, Message: "internal error", cause: cause}
+}
+
+// AsError classifies any error; unclassified ones are internal.
+func AsError(err error) *Error {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+
+	var describer ErrorDescriber
+	if errors.As(err, &describer) {
+		return describer.DescribeError()
+	}
+
+	return Internal(err)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// ImpersonationSubject is the casbin subject impersonated requests must also be allowed for.
+const ImpersonationSubject = "IMPERSONATION"
+
+// ImpersonationClaim holds the id of the admin acting as the token's user.
+const ImpersonationClaim = "act"
+
+type ImpersonationConfig struct {
+	Ttl time.Duration
+}
+
+type Impersonation struct {
+	TokenUuid   string
+	AccessToken string
+	UserId      string
+	ActorId     string
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// KnownDevice is a device and IP address pair a user has logged in from.
+type KnownDevice struct {
+	gorm.Model
+	ProfileInfoId string `gorm:"index"`
+	Fingerprint   string
+	IpAddress     string
+	LastSeenAt    time.Time
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// Methods a login step can be completed with.
+const (
+	LoginMethodPassword  = "password"
+	LoginMethodMagicLink = "magic_link"
+	LoginMethodTotp      = "totp"
+)
+
+const (
+	MfaNone = "none"
+	MfaTotp = "totp"
+)
+
+// Reasons a login step did not end with a session.
+const (
+	LoginUnknownUser      = "unknown_user"
+	LoginInvalidPassword  = "invalid_password"
+	LoginInvalidTotp      = "invalid_totp"
+	LoginAccountLocked    = "account_locked"
+	LoginAccountSuspended = "account_suspended"
+	LoginAccountBanned    = "account_banned"
+	LoginThrottled        = "throttled"
+	LoginMfaChallenge     = "mfa_challenge"
+	LoginTokenError       = "token_error"
+)
+
+// LoginStatusReasons maps an account status to the reason a login was refused.
+var LoginStatusReasons = map[string]string{
+	AccountLocked:    LoginAccountLocked,
+	AccountSuspended: LoginAccountSuspended,
+	AccountBanned:    LoginAccountBanned,
+}
+
+type LoginEvent struct {
+	gorm.Model
+	ProfileInfoId string `json:"profile_info_id" gorm:"index"`
+	Username      string `json:"username" gorm:"index"`
+	Success       bool   `json:"success"`
+	Reason        string `json:"reason,omitempty"`
+	Method        string `json:"method"`
+	MfaMethod     string `json:"mfa_method"`
+	IpAddress     string `json:"ip_address"`
+	UserAgent     string `json:"user_agent"`
+	ClientId      string `json:"client_id,omitempty"`
+}
+
+type LoginEventFilter struct {
+	ProfileInfoId string
+	Username      string
+	IpAddress     string
+	Success       *bool
+	Since         time.Time
+	Until         time.Time
+	Limit         int
+}
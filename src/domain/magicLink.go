@@ -0,0 +1,9 @@
+package domain
+
+import "time"
+
+type MagicLinkConfig struct {
+	Enabled bool
+	Ttl     time.Duration
+	LinkUrl string
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// OAuthClientSessionPrefix indexes a client's tokens like a user's sessions.
+const OAuthClientSessionPrefix = "client:"
+
+// OAuthClient gets tokens with the client credentials grant; only its secret's hash is stored.
+type OAuthClient struct {
+	ID         string `gorm:"primaryKey"`
+	Name       string
+	SecretHash string
+	Scopes     []Scope `gorm:"many2many:oauth_client_scopes;"`
+	CreatedBy  string
+	CreatedAt  time.Time
+}
+
+func (c OAuthClient) ScopeNames() []string {
+	names := make([]string, 0, len(c.Scopes))
+	for _, scope := range c.Scopes {
+		names = append(names, scope.Name)
+	}
+	return names
+}
+
+func (c OAuthClient) SessionId() string {
+	return OAuthClientSessionPrefix + c.ID
+}
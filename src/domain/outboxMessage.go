@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	OutboxPending    = "pending"
+	OutboxSent       = "sent"
+	OutboxDead       = "dead"
+	OutboxSuperseded = "superseded"
+)
+
+type OutboxMessage struct {
+	gorm.Model
+	Category      string `json:"category" gorm:"index"`
+	Recipient     string `json:"recipient" gorm:"index"`
+	Subject       string `json:"subject"`
+	TextBody      string `json:"-"`
+	HtmlBody      string `json:"-"`
+	Status        string `json:"status" gorm:"index"`
+	Attempts      int    `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at" gorm:"index"`
+	LastError     string `json:"last_error"`
+	SentAt        *time.Time `json:"sent_at"`
+}
+
+type OutboxConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+}
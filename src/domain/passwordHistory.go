@@ -0,0 +1,10 @@
+package domain
+
+import "gorm.io/gorm"
+
+type PasswordHistory struct {
+	gorm.Model
+	ProfileInfo ProfileInfo
+	ProfileInfoId string
+	Password string
+}
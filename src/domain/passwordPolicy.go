@@ -0,0 +1,16 @@
+package domain
+
+type PasswordPolicy struct {
+	MinLength      int
+	MaxLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+	ForbidUsername bool
+	ForbidEmail    bool
+	HistoryDepth   int
+	MaxAgeDays     int
+
+	BreachedPasswordsFile string
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Transports with their own policy, and the resource policy checked by usecases.
+const (
+	PolicyTransportHttp     = "http"
+	PolicyTransportGrpc     = "grpc"
+	PolicyTransportResource = "resource"
+)
+
+// Casbin rule types, p for permissions and g for role inheritance.
+const (
+	PolicyTypePermission  = "p"
+	PolicyTypeInheritance = "g"
+)
+
+// PolicyRule is one line of a transport's casbin policy as stored in the database.
+type PolicyRule struct {
+	ID        uint   `gorm:"primaryKey"`
+	Transport string `gorm:"uniqueIndex:idx_policy_rule"`
+	Ptype     string `gorm:"uniqueIndex:idx_policy_rule"`
+	V0        string `gorm:"uniqueIndex:idx_policy_rule"`
+	V1        string `gorm:"uniqueIndex:idx_policy_rule"`
+	V2        string `gorm:"uniqueIndex:idx_policy_rule"`
+	V3        string `gorm:"uniqueIndex:idx_policy_rule"`
+	V4        string `gorm:"uniqueIndex:idx_policy_rule"`
+	V5        string `gorm:"uniqueIndex:idx_policy_rule"`
+}
+
+// Values returns the rule's values without the trailing empty ones.
+func (r PolicyRule) Values() []string {
+	values := []string{r.V0, r.V1, r.V2, r.V3, r.V4, r.V5}
+	for len(values) > 0 && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+
+	return values
+}
+
+// Policy allows a role an action on a path, a gRPC method or a resource type.
+type Policy struct {
+	Subject   string
+	Object    string
+	Action    string
+	Condition string
+}
+
+// Values returns the rule's values, the condition only for resource rules.
+func (p Policy) Values() []string {
+	if p.Condition == "" {
+		return []string{p.Subject, p.Object, p.Action}
+	}
+
+	return []string{p.Subject, p.Object, p.Action, p.Condition}
+}
+
+// RoleInheritance gives a role everything its parent role is allowed to do.
+type RoleInheritance struct {
+	Role   string
+	Parent string
+}
+
+// Route is an HTTP route or a gRPC method a transport serves.
+type Route struct {
+	Object string
+	Action string
+}
+
+// PolicyExplanation tells why a subject is allowed or denied a route.
+type PolicyExplanation struct {
+	Subject string
+	// Roles are the roles the subject inherits from, nearest first.
+	Roles []string
+	Route Route
+	// Routed is false when the transport doesn't serve the route.
+	Routed  bool
+	Allowed bool
+	// Rule is the policy rule that allowed the route.
+	Rule []string
+}
+
+// AccessChange is a subject that gained or lost a route between two policies.
+type AccessChange struct {
+	Subject string
+	Route   Route
+	Gained  bool
+}
+
+// NormalizePolicySubject upper-cases a role and lower-cases a scope subject.
+func NormalizePolicySubject(subject string) string {
+	if IsScopeSubject(subject) {
+		return strings.ToLower(subject)
+	}
+
+	return strings.ToUpper(subject)
+}
+
+// PolicyMigration records a policy migration applied to the stored rules.
+type PolicyMigration struct {
+	Version   string `gorm:"primaryKey"`
+	Transport string `gorm:"primaryKey"`
+	AppliedAt time.Time
+}
@@ -2,9 +2,18 @@ package domain
 
 import (
 	"gorm.io/gorm"
+	"sort"
+	"strings"
 	"time"
 )
 
+const (
+	AccountActive    = "active"
+	AccountSuspended = "suspended"
+	AccountBanned    = "banned"
+	AccountLocked    = "locked"
+)
+
 type ProfileInfo struct {
 	ID string `json:"id" ,gorm:"primarykey"`
 	CreatedAt time.Time
@@ -13,6 +22,61 @@ type ProfileInfo struct {
 	Username string `json:"username" ,gorm:"unique"`
 	Email string `json:"email" ,gorm:"unique"`
 	Password string `json:"password"`
-	Role Role
-	RoleId uint
+	PasswordChangedAt time.Time
+	Locale string `json:"locale"`
+	// LockedAt blocks logins until the password is reset.
+	LockedAt *time.Time `json:"-"`
+	// SuspendedUntil and BannedAt are set by admins, StatusReason says why.
+	SuspendedUntil *time.Time `json:"-"`
+	BannedAt *time.Time `json:"-"`
+	StatusReason string `json:"-"`
+	Roles []Role `gorm:"many2many:profile_info_roles;"`
+}
+
+// RoleNames returns the account's role names in creation order.
+func (p ProfileInfo) RoleNames() []string {
+	roles := make([]Role, len(p.Roles))
+	copy(roles, p.Roles)
+	sort.Slice(roles, func(i, j int) bool { return roles[i].ID < roles[j].ID })
+
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.RoleName)
+	}
+	return names
+}
+
+// PrimaryRole is the first of RoleNames, for clients that know a single role.
+func (p ProfileInfo) PrimaryRole() string {
+	if names := p.RoleNames(); len(names) > 0 {
+		return names[0]
+	}
+	return ""
+}
+
+func (p ProfileInfo) HasRole(roleName string) bool {
+	for _, role := range p.Roles {
+		if strings.EqualFold(role.RoleName, roleName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p ProfileInfo) IsLocked() bool {
+	return p.LockedAt != nil
+}
+
+// Status returns the most restrictive state of the account.
+func (p ProfileInfo) Status() string {
+	switch {
+	case p.BannedAt != nil:
+		return AccountBanned
+	case p.SuspendedUntil != nil && p.SuspendedUntil.After(time.Now()):
+		return AccountSuspended
+	case p.IsLocked():
+		return AccountLocked
+	default:
+		return AccountActive
+	}
 }
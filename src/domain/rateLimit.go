@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Keys a rate limit rule can count requests by.
+const (
+	RateLimitByIp    = "ip"
+	RateLimitByUser  = "user"
+	RateLimitByEmail = "email"
+)
+
+// RateLimitRule allows Limit requests per Window to a gin route or gRPC method.
+type RateLimitRule struct {
+	Route  string
+	Method string
+	Key    string
+	Limit  int
+	Window time.Duration
+}
+
+type RateLimitConfig struct {
+	Enabled bool
+	Rules   []RateLimitRule
+}
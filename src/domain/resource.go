@@ -0,0 +1,23 @@
+package domain
+
+// Resource types and actions of the resource policy.
+const (
+	ResourceProfile = "profile"
+
+	ResourceActionDelete = "delete"
+)
+
+// AccessSubject is the caller of a request as the resource policy sees it.
+type AccessSubject struct {
+	Id       string
+	Subjects []string
+	// Status is the account status, empty for callers without an account.
+	Status string
+}
+
+// Resource is what a request acts on; Owner is the id of the owning account.
+type Resource struct {
+	Type  string
+	Id    string
+	Owner string
+}
@@ -2,7 +2,15 @@ package domain
 
 import "gorm.io/gorm"
 
+// Roles created by the seeder.
+const (
+	RoleAdmin = "admin"
+	RoleAgent = "agent"
+	RoleUser  = "user"
+)
+
 type Role struct {
 	gorm.Model
 	RoleName string `json:"role_name" ,gorm:"unique"`
+	Scopes []Scope `gorm:"many2many:role_scopes;"`
 }
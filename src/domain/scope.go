@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"gorm.io/gorm"
+	"strings"
+)
+
+// ScopeClaim is the token claim holding the space separated granted scopes.
+const ScopeClaim = "scope"
+
+// ScopeSubjectPrefix marks policy subjects that name a scope instead of a role.
+const ScopeSubjectPrefix = "scope:"
+
+// Scopes created by the seeder, each required by some policy rule.
+const (
+	ScopeProfileWrite = "profile:write"
+	ScopeTotpManage   = "totp:manage"
+	ScopeAgentReview  = "agent:review"
+	ScopeActivityRead = "activity:read"
+	ScopePolicyDecide = "policy:decide"
+)
+
+// RetiredScopes were seeded by earlier releases although no rule required them.
+var RetiredScopes = []string{"profile:read", "token:validate"}
+
+// Scope is a named permission that roles and OAuth clients can be granted.
+type Scope struct {
+	gorm.Model
+	Name        string `json:"name" gorm:"uniqueIndex"`
+	Description string `json:"description"`
+}
+
+func ScopeSubject(scope string) string {
+	return ScopeSubjectPrefix + scope
+}
+
+func IsScopeSubject(subject string) bool {
+	return strings.HasPrefix(strings.ToLower(subject), ScopeSubjectPrefix)
+}
+
+// JoinScopes formats scopes as the value of the scope claim.
+func JoinScopes(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
+
+func SplitScopes(scope string) []string {
+	return strings.Fields(scope)
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+const (
+	SecurityEventNewDeviceLogin  = "new_device_login"
+	SecurityEventPasswordChanged = "password_changed"
+	SecurityEventPasswordReset   = "password_reset"
+	SecurityEventTotpEnabled     = "totp_enabled"
+	SecurityEventTotpDisabled    = "totp_disabled"
+	SecurityEventEmailChanged    = "email_changed"
+	SecurityEventSessionsRevoked = "sessions_revoked"
+)
+
+type SecurityEvent struct {
+	Type          string
+	ProfileInfoId string
+	IpAddress     string
+	UserAgent     string
+	Locale        string
+	OccurredAt    time.Time
+	// PreviousEmail is set for email changes so the old address is notified too.
+	PreviousEmail string
+}
+
+type SecurityNotificationConfig struct {
+	Enabled bool
+	LockUrl string
+	LockTtl time.Duration
+}
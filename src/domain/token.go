@@ -6,6 +6,7 @@ type AccessDetails struct {
 }
 
 type TokenDetails struct {
+	UserId       string
 	AccessToken  string
 	RefreshToken string
 	TokenUuid    string
@@ -19,3 +20,18 @@ type TemporaryTokenDetails struct {
 	TokenUuid   string
 	Expires     int64
 }
+
+const (
+	RoleTemporaryUser   = "temporary_user"
+	RolePasswordExpired = "password_expired"
+)
+
+// Session is what a login hands back to the client, whatever the transport.
+type Session struct {
+	UserId      string
+	Username    string
+	Role        string
+	Roles       []string
+	TokenUuid   string
+	RefreshUuid string
+}
@@ -15,6 +15,7 @@ type userGateway struct {
 
 type UserGateway interface {
 	SaveRegisteredUser(context context.Context, user *domain.User) error
+	UpdateUserEmail(context context.Context, userId, email string) error
 }
 
 func NewUserGateway(resty *resty.Client) UserGateway {
@@ -38,3 +39,27 @@ func (u *userGateway) SaveRegisteredUser(context context.Context, user *domain.U
 
 	return nil
 }
+
+func (u *userGateway) UpdateUserEmail(context context.Context, userId, email string) error {
+	json, err := json.Marshal(struct {
+		ID    string `json:"id"`
+		Email string `json:"email"`
+	}{userId, email})
+	domain := os.Getenv("USER_DOMAIN")
+	if domain == "" {
+		domain = "127.0.0.1"
+	}
+	if err != nil {
+		return err
+	}
+	response, err := u.RestyClient.R().SetBody(json).Post("https://" + domain + ":8082/updateUserEmail")
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != 200 {
+		return errors.New("Updating user email failed")
+	}
+
+	return nil
+}
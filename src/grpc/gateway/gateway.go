@@ -0,0 +1,143 @@
+package gateway
+
+import (
+	"auth-service/grpc/helper"
+	"auth-service/grpc/server/authentication_server"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"github.com/golang/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// Prefix is the path every gateway route is served under.
+const Prefix = "/v1/"
+
+// OpenApiPath serves the OpenAPI v2 document generated from the protos.
+const OpenApiPath = Prefix + "openapi.json"
+
+//go:embed authentication.swagger.json
+var openApi []byte
+
+// MagicLinkCookie keeps the nonce of a magic link from the browser's scripts.
+const MagicLinkCookie = "magic_link_nonce"
+
+type magicLinkNonce struct{}
+
+// forwardedHeaders are passed to the gRPC services as metadata, User-Agent goes as grpcgateway-user-agent.
+var forwardedHeaders = map[string]bool{
+	"Accept-Language": true,
+	"X-Client-Id":     true,
+	"X-Request-Id":    true,
+}
+
+// NewHandler serves the REST mapping of the services through the gRPC server at endpoint.
+func NewHandler(ctx context.Context, endpoint string) (http.Handler, error) {
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(headerMatcher), runtime.WithProtoErrorHandler(errorHandler),
+		runtime.WithMetadata(magicLinkMetadata), runtime.WithForwardResponseOption(magicLinkResponse))
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if err := authentication_server.RegisterAuthenticationHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
+		return nil, err
+	}
+	if err := authentication_server.RegisterTotpHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
+		return nil, err
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == OpenApiPath && r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(openApi)
+			return
+		}
+
+		// clients may send a bearer token, the services expect the bare uuid
+		if token := r.Header.Get("Authorization"); token != "" {
+			r.Header.Set("Authorization", strings.Trim(strings.TrimPrefix(token, "Bearer "), "\""))
+		}
+		if cookie, err := r.Cookie(MagicLinkCookie); err == nil && cookie.Value != "" {
+			r = r.WithContext(context.WithValue(r.Context(), magicLinkNonce{}, cookie.Value))
+		}
+		mux.ServeHTTP(w, r)
+	}), nil
+}
+
+// magicLinkMetadata passes the nonce cookie to MagicLogin.
+func magicLinkMetadata(ctx context.Context, r *http.Request) metadata.MD {
+	if nonce, ok := r.Context().Value(magicLinkNonce{}).(string); ok {
+		return metadata.Pairs(helper.MagicLinkNonceKey, nonce)
+	}
+
+	return nil
+}
+
+// magicLinkResponse moves the nonce into an HttpOnly cookie and drops it after login.
+func magicLinkResponse(ctx context.Context, w http.ResponseWriter, response proto.Message) error {
+	cookie := &http.Cookie{
+		Name:     MagicLinkCookie,
+		Path:     Prefix + "magicLink",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	}
+
+	switch response := response.(type) {
+	case *authentication_server.MagicLinkResponse:
+		cookie.Value = response.Nonce
+		response.Nonce = ""
+	case *authentication_server.LoginResponse:
+		if _, ok := ctx.Value(magicLinkNonce{}).(string); !ok {
+			return nil
+		}
+		cookie.MaxAge = -1
+	default:
+		return nil
+	}
+
+	http.SetCookie(w, cookie)
+	return nil
+}
+
+func headerMatcher(key string) (string, bool) {
+	if forwardedHeaders[textproto.CanonicalMIMEHeaderKey(key)] {
+		return strings.ToLower(key), true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+type fieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+type errorBody struct {
+	Message    string            `json:"message"`
+	Reason     string            `json:"reason,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	Violations []fieldViolation  `json:"violations,omitempty"`
+}
+
+// errorHandler responds with the body of the gin handlers' errorResponse.
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+	domainErr := helper.Error(st)
+
+	body := errorBody{Message: domainErr.Message, Reason: domainErr.Reason, Metadata: domainErr.Metadata}
+	for _, violation := range domainErr.Violations {
+		body.Violations = append(body.Violations, fieldViolation{Field: violation.Field, Description: violation.Description})
+	}
+	if domainErr.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(domainErr.RetryAfter.Seconds())+1))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(runtime.HTTPStatusFromCode(st.Code()))
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,176 @@
+package gateway
+
+import (
+	"auth-service/domain"
+	"auth-service/grpc/helper"
+	pb "auth-service/grpc/server/authentication_server"
+	clientInfo "auth-service/helper"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// authenticationServer answers the RPCs the way the real services would, echoing what reached it.
+type authenticationServer struct {
+	pb.UnimplementedAuthenticationServer
+}
+
+func (authenticationServer) Login(ctx context.Context, in *pb.LoginCredentials) (*pb.LoginResponse, error) {
+	switch in.Username {
+	case "locked":
+		return nil, helper.Status(domain.ResourceExhausted(domain.ReasonTooManyAttempts, "too many attempts", 10*time.Second)).Err()
+	case "":
+		return nil, helper.Status(domain.InvalidArgument("username is required", domain.FieldViolation{Field: "username", Description: "username is required"})).Err()
+	}
+
+	headers, _ := metadata.FromIncomingContext(ctx)
+	_, userAgent := clientInfo.ClientInfoFromContext(ctx)
+	return &pb.LoginResponse{Username: in.Username, AccessToken: strings.Join(headers["authorization"], ","), Role: userAgent}, nil
+}
+
+func (authenticationServer) SendMagicLink(ctx context.Context, in *pb.MagicLinkRequest) (*pb.MagicLinkResponse, error) {
+	return &pb.MagicLinkResponse{Nonce: "nonce-of-" + in.Email}, nil
+}
+
+func (authenticationServer) MagicLogin(ctx context.Context, in *pb.MagicLoginRequest) (*pb.LoginResponse, error) {
+	headers, _ := metadata.FromIncomingContext(ctx)
+	return &pb.LoginResponse{Username: strings.Join(headers[helper.MagicLinkNonceKey], ",")}, nil
+}
+
+func newGateway(t *testing.T) http.Handler {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	pb.RegisterAuthenticationServer(server, authenticationServer{})
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	handler, err := NewHandler(ctx, lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return handler
+}
+
+func post(handler http.Handler, path, body string, header http.Header, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	for key, values := range header {
+		r.Header[key] = values
+	}
+	for _, cookie := range cookies {
+		r.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func TestGatewayRoutesToTheService(t *testing.T) {
+	handler := newGateway(t)
+	if err := clientInfo.SetTrustedProxies([]string{"127.0.0.1"}); err != nil {
+		t.Fatal(err)
+	}
+	defer clientInfo.SetTrustedProxies(nil)
+
+	w := post(handler, "/v1/login", `{"username":"jelena","password":"secret"}`,
+		http.Header{"Authorization": {`Bearer "token-uuid"`}, "User-Agent": {"curl/7.79"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %v, body %v", w.Code, w.Body)
+	}
+
+	var response pb.LoginResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Username != "jelena" {
+		t.Errorf("username = %q", response.Username)
+	}
+	if response.AccessToken != "token-uuid" {
+		t.Errorf("service got authorization %q, want the bare uuid", response.AccessToken)
+	}
+	if response.Role != "curl/7.79" {
+		t.Errorf("service got user agent %q", response.Role)
+	}
+
+	if w := post(handler, "/v1/unknown", `{}`, nil); w.Code != http.StatusNotImplemented {
+		t.Errorf("unknown route: status = %v", w.Code)
+	}
+}
+
+func TestGatewayErrors(t *testing.T) {
+	handler := newGateway(t)
+
+	tests := []struct {
+		name           string
+		body           string
+		wantStatus     int
+		wantReason     string
+		wantViolations int
+		wantRetryAfter string
+	}{
+		{name: "invalid argument", body: `{"password":"secret"}`, wantStatus: http.StatusBadRequest, wantReason: domain.ReasonInvalidArgument, wantViolations: 1},
+		{name: "too many attempts", body: `{"username":"locked"}`, wantStatus: http.StatusTooManyRequests, wantReason: domain.ReasonTooManyAttempts, wantRetryAfter: "11"},
+		{name: "malformed body", body: `{"username":`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := post(handler, "/v1/login", tt.body, nil)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %v, want %v", w.Code, tt.wantStatus)
+			}
+
+			var body errorBody
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body %v isn't an error response: %v", w.Body, err)
+			}
+			if body.Message == "" || body.Reason != tt.wantReason || len(body.Violations) != tt.wantViolations {
+				t.Errorf("body = %+v", body)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+		})
+	}
+}
+
+func TestGatewayMagicLinkCookie(t *testing.T) {
+	handler := newGateway(t)
+
+	w := post(handler, "/v1/magicLink", `{"email":"jelena@example.com"}`, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %v, body %v", w.Code, w.Body)
+	}
+	if strings.Contains(w.Body.String(), "nonce-of-") {
+		t.Errorf("nonce leaked into the body %v", w.Body)
+	}
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != MagicLinkCookie || !cookies[0].HttpOnly || cookies[0].Value != "nonce-of-jelena@example.com" {
+		t.Fatalf("cookies = %v", cookies)
+	}
+
+	w = post(handler, "/v1/magicLink/login", `{"token":"t"}`, nil, cookies[0])
+	var response pb.LoginResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Username != cookies[0].Value {
+		t.Errorf("service got nonce %q, want the cookie's", response.Username)
+	}
+	if cleared := w.Result().Cookies(); len(cleared) != 1 || cleared[0].MaxAge >= 0 {
+		t.Errorf("cookie wasn't cleared after login: %v", cleared)
+	}
+}
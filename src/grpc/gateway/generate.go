@@ -0,0 +1,10 @@
+package gateway
+
+// Generated from authentication.proto with protoc v3.17.3 and these plugins:
+//
+//	go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.26.0
+//	go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.1.0
+//	go install github.com/grpc-ecosystem/grpc-gateway/protoc-gen-grpc-gateway@v1.16.0
+//	go install github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger@v1.16.0
+
+//go:generate protoc -I ../proto --go_out=../server/authentication_server --go_opt=paths=source_relative --go-grpc_out=../server/authentication_server --go-grpc_opt=paths=source_relative --grpc-gateway_out=../server/authentication_server --grpc-gateway_opt=paths=source_relative --swagger_out=logtostderr=true:. authentication.proto
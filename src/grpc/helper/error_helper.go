@@ -0,0 +1,88 @@
+package helper
+
+import (
+	"auth-service/domain"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var errorCodes = map[string]codes.Code{
+	domain.ErrorInvalidArgument:   codes.InvalidArgument,
+	domain.ErrorUnauthenticated:   codes.Unauthenticated,
+	domain.ErrorPermissionDenied:  codes.PermissionDenied,
+	domain.ErrorNotFound:          codes.NotFound,
+	domain.ErrorResourceExhausted: codes.ResourceExhausted,
+	domain.ErrorInternal:          codes.Internal,
+}
+
+// Status converts an error to the status clients get.
+func Status(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	domainErr := domain.AsError(err)
+	code, ok := errorCodes[domainErr.Kind]
+	if !ok {
+		code = codes.Internal
+	}
+
+	details := []proto.Message{&errdetails.ErrorInfo{
+		Reason:   domainErr.Reason,
+		Domain:   domain.ErrorDomain,
+		Metadata: domainErr.Metadata,
+	}}
+	if len(domainErr.Violations) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(domainErr.Violations))
+		for _, violation := range domainErr.Violations {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: violation.Field, Description: violation.Description})
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	if domainErr.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(domainErr.RetryAfter)})
+	}
+
+	st := status.New(code, domainErr.Message)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+var errorKinds = map[codes.Code]string{
+	codes.InvalidArgument:   domain.ErrorInvalidArgument,
+	codes.Unauthenticated:   domain.ErrorUnauthenticated,
+	codes.PermissionDenied:  domain.ErrorPermissionDenied,
+	codes.NotFound:          domain.ErrorNotFound,
+	codes.ResourceExhausted: domain.ErrorResourceExhausted,
+	codes.Internal:          domain.ErrorInternal,
+}
+
+// Error reads back the error a status was made from by Status.
+func Error(st *status.Status) *domain.Error {
+	kind, ok := errorKinds[st.Code()]
+	if !ok {
+		kind = domain.ErrorInternal
+	}
+
+	domainErr := &domain.Error{Kind: kind, Message: st.Message()}
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			domainErr.Reason = detail.Reason
+			domainErr.Metadata = detail.Metadata
+		case *errdetails.BadRequest:
+			for _, violation := range detail.FieldViolations {
+				domainErr.Violations = append(domainErr.Violations, domain.FieldViolation{Field: violation.Field, Description: violation.Description})
+			}
+		case *errdetails.RetryInfo:
+			domainErr.RetryAfter = detail.RetryDelay.AsDuration()
+		}
+	}
+
+	return domainErr
+}
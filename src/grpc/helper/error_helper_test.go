@@ -0,0 +1,97 @@
+package helper
+
+import (
+	"auth-service/domain"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type lockedError struct{}
+
+func (lockedError) Error() string {
+	return "account is locked"
+}
+
+func (lockedError) DescribeError() *domain.Error {
+	return &domain.Error{Kind: domain.ErrorPermissionDenied, Reason: domain.ReasonAccountLocked, Message: "account is locked", Metadata: map[string]string{"until": "2021-06-01T00:00:00Z"}}
+}
+
+func TestStatusRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+		want     *domain.Error
+	}{
+		{
+			name: "invalid argument with violations", err: domain.InvalidArgument("invalid request", domain.FieldViolation{Field: "email", Description: "email is required"}),
+			wantCode: codes.InvalidArgument,
+			want:     &domain.Error{Kind: domain.ErrorInvalidArgument, Reason: domain.ReasonInvalidArgument, Message: "invalid request", Violations: []domain.FieldViolation{{Field: "email", Description: "email is required"}}},
+		},
+		{
+			name: "unauthenticated", err: domain.Unauthenticated(domain.ReasonInvalidToken, "token is invalid"),
+			wantCode: codes.Unauthenticated,
+			want:     &domain.Error{Kind: domain.ErrorUnauthenticated, Reason: domain.ReasonInvalidToken, Message: "token is invalid"},
+		},
+		{
+			name: "permission denied", err: domain.PermissionDenied(domain.ReasonForbidden, "forbidden"),
+			wantCode: codes.PermissionDenied,
+			want:     &domain.Error{Kind: domain.ErrorPermissionDenied, Reason: domain.ReasonForbidden, Message: "forbidden"},
+		},
+		{
+			name: "not found", err: domain.NotFound("user not found"),
+			wantCode: codes.NotFound,
+			want:     &domain.Error{Kind: domain.ErrorNotFound, Reason: domain.ReasonNotFound, Message: "user not found"},
+		},
+		{
+			name: "resource exhausted with retry", err: domain.ResourceExhausted(domain.ReasonRateLimited, "too many requests", 90*time.Second),
+			wantCode: codes.ResourceExhausted,
+			want:     &domain.Error{Kind: domain.ErrorResourceExhausted, Reason: domain.ReasonRateLimited, Message: "too many requests", RetryAfter: 90 * time.Second},
+		},
+		{
+			name: "error describing itself with metadata", err: fmt.Errorf("login: %w", lockedError{}),
+			wantCode: codes.PermissionDenied,
+			want:     &domain.Error{Kind: domain.ErrorPermissionDenied, Reason: domain.ReasonAccountLocked, Message: "account is locked", Metadata: map[string]string{"until": "2021-06-01T00:00:00Z"}},
+		},
+		{
+			name: "raw error hides its cause", err: errors.New("dial tcp: connection refused"),
+			wantCode: codes.Internal,
+			want:     &domain.Error{Kind: domain.ErrorInternal, Reason: domain.ReasonInternal, Message: "internal error"},
+		},
+		{
+			name: "unknown kind is internal", err: &domain.Error{Kind: "conflict", Reason: "CONFLICT", Message: "conflict"},
+			wantCode: codes.Internal,
+			want:     &domain.Error{Kind: domain.ErrorInternal, Reason: "CONFLICT", Message: "conflict"},
+		},
+		{
+			name: "status is kept", err: status.Error(codes.NotFound, "unknown method"),
+			wantCode: codes.NotFound,
+			want:     &domain.Error{Kind: domain.ErrorNotFound, Message: "unknown method"},
+		},
+		{
+			name: "code without a kind is internal", err: status.Error(codes.Unavailable, "transport is closing"),
+			wantCode: codes.Unavailable,
+			want:     &domain.Error{Kind: domain.ErrorInternal, Message: "transport is closing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := Status(tt.err)
+			if st.Code() != tt.wantCode {
+				t.Fatalf("code = %v, want %v", st.Code(), tt.wantCode)
+			}
+
+			got := Error(st)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("error = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
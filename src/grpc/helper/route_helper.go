@@ -0,0 +1,18 @@
+package helper
+
+import (
+	"auth-service/domain"
+	"google.golang.org/grpc"
+)
+
+// Routes returns the full methods of the services registered on the server.
+func Routes(server *grpc.Server) []domain.Route {
+	routes := make([]domain.Route, 0)
+	for service, info := range server.GetServiceInfo() {
+		for _, method := range info.Methods {
+			routes = append(routes, domain.Route{Object: "/" + service + "/" + method.Name, Action: "*"})
+		}
+	}
+
+	return routes
+}
@@ -1,15 +1,22 @@
 package helper
 
 import (
+	"auth-service/helper"
+	"auth-service/usecase"
+	"context"
 	"fmt"
 	"github.com/dgrijalva/jwt-go"
+	"google.golang.org/grpc/metadata"
 	"os"
 )
 
+// MagicLinkNonceKey is the metadata the REST gateway passes the nonce cookie in.
+const MagicLinkNonceKey = "magic-link-nonce"
+
 func ExtractUserIdFromToken(tokenString string) (*string, error) {
 
 	if tokenString == "" {
-		return nil, fmt.Errorf("", "message= %s", "Authorization header does not exist")
+		return nil, fmt.Errorf("message= %s", "Authorization header does not exist")
 	}
 
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -32,7 +39,7 @@ func ExtractUserIdFromToken(tokenString string) (*string, error) {
 func ExtractTokenUuid (tokenString string) (*string, error) {
 
 	if tokenString == "" {
-		return nil, fmt.Errorf("", "message= %s", "Authorization header does noe exist")
+		return nil, fmt.Errorf("message= %s", "Authorization header does noe exist")
 
 	}
 
@@ -52,3 +59,50 @@ func ExtractTokenUuid (tokenString string) (*string, error) {
 	}
 	return nil, err
 }
+
+// CallerId returns the id of the calling user, or an empty string for anonymous calls.
+func CallerId(ctx context.Context, authenticationUsecase usecase.AuthenticationUsecase) string {
+	headers, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(headers["authorization"]) != 1 {
+		return ""
+	}
+
+	token, err := authenticationUsecase.FetchAuthToken(ctx, headers["authorization"][0])
+	if err != nil {
+		return ""
+	}
+
+	userId, err := ExtractUserIdFromToken(string(token))
+	if err != nil {
+		return ""
+	}
+
+	return *userId
+}
+
+// CallerSubjects returns the roles and scopes of the caller's token, or ANONYMOUS.
+func CallerSubjects(ctx context.Context, authenticationUsecase usecase.AuthenticationUsecase) []string {
+	headers, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(headers["authorization"]) != 1 {
+		return []string{"ANONYMOUS"}
+	}
+
+	token, err := authenticationUsecase.FetchAuthToken(ctx, headers["authorization"][0])
+	if err != nil {
+		return []string{"ANONYMOUS"}
+	}
+
+	parsed, err := jwt.Parse(string(token), func(token *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("ACCESS_SECRET")), nil
+	})
+	if err != nil {
+		return []string{"ANONYMOUS"}
+	}
+
+	if claims, ok := parsed.Claims.(jwt.MapClaims); ok {
+		if subjects := helper.SubjectsFromClaims(claims); len(subjects) > 0 {
+			return subjects
+		}
+	}
+	return []string{"ANONYMOUS"}
+}
@@ -1,12 +1,14 @@
 package auth_interceptor
 
 import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"auth-service/infrastructure/policy"
 	"auth-service/infrastructure/tracer"
 	"auth-service/usecase"
 	"context"
-	"fmt"
-	"github.com/casbin/casbin/v2"
 	"github.com/dgrijalva/jwt-go"
+	logger "github.com/jelena-vlajkov/logger/logger"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	"log"
@@ -16,40 +18,78 @@ import (
 
 type authUnaryInterceptor struct {
 	Authenticationusecase usecase.AuthenticationUsecase
+	Enforcer              policy.Enforcer
+	logger                *logger.Logger
 }
 
 type AuthUnaryInterceptor interface {
 	UnaryAuthorizationInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error)
+	StreamAuthorizationInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error
 	ExtractUserRole(ctx context.Context, info *grpc.UnaryServerInfo) (string, error)
+	ExtractSubjects(ctx context.Context, info *grpc.UnaryServerInfo) ([]string, error)
+	ExtractActorId(ctx context.Context, info *grpc.UnaryServerInfo) string
 }
 
-func NewAuthUnaryInterceptor(authenticationUsecase usecase.AuthenticationUsecase) AuthUnaryInterceptor {
-	return &authUnaryInterceptor{authenticationUsecase}
+func NewAuthUnaryInterceptor(authenticationUsecase usecase.AuthenticationUsecase, enforcer policy.Enforcer, logger *logger.Logger) AuthUnaryInterceptor {
+	return &authUnaryInterceptor{authenticationUsecase, enforcer, logger}
 }
 func (a *authUnaryInterceptor) UnaryAuthorizationInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.authorize(ctx, info); err != nil {
+		return nil, err
+	}
 
-	role, err := a.ExtractUserRole(ctx, info)
+	log.Println("USAO U INTERCEPTOR: --> unary interceptor: ", info.FullMethod)
+	return handler(ctx, req)
+}
 
-	if err != nil {
-		return nil, err
+// StreamAuthorizationInterceptor applies the same policy to streaming RPCs.
+func (a *authUnaryInterceptor) StreamAuthorizationInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.authorize(ss.Context(), &grpc.UnaryServerInfo{FullMethod: info.FullMethod}); err != nil {
+		return err
 	}
 
-	if role == "" {
-		return nil, err
+	return handler(srv, ss)
+}
+
+func (a *authUnaryInterceptor) authorize(ctx context.Context, info *grpc.UnaryServerInfo) error {
+	subjects, err := a.ExtractSubjects(ctx, info)
+
+	if err != nil || len(subjects) == 0 {
+		return usecase.ErrInvalidToken
 	}
 
 	fullMethod := info.FullMethod
-	ok, err := enforce(role, fullMethod, "*")
+	ok, err := policy.EnforceAny(a.Enforcer, subjects, fullMethod, "*")
 
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if !ok {
-		return nil, err
+		if len(subjects) == 1 && subjects[0] == "ANONYMOUS" {
+			return domain.Unauthenticated(domain.ReasonMissingToken, "authentication required")
+		}
+		return domain.PermissionDenied(domain.ReasonForbidden, "forbidden")
 	}
-	log.Println("USAO U INTERCEPTOR: --> unary interceptor: ", fullMethod)
-	return handler(ctx, req)
+
+	if actorId, accessUuid := a.impersonation(ctx, info); actorId != "" {
+		if _, err := a.Authenticationusecase.FetchAuthToken(ctx, accessUuid); accessUuid == "" || err != nil {
+			a.logger.Logger.Warnf("ended impersonation token of %v used on %v\n", actorId, fullMethod)
+			return usecase.ErrInvalidToken
+		}
+
+		ok, err := a.Enforcer.Enforce(domain.ImpersonationSubject, fullMethod, "*")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			a.logger.Logger.Warnf("impersonation by %v blocked on %v\n", actorId, fullMethod)
+			return domain.PermissionDenied(domain.ReasonImpersonation, "forbidden while impersonating")
+		}
+		a.logger.Logger.Infof("impersonated request by %v: %v\n", actorId, fullMethod)
+	}
+
+	return nil
 }
 
 
@@ -81,6 +121,54 @@ func (a *authUnaryInterceptor) ExtractUserRole(ctx context.Context, info *grpc.U
 	return  "ANONYMOUS", err
 }
 
+// ExtractSubjects returns the roles and scopes of the caller's token, or ANONYMOUS.
+func (a *authUnaryInterceptor) ExtractSubjects(ctx context.Context, info *grpc.UnaryServerInfo) ([]string, error) {
+	span := tracer.StartSpanFromContext(ctx, "middleware/ExtractSubjects")
+	defer span.Finish()
+
+	tokenString := a.ExtractToken(ctx, info)
+	if tokenString == nil {
+		return []string{"ANONYMOUS"}, nil
+	}
+
+	token, err := jwt.Parse(*tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("ACCESS_SECRET")), nil
+	})
+	if err != nil {
+		return []string{"ANONYMOUS"}, err
+	}
+
+	if claims, ok := token.Claims.(jwt.MapClaims); ok {
+		if subjects := helper.SubjectsFromClaims(claims); len(subjects) > 0 {
+			return subjects, nil
+		}
+	}
+	return []string{"ANONYMOUS"}, nil
+}
+
+// ExtractActorId returns the admin impersonating the token's user, if any.
+func (a *authUnaryInterceptor) ExtractActorId(ctx context.Context, info *grpc.UnaryServerInfo) string {
+	actorId, _ := a.impersonation(ctx, info)
+	return actorId
+}
+
+func (a *authUnaryInterceptor) impersonation(ctx context.Context, info *grpc.UnaryServerInfo) (actorId, accessUuid string) {
+	tokenString := a.ExtractToken(ctx, info)
+	if tokenString == nil {
+		return "", ""
+	}
+
+	token, _ := jwt.Parse(*tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("ACCESS_SECRET")), nil
+	})
+
+	if claims, ok := token.Claims.(jwt.MapClaims); ok {
+		actorId, _ = claims[domain.ImpersonationClaim].(string)
+		accessUuid, _ = claims["access_uuid"].(string)
+	}
+	return actorId, accessUuid
+}
+
 func (a *authUnaryInterceptor) ExtractToken(ctx context.Context, info *grpc.UnaryServerInfo) *string {
 	span := tracer.StartSpanFromContext(ctx, "middleware/ExtractToken")
 	defer span.Finish()
@@ -131,29 +219,3 @@ func (a *authUnaryInterceptor) FetchToken(ctx context.Context, tokenId string, i
 
 	return &tokenStr, nil
 }
-
-func enforce(role string, obj string, act string) (bool, error) {
-	m, _ := os.Getwd()
-
-	if !strings.HasSuffix(m, "src")  {
-		splits := strings.Split(m, "src")
-		wd := splits[0] + "/src"
-		if err := os.Chdir(wd); err != nil {
-			return false, err
-		}
-	}
-
-	enforcer, err := casbin.NewEnforcer("grpc/interceptor/auth_interceptor/rbac_model.conf", "grpc/interceptor/auth_interceptor/rbac_policy.csv")
-	if err != nil {
-		return false, fmt.Errorf("failed to load policy from DB: %w", err)
-	}
-
-	err = enforcer.LoadPolicy()
-	if err != nil {
-		return false, fmt.Errorf("failed to load policy from DB: %w", err)
-	}
-
-	ok, _ := enforcer.Enforce(role, obj, act)
-
-	return ok, nil
-}
@@ -0,0 +1,114 @@
+package auth_interceptor
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/policy"
+	"auth-service/usecase"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type snapshotEnforcer struct {
+	policy.Enforcer
+	snapshot *policy.Snapshot
+}
+
+func (s snapshotEnforcer) Enforce(sub, obj, act string) (bool, error) {
+	ok, _, err := s.snapshot.EnforceEx(sub, obj, act)
+	return ok, err
+}
+
+func (s snapshotEnforcer) EnforceEx(sub, obj, act string) (bool, []string, error) {
+	return s.snapshot.EnforceEx(sub, obj, act)
+}
+
+// storedTokens serves access tokens by uuid like Redis does.
+type storedTokens struct {
+	usecase.AuthenticationUsecase
+	tokens map[string]string
+}
+
+func (s storedTokens) FetchAuthToken(ctx context.Context, tokenUuid string) ([]byte, error) {
+	token, ok := s.tokens[tokenUuid]
+	if !ok {
+		return nil, errors.New("redis: nil")
+	}
+	return []byte(token), nil
+}
+
+func signed(t *testing.T, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(os.Getenv("ACCESS_SECRET")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestAuthorizeImpersonation(t *testing.T) {
+	rules, err := os.Open("rbac_policy.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rules.Close()
+	snapshot, err := policy.LoadSnapshot("rbac_model.conf", rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokens := storedTokens{tokens: map[string]string{
+		"user":          signed(t, jwt.MapClaims{"access_uuid": "user", "user_id": "1", "role": "user"}),
+		"impersonation": signed(t, jwt.MapClaims{"access_uuid": "impersonation", "user_id": "1", "role": "user", domain.ImpersonationClaim: "2"}),
+		"copied":        signed(t, jwt.MapClaims{"access_uuid": "ended", "user_id": "1", "role": "user", domain.ImpersonationClaim: "2"}),
+	}}
+	interceptor := NewAuthUnaryInterceptor(tokens, snapshotEnforcer{snapshot: snapshot}, logger.InitializeLogger("auth-service", context.Background()))
+
+	tests := []struct {
+		name       string
+		token      string
+		method     string
+		wantReason string
+	}{
+		{name: "user", token: "user", method: "/Authentication/ChangePassword"},
+		{name: "impersonated read", token: "impersonation", method: "/Authentication/GetActivity"},
+		{name: "impersonated password change", token: "impersonation", method: "/Authentication/ChangePassword", wantReason: domain.ReasonImpersonation},
+		{name: "impersonated email change", token: "impersonation", method: "/Authentication/RequestEmailChange", wantReason: domain.ReasonImpersonation},
+		{name: "ended impersonation", token: "ended", method: "/Authentication/GetActivity", wantReason: domain.ReasonMissingToken},
+		{name: "impersonation whose access token is gone", token: "copied", method: "/Authentication/GetActivity", wantReason: domain.ReasonInvalidToken},
+		{name: "anonymous", method: "/Authentication/ChangePassword", wantReason: domain.ReasonMissingToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.token))
+			}
+
+			called := false
+			_, err := interceptor.UnaryAuthorizationInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			})
+
+			if tt.wantReason == "" {
+				if err != nil || !called {
+					t.Errorf("err = %v, want the call to go through", err)
+				}
+				return
+			}
+			if called {
+				t.Error("handler called")
+			}
+			if got := domain.AsError(err).Reason; got != tt.wantReason {
+				t.Errorf("reason = %v, want %v", got, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package auth_interceptor
+
+import (
+	pb "auth-service/grpc/server/authentication_server"
+	"os"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+// TestEveryMethodHasAPolicy keeps new RPCs from being unreachable for everyone.
+func TestEveryMethodHasAPolicy(t *testing.T) {
+	rules, err := os.ReadFile("rbac_policy.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objects := map[string]bool{}
+	for _, line := range strings.Split(string(rules), "\n") {
+		values := strings.Split(line, ",")
+		if len(values) >= 3 && strings.TrimSpace(values[0]) == "p" {
+			objects[strings.TrimSpace(values[2])] = true
+		}
+	}
+
+	for _, service := range []grpc.ServiceDesc{pb.Authentication_ServiceDesc, pb.Totp_ServiceDesc} {
+		for _, method := range service.Methods {
+			if fullMethod := "/" + service.ServiceName + "/" + method.MethodName; !objects[fullMethod] {
+				t.Errorf("no policy allows %v", fullMethod)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package error_interceptor
+
+import (
+	helper2 "auth-service/grpc/helper"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+type errorUnaryInterceptor struct {
+	logger *logger.Logger
+}
+
+type ErrorUnaryInterceptor interface {
+	UnaryErrorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error)
+	StreamErrorInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error
+}
+
+func NewErrorUnaryInterceptor(logger *logger.Logger) ErrorUnaryInterceptor {
+	return &errorUnaryInterceptor{logger: logger}
+}
+
+// UnaryErrorInterceptor turns errors into statuses and must be first in the chain.
+func (e *errorUnaryInterceptor) UnaryErrorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+
+	return nil, e.status(info.FullMethod, err)
+}
+
+// StreamErrorInterceptor does the same for streaming RPCs.
+func (e *errorUnaryInterceptor) StreamErrorInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := handler(srv, ss); err != nil {
+		return e.status(info.FullMethod, err)
+	}
+
+	return nil
+}
+
+func (e *errorUnaryInterceptor) status(fullMethod string, err error) error {
+	st := helper2.Status(err)
+	if st.Code() == codes.Internal {
+		e.logger.Logger.Errorf("error while handling %v, error: %v\n", fullMethod, err)
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,59 @@
+package rate_limit_interceptor
+
+import (
+	"auth-service/domain"
+	helper2 "auth-service/grpc/helper"
+	"auth-service/helper"
+	"auth-service/usecase"
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strconv"
+	"strings"
+)
+
+type rateLimitUnaryInterceptor struct {
+	RateLimitUsecase      usecase.RateLimitUsecase
+	AuthenticationUsecase usecase.AuthenticationUsecase
+}
+
+type RateLimitUnaryInterceptor interface {
+	UnaryRateLimitInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error)
+}
+
+func NewRateLimitUnaryInterceptor(rateLimitUsecase usecase.RateLimitUsecase, authenticationUsecase usecase.AuthenticationUsecase) RateLimitUnaryInterceptor {
+	return &rateLimitUnaryInterceptor{RateLimitUsecase: rateLimitUsecase, AuthenticationUsecase: authenticationUsecase}
+}
+
+// UnaryRateLimitInterceptor rejects requests over the limit of the called method.
+func (r *rateLimitUnaryInterceptor) UnaryRateLimitInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	for _, rule := range r.RateLimitUsecase.Rules(info.FullMethod, "") {
+		err := r.RateLimitUsecase.Allow(ctx, rule, r.key(ctx, req, rule.Key))
+
+		var limitErr *usecase.RateLimitExceededError
+		if errors.As(err, &limitErr) {
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(limitErr.RetryAfter.Seconds())+1)))
+			return nil, limitErr
+		}
+	}
+
+	return handler(ctx, req)
+}
+
+func (r *rateLimitUnaryInterceptor) key(ctx context.Context, req interface{}, key string) string {
+	switch key {
+	case domain.RateLimitByIp:
+		ip, _ := helper.ClientInfoFromContext(ctx)
+		return ip
+	case domain.RateLimitByUser:
+		return helper2.CallerId(ctx, r.AuthenticationUsecase)
+	case domain.RateLimitByEmail:
+		if request, ok := req.(interface{ GetEmail() string }); ok {
+			return strings.TrimSpace(request.GetEmail())
+		}
+	}
+
+	return ""
+}
+
@@ -0,0 +1,39 @@
+package rate_limit_interceptor
+
+import (
+	"auth-service/domain"
+	pb "auth-service/grpc/server/authentication_server"
+	"auth-service/helper"
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/peer"
+)
+
+func TestRateLimitKey(t *testing.T) {
+	interceptor := &rateLimitUnaryInterceptor{}
+	forwarded := context.WithValue(context.Background(), helper.ClientIpKey, "10.0.0.1")
+	direct := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 5000}})
+
+	if got := interceptor.key(forwarded, nil, domain.RateLimitByIp); got != "10.0.0.1" {
+		t.Errorf("ip forwarded by the gateway = %q", got)
+	}
+	if got := interceptor.key(direct, nil, domain.RateLimitByIp); got != "10.0.0.2" {
+		t.Errorf("ip of the peer = %q", got)
+	}
+	if got := interceptor.key(direct, &pb.MagicLinkRequest{Email: " user@example.com "}, domain.RateLimitByEmail); got != "user@example.com" {
+		t.Errorf("email of the request = %q", got)
+	}
+
+	// Allow skips the rule when the key can't be read from the request
+	if got := interceptor.key(direct, &pb.MagicLoginRequest{Token: "token"}, domain.RateLimitByEmail); got != "" {
+		t.Errorf("request without email = %q", got)
+	}
+	if got := interceptor.key(direct, nil, domain.RateLimitByUser); got != "" {
+		t.Errorf("anonymous user = %q", got)
+	}
+	if got := interceptor.key(direct, &pb.MagicLinkRequest{Email: "user@example.com"}, "header"); got != "" {
+		t.Errorf("unknown key = %q", got)
+	}
+}
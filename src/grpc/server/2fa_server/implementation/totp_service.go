@@ -1,22 +1,37 @@
 package implementation
 
 import (
+	"auth-service/domain"
+	helper2 "auth-service/grpc/helper"
 	pb "auth-service/grpc/server/authentication_server"
 	"auth-service/usecase"
 	"context"
-	"fmt"
+	"errors"
 	"github.com/microcosm-cc/bluemonday"
 	"strings"
 )
 
+const invalidPasscode = "passcode is not valid"
+
+// invalidPasscodeError reports the passcode of the request as the invalid field.
+func invalidPasscodeError() error {
+	err := domain.InvalidArgument(invalidPasscode, domain.FieldViolation{Field: "passcode", Description: invalidPasscode})
+	err.Reason = domain.ReasonInvalidPasscode
+	return err
+}
+
 type TotpServer struct {
 	pb.UnimplementedTotpServer
 	TotpUsecase usecase.TotpUsecase
 	ProfileInfoUsecase usecase.ProfileInfoUsecase
+	SecurityEventUsecase usecase.SecurityEventUsecase
+	BruteForceUsecase usecase.BruteForceUsecase
+	AuthenticationUsecase usecase.AuthenticationUsecase
+	AuditUsecase usecase.AuditUsecase
 }
 
-func NewTotpServer (totpUsecase usecase.TotpUsecase, profileInfoUsecase usecase.ProfileInfoUsecase) *TotpServer {
-	return &TotpServer{TotpUsecase: totpUsecase, ProfileInfoUsecase: profileInfoUsecase}
+func NewTotpServer (totpUsecase usecase.TotpUsecase, profileInfoUsecase usecase.ProfileInfoUsecase, securityEventUsecase usecase.SecurityEventUsecase, bruteForceUsecase usecase.BruteForceUsecase, authenticationUsecase usecase.AuthenticationUsecase, auditUsecase usecase.AuditUsecase) *TotpServer {
+	return &TotpServer{TotpUsecase: totpUsecase, ProfileInfoUsecase: profileInfoUsecase, SecurityEventUsecase: securityEventUsecase, BruteForceUsecase: bruteForceUsecase, AuthenticationUsecase: authenticationUsecase, AuditUsecase: auditUsecase}
 }
 
 func(t *TotpServer) Verify(ctx context.Context, in *pb.TotpSecret) (*pb.BoolWrapper, error) {
@@ -26,15 +41,26 @@ func(t *TotpServer) Verify(ctx context.Context, in *pb.TotpSecret) (*pb.BoolWrap
 	in.Passcode = strings.TrimSpace(policy.Sanitize(in.Passcode))
 	in.UserId = strings.TrimSpace(policy.Sanitize(in.UserId))
 
+	if err := t.BruteForceUsecase.Check(ctx, usecase.BruteForceTotp, in.UserId); err != nil {
+		return nil, err
+	}
+
 	if !t.TotpUsecase.Verify(ctx, in.Passcode, in.UserId) {
-		return nil, fmt.Errorf("")
+		t.BruteForceUsecase.Fail(ctx, usecase.BruteForceTotp, in.UserId)
+		t.AuditUsecase.Record(ctx, helper2.CallerId(ctx, t.AuthenticationUsecase), in.UserId, domain.AuditTotpEnabled, errors.New(invalidPasscode))
+		return nil, invalidPasscodeError()
 	}
+	t.BruteForceUsecase.Succeed(ctx, usecase.BruteForceTotp, in.UserId)
 
-	if err := t.TotpUsecase.SaveSecret(ctx, in.UserId); err != nil {
+	err := t.TotpUsecase.SaveSecret(ctx, in.UserId)
+	t.AuditUsecase.Record(ctx, helper2.CallerId(ctx, t.AuthenticationUsecase), in.UserId, domain.AuditTotpEnabled, err)
+	if err != nil {
 
 		return nil, err
 	}
 
+	t.SecurityEventUsecase.Publish(ctx, domain.SecurityEvent{Type: domain.SecurityEventTotpEnabled, ProfileInfoId: in.UserId})
+
 	return &pb.BoolWrapper{Value: true}, nil
 }
 
@@ -45,7 +71,7 @@ func(t *TotpServer) IsEnabled(ctx context.Context, in *pb.Username) (*pb.BoolWra
 	secret, err := t.TotpUsecase.GetSecretByProfileInfoId(ctx, in.Username)
 
 	if err != nil {
-		return nil, fmt.Errorf("")
+		return nil, domain.NotFound("totp is not enabled")
 	}
 
 	if secret != nil {
@@ -61,14 +87,25 @@ func(t *TotpServer) Disable(ctx context.Context, in *pb.TotpSecret) (*pb.BoolWra
 	in.Passcode = strings.TrimSpace(policy.Sanitize(in.Passcode))
 	in.UserId = strings.TrimSpace(policy.Sanitize(in.UserId))
 
+	if err := t.BruteForceUsecase.Check(ctx, usecase.BruteForceTotp, in.UserId); err != nil {
+		return nil, err
+	}
+
 	if !t.TotpUsecase.Validate(ctx, in.UserId, in.Passcode) {
-		return nil, fmt.Errorf("")
+		t.BruteForceUsecase.Fail(ctx, usecase.BruteForceTotp, in.UserId)
+		t.AuditUsecase.Record(ctx, helper2.CallerId(ctx, t.AuthenticationUsecase), in.UserId, domain.AuditTotpDisabled, errors.New(invalidPasscode))
+		return nil, invalidPasscodeError()
 	}
+	t.BruteForceUsecase.Succeed(ctx, usecase.BruteForceTotp, in.UserId)
 
-	if err := t.TotpUsecase.DeleteSecretByProfileId(ctx, in.UserId); err != nil {
+	err := t.TotpUsecase.DeleteSecretByProfileId(ctx, in.UserId)
+	t.AuditUsecase.Record(ctx, helper2.CallerId(ctx, t.AuthenticationUsecase), in.UserId, domain.AuditTotpDisabled, err)
+	if err != nil {
 		return nil, err
 	}
 
+	t.SecurityEventUsecase.Publish(ctx, domain.SecurityEvent{Type: domain.SecurityEventTotpDisabled, ProfileInfoId: in.UserId})
+
 	return &pb.BoolWrapper{Value: true}, nil
 }
 
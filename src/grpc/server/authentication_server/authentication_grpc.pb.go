@@ -25,6 +25,53 @@ type AuthenticationClient interface {
 	GenerateSecret(ctx context.Context, in *AccessToken, opts ...grpc.CallOption) (*ScanTotp, error)
 	ValidateTemporaryToken(ctx context.Context, in *AccessToken, opts ...grpc.CallOption) (*AccessToken, error)
 	ValidateTotp(ctx context.Context, in *TotpValidation, opts ...grpc.CallOption) (*LoginResponse, error)
+	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*PasswordResponse, error)
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*PasswordResponse, error)
+	SuspendAccount(ctx context.Context, in *AccountStatusRequest, opts ...grpc.CallOption) (*AccountStatus, error)
+	BanAccount(ctx context.Context, in *AccountStatusRequest, opts ...grpc.CallOption) (*AccountStatus, error)
+	UnlockAccount(ctx context.Context, in *AccountStatusRequest, opts ...grpc.CallOption) (*AccountStatus, error)
+	ListPolicies(ctx context.Context, in *PolicyListRequest, opts ...grpc.CallOption) (*PolicyList, error)
+	AddPolicy(ctx context.Context, in *PolicyRequest, opts ...grpc.CallOption) (*BooleanResponse, error)
+	RemovePolicy(ctx context.Context, in *PolicyRequest, opts ...grpc.CallOption) (*BooleanResponse, error)
+	AddRoleInheritance(ctx context.Context, in *RoleInheritanceRequest, opts ...grpc.CallOption) (*BooleanResponse, error)
+	RemoveRoleInheritance(ctx context.Context, in *RoleInheritanceRequest, opts ...grpc.CallOption) (*BooleanResponse, error)
+	GrantRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*UserRoles, error)
+	RevokeRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*UserRoles, error)
+	Authorize(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*AuthorizeResponse, error)
+	AuthorizeBatch(ctx context.Context, in *AuthorizeBatchRequest, opts ...grpc.CallOption) (*AuthorizeBatchResponse, error)
+	LogoutAll(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BooleanResponse, error)
+	RefreshToken(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*TokenValidationResponse, error)
+	Register(ctx context.Context, in *RegistrationRequest, opts ...grpc.CallOption) (*BooleanResponse, error)
+	ConfirmAccount(ctx context.Context, in *AccountConfirmation, opts ...grpc.CallOption) (*BooleanResponse, error)
+	ResendRegistrationCode(ctx context.Context, in *ResendEmailRequest, opts ...grpc.CallOption) (*BooleanResponse, error)
+	RegisterAgent(ctx context.Context, in *RegistrationRequest, opts ...grpc.CallOption) (*BooleanResponse, error)
+	ValidateAgentAccount(ctx context.Context, in *AccountConfirmation, opts ...grpc.CallOption) (*BooleanResponse, error)
+	ListAgentRequests(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AgentList, error)
+	ConfirmAgentAccount(ctx context.Context, in *AgentConfirmation, opts ...grpc.CallOption) (*BooleanResponse, error)
+	DeleteProfileInfo(ctx context.Context, in *Username, opts ...grpc.CallOption) (*BooleanResponse, error)
+	SendMagicLink(ctx context.Context, in *MagicLinkRequest, opts ...grpc.CallOption) (*MagicLinkResponse, error)
+	MagicLogin(ctx context.Context, in *MagicLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	RequestEmailChange(ctx context.Context, in *EmailChangeRequest, opts ...grpc.CallOption) (*BooleanResponse, error)
+	ConfirmEmailChange(ctx context.Context, in *EmailChangeConfirmation, opts ...grpc.CallOption) (*BooleanResponse, error)
+	CancelEmailChange(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*BooleanResponse, error)
+	LockAccount(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*BooleanResponse, error)
+	IssueClientToken(ctx context.Context, in *ClientCredentials, opts ...grpc.CallOption) (*ClientToken, error)
+	GetActivity(ctx context.Context, in *ActivityRequest, opts ...grpc.CallOption) (*LoginEventList, error)
+	SearchActivity(ctx context.Context, in *ActivityFilter, opts ...grpc.CallOption) (*LoginEventList, error)
+	ExportAuditLog(ctx context.Context, in *AuditExportRequest, opts ...grpc.CallOption) (Authentication_ExportAuditLogClient, error)
+	StartImpersonation(ctx context.Context, in *ImpersonationRequest, opts ...grpc.CallOption) (*Impersonation, error)
+	EndImpersonation(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*BooleanResponse, error)
+	ListScopes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ScopeList, error)
+	AddRoleScope(ctx context.Context, in *RoleScopeRequest, opts ...grpc.CallOption) (*RoleScopes, error)
+	RemoveRoleScope(ctx context.Context, in *RoleScopeRequest, opts ...grpc.CallOption) (*RoleScopes, error)
+	ListOAuthClients(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OAuthClientList, error)
+	CreateOAuthClient(ctx context.Context, in *OAuthClientRequest, opts ...grpc.CallOption) (*OAuthClient, error)
+	DeleteOAuthClient(ctx context.Context, in *OAuthClientRequest, opts ...grpc.CallOption) (*BooleanResponse, error)
+	ReloadPolicy(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BooleanResponse, error)
+	ExplainPolicy(ctx context.Context, in *PolicyRequest, opts ...grpc.CallOption) (*PolicyExplanationList, error)
+	DiffPolicy(ctx context.Context, in *PolicyDiffRequest, opts ...grpc.CallOption) (*PolicyDiff, error)
+	ListOutboxMessages(ctx context.Context, in *OutboxListRequest, opts ...grpc.CallOption) (*OutboxMessageList, error)
+	RedriveOutboxMessage(ctx context.Context, in *OutboxMessageRequest, opts ...grpc.CallOption) (*BooleanResponse, error)
 }
 
 type authenticationClient struct {
@@ -98,6 +145,452 @@ func (c *authenticationClient) ValidateTotp(ctx context.Context, in *TotpValidat
 	return out, nil
 }
 
+func (c *authenticationClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*PasswordResponse, error) {
+	out := new(PasswordResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/ResetPassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*PasswordResponse, error) {
+	out := new(PasswordResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/ChangePassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) SuspendAccount(ctx context.Context, in *AccountStatusRequest, opts ...grpc.CallOption) (*AccountStatus, error) {
+	out := new(AccountStatus)
+	err := c.cc.Invoke(ctx, "/Authentication/SuspendAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) BanAccount(ctx context.Context, in *AccountStatusRequest, opts ...grpc.CallOption) (*AccountStatus, error) {
+	out := new(AccountStatus)
+	err := c.cc.Invoke(ctx, "/Authentication/BanAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) UnlockAccount(ctx context.Context, in *AccountStatusRequest, opts ...grpc.CallOption) (*AccountStatus, error) {
+	out := new(AccountStatus)
+	err := c.cc.Invoke(ctx, "/Authentication/UnlockAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ListPolicies(ctx context.Context, in *PolicyListRequest, opts ...grpc.CallOption) (*PolicyList, error) {
+	out := new(PolicyList)
+	err := c.cc.Invoke(ctx, "/Authentication/ListPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) AddPolicy(ctx context.Context, in *PolicyRequest, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/AddPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) RemovePolicy(ctx context.Context, in *PolicyRequest, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/RemovePolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) AddRoleInheritance(ctx context.Context, in *RoleInheritanceRequest, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/AddRoleInheritance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) RemoveRoleInheritance(ctx context.Context, in *RoleInheritanceRequest, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/RemoveRoleInheritance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) GrantRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*UserRoles, error) {
+	out := new(UserRoles)
+	err := c.cc.Invoke(ctx, "/Authentication/GrantRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) RevokeRole(ctx context.Context, in *RoleRequest, opts ...grpc.CallOption) (*UserRoles, error) {
+	out := new(UserRoles)
+	err := c.cc.Invoke(ctx, "/Authentication/RevokeRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) Authorize(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*AuthorizeResponse, error) {
+	out := new(AuthorizeResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/Authorize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) AuthorizeBatch(ctx context.Context, in *AuthorizeBatchRequest, opts ...grpc.CallOption) (*AuthorizeBatchResponse, error) {
+	out := new(AuthorizeBatchResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/AuthorizeBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) LogoutAll(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/LogoutAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) RefreshToken(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*TokenValidationResponse, error) {
+	out := new(TokenValidationResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/RefreshToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) Register(ctx context.Context, in *RegistrationRequest, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/Register", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ConfirmAccount(ctx context.Context, in *AccountConfirmation, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/ConfirmAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ResendRegistrationCode(ctx context.Context, in *ResendEmailRequest, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/ResendRegistrationCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) RegisterAgent(ctx context.Context, in *RegistrationRequest, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/RegisterAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ValidateAgentAccount(ctx context.Context, in *AccountConfirmation, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/ValidateAgentAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ListAgentRequests(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AgentList, error) {
+	out := new(AgentList)
+	err := c.cc.Invoke(ctx, "/Authentication/ListAgentRequests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ConfirmAgentAccount(ctx context.Context, in *AgentConfirmation, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/ConfirmAgentAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) DeleteProfileInfo(ctx context.Context, in *Username, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/DeleteProfileInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) SendMagicLink(ctx context.Context, in *MagicLinkRequest, opts ...grpc.CallOption) (*MagicLinkResponse, error) {
+	out := new(MagicLinkResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/SendMagicLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) MagicLogin(ctx context.Context, in *MagicLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/MagicLogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) RequestEmailChange(ctx context.Context, in *EmailChangeRequest, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/RequestEmailChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ConfirmEmailChange(ctx context.Context, in *EmailChangeConfirmation, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/ConfirmEmailChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) CancelEmailChange(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/CancelEmailChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) LockAccount(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/LockAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) IssueClientToken(ctx context.Context, in *ClientCredentials, opts ...grpc.CallOption) (*ClientToken, error) {
+	out := new(ClientToken)
+	err := c.cc.Invoke(ctx, "/Authentication/IssueClientToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) GetActivity(ctx context.Context, in *ActivityRequest, opts ...grpc.CallOption) (*LoginEventList, error) {
+	out := new(LoginEventList)
+	err := c.cc.Invoke(ctx, "/Authentication/GetActivity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) SearchActivity(ctx context.Context, in *ActivityFilter, opts ...grpc.CallOption) (*LoginEventList, error) {
+	out := new(LoginEventList)
+	err := c.cc.Invoke(ctx, "/Authentication/SearchActivity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ExportAuditLog(ctx context.Context, in *AuditExportRequest, opts ...grpc.CallOption) (Authentication_ExportAuditLogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Authentication_ServiceDesc.Streams[0], "/Authentication/ExportAuditLog", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &authenticationExportAuditLogClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Authentication_ExportAuditLogClient interface {
+	Recv() (*AuditEntry, error)
+	grpc.ClientStream
+}
+
+type authenticationExportAuditLogClient struct {
+	grpc.ClientStream
+}
+
+func (x *authenticationExportAuditLogClient) Recv() (*AuditEntry, error) {
+	m := new(AuditEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *authenticationClient) StartImpersonation(ctx context.Context, in *ImpersonationRequest, opts ...grpc.CallOption) (*Impersonation, error) {
+	out := new(Impersonation)
+	err := c.cc.Invoke(ctx, "/Authentication/StartImpersonation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) EndImpersonation(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/EndImpersonation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ListScopes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ScopeList, error) {
+	out := new(ScopeList)
+	err := c.cc.Invoke(ctx, "/Authentication/ListScopes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) AddRoleScope(ctx context.Context, in *RoleScopeRequest, opts ...grpc.CallOption) (*RoleScopes, error) {
+	out := new(RoleScopes)
+	err := c.cc.Invoke(ctx, "/Authentication/AddRoleScope", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) RemoveRoleScope(ctx context.Context, in *RoleScopeRequest, opts ...grpc.CallOption) (*RoleScopes, error) {
+	out := new(RoleScopes)
+	err := c.cc.Invoke(ctx, "/Authentication/RemoveRoleScope", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ListOAuthClients(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OAuthClientList, error) {
+	out := new(OAuthClientList)
+	err := c.cc.Invoke(ctx, "/Authentication/ListOAuthClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) CreateOAuthClient(ctx context.Context, in *OAuthClientRequest, opts ...grpc.CallOption) (*OAuthClient, error) {
+	out := new(OAuthClient)
+	err := c.cc.Invoke(ctx, "/Authentication/CreateOAuthClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) DeleteOAuthClient(ctx context.Context, in *OAuthClientRequest, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/DeleteOAuthClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ReloadPolicy(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/ReloadPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ExplainPolicy(ctx context.Context, in *PolicyRequest, opts ...grpc.CallOption) (*PolicyExplanationList, error) {
+	out := new(PolicyExplanationList)
+	err := c.cc.Invoke(ctx, "/Authentication/ExplainPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) DiffPolicy(ctx context.Context, in *PolicyDiffRequest, opts ...grpc.CallOption) (*PolicyDiff, error) {
+	out := new(PolicyDiff)
+	err := c.cc.Invoke(ctx, "/Authentication/DiffPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) ListOutboxMessages(ctx context.Context, in *OutboxListRequest, opts ...grpc.CallOption) (*OutboxMessageList, error) {
+	out := new(OutboxMessageList)
+	err := c.cc.Invoke(ctx, "/Authentication/ListOutboxMessages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationClient) RedriveOutboxMessage(ctx context.Context, in *OutboxMessageRequest, opts ...grpc.CallOption) (*BooleanResponse, error) {
+	out := new(BooleanResponse)
+	err := c.cc.Invoke(ctx, "/Authentication/RedriveOutboxMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthenticationServer is the server API for Authentication service.
 // All implementations must embed UnimplementedAuthenticationServer
 // for forward compatibility
@@ -109,169 +602,1206 @@ type AuthenticationServer interface {
 	GenerateSecret(context.Context, *AccessToken) (*ScanTotp, error)
 	ValidateTemporaryToken(context.Context, *AccessToken) (*AccessToken, error)
 	ValidateTotp(context.Context, *TotpValidation) (*LoginResponse, error)
+	ResetPassword(context.Context, *ResetPasswordRequest) (*PasswordResponse, error)
+	ChangePassword(context.Context, *ChangePasswordRequest) (*PasswordResponse, error)
+	SuspendAccount(context.Context, *AccountStatusRequest) (*AccountStatus, error)
+	BanAccount(context.Context, *AccountStatusRequest) (*AccountStatus, error)
+	UnlockAccount(context.Context, *AccountStatusRequest) (*AccountStatus, error)
+	ListPolicies(context.Context, *PolicyListRequest) (*PolicyList, error)
+	AddPolicy(context.Context, *PolicyRequest) (*BooleanResponse, error)
+	RemovePolicy(context.Context, *PolicyRequest) (*BooleanResponse, error)
+	AddRoleInheritance(context.Context, *RoleInheritanceRequest) (*BooleanResponse, error)
+	RemoveRoleInheritance(context.Context, *RoleInheritanceRequest) (*BooleanResponse, error)
+	GrantRole(context.Context, *RoleRequest) (*UserRoles, error)
+	RevokeRole(context.Context, *RoleRequest) (*UserRoles, error)
+	Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error)
+	AuthorizeBatch(context.Context, *AuthorizeBatchRequest) (*AuthorizeBatchResponse, error)
+	LogoutAll(context.Context, *Empty) (*BooleanResponse, error)
+	RefreshToken(context.Context, *TokenRequest) (*TokenValidationResponse, error)
+	Register(context.Context, *RegistrationRequest) (*BooleanResponse, error)
+	ConfirmAccount(context.Context, *AccountConfirmation) (*BooleanResponse, error)
+	ResendRegistrationCode(context.Context, *ResendEmailRequest) (*BooleanResponse, error)
+	RegisterAgent(context.Context, *RegistrationRequest) (*BooleanResponse, error)
+	ValidateAgentAccount(context.Context, *AccountConfirmation) (*BooleanResponse, error)
+	ListAgentRequests(context.Context, *Empty) (*AgentList, error)
+	ConfirmAgentAccount(context.Context, *AgentConfirmation) (*BooleanResponse, error)
+	DeleteProfileInfo(context.Context, *Username) (*BooleanResponse, error)
+	SendMagicLink(context.Context, *MagicLinkRequest) (*MagicLinkResponse, error)
+	MagicLogin(context.Context, *MagicLoginRequest) (*LoginResponse, error)
+	RequestEmailChange(context.Context, *EmailChangeRequest) (*BooleanResponse, error)
+	ConfirmEmailChange(context.Context, *EmailChangeConfirmation) (*BooleanResponse, error)
+	CancelEmailChange(context.Context, *TokenRequest) (*BooleanResponse, error)
+	LockAccount(context.Context, *TokenRequest) (*BooleanResponse, error)
+	IssueClientToken(context.Context, *ClientCredentials) (*ClientToken, error)
+	GetActivity(context.Context, *ActivityRequest) (*LoginEventList, error)
+	SearchActivity(context.Context, *ActivityFilter) (*LoginEventList, error)
+	ExportAuditLog(*AuditExportRequest, Authentication_ExportAuditLogServer) error
+	StartImpersonation(context.Context, *ImpersonationRequest) (*Impersonation, error)
+	EndImpersonation(context.Context, *TokenRequest) (*BooleanResponse, error)
+	ListScopes(context.Context, *Empty) (*ScopeList, error)
+	AddRoleScope(context.Context, *RoleScopeRequest) (*RoleScopes, error)
+	RemoveRoleScope(context.Context, *RoleScopeRequest) (*RoleScopes, error)
+	ListOAuthClients(context.Context, *Empty) (*OAuthClientList, error)
+	CreateOAuthClient(context.Context, *OAuthClientRequest) (*OAuthClient, error)
+	DeleteOAuthClient(context.Context, *OAuthClientRequest) (*BooleanResponse, error)
+	ReloadPolicy(context.Context, *Empty) (*BooleanResponse, error)
+	ExplainPolicy(context.Context, *PolicyRequest) (*PolicyExplanationList, error)
+	DiffPolicy(context.Context, *PolicyDiffRequest) (*PolicyDiff, error)
+	ListOutboxMessages(context.Context, *OutboxListRequest) (*OutboxMessageList, error)
+	RedriveOutboxMessage(context.Context, *OutboxMessageRequest) (*BooleanResponse, error)
+	mustEmbedUnimplementedAuthenticationServer()
+}
+
+// UnimplementedAuthenticationServer must be embedded to have forward compatible implementations.
+type UnimplementedAuthenticationServer struct {
+}
+
+func (UnimplementedAuthenticationServer) Login(context.Context, *LoginCredentials) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedAuthenticationServer) Logout(context.Context, *Tokens) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedAuthenticationServer) ValidateToken(context.Context, *Tokens) (*TokenValidationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateToken not implemented")
+}
+func (UnimplementedAuthenticationServer) ResendEmail(context.Context, *ResendEmailRequest) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendEmail not implemented")
+}
+func (UnimplementedAuthenticationServer) GenerateSecret(context.Context, *AccessToken) (*ScanTotp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateSecret not implemented")
+}
+func (UnimplementedAuthenticationServer) ValidateTemporaryToken(context.Context, *AccessToken) (*AccessToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateTemporaryToken not implemented")
+}
+func (UnimplementedAuthenticationServer) ValidateTotp(context.Context, *TotpValidation) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateTotp not implemented")
+}
+func (UnimplementedAuthenticationServer) ResetPassword(context.Context, *ResetPasswordRequest) (*PasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (UnimplementedAuthenticationServer) ChangePassword(context.Context, *ChangePasswordRequest) (*PasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedAuthenticationServer) SuspendAccount(context.Context, *AccountStatusRequest) (*AccountStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuspendAccount not implemented")
+}
+func (UnimplementedAuthenticationServer) BanAccount(context.Context, *AccountStatusRequest) (*AccountStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanAccount not implemented")
+}
+func (UnimplementedAuthenticationServer) UnlockAccount(context.Context, *AccountStatusRequest) (*AccountStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockAccount not implemented")
+}
+func (UnimplementedAuthenticationServer) ListPolicies(context.Context, *PolicyListRequest) (*PolicyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPolicies not implemented")
+}
+func (UnimplementedAuthenticationServer) AddPolicy(context.Context, *PolicyRequest) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPolicy not implemented")
+}
+func (UnimplementedAuthenticationServer) RemovePolicy(context.Context, *PolicyRequest) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePolicy not implemented")
+}
+func (UnimplementedAuthenticationServer) AddRoleInheritance(context.Context, *RoleInheritanceRequest) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRoleInheritance not implemented")
+}
+func (UnimplementedAuthenticationServer) RemoveRoleInheritance(context.Context, *RoleInheritanceRequest) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoleInheritance not implemented")
+}
+func (UnimplementedAuthenticationServer) GrantRole(context.Context, *RoleRequest) (*UserRoles, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantRole not implemented")
+}
+func (UnimplementedAuthenticationServer) RevokeRole(context.Context, *RoleRequest) (*UserRoles, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeRole not implemented")
+}
+func (UnimplementedAuthenticationServer) Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
+}
+func (UnimplementedAuthenticationServer) AuthorizeBatch(context.Context, *AuthorizeBatchRequest) (*AuthorizeBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthorizeBatch not implemented")
+}
+func (UnimplementedAuthenticationServer) LogoutAll(context.Context, *Empty) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogoutAll not implemented")
+}
+func (UnimplementedAuthenticationServer) RefreshToken(context.Context, *TokenRequest) (*TokenValidationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedAuthenticationServer) Register(context.Context, *RegistrationRequest) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedAuthenticationServer) ConfirmAccount(context.Context, *AccountConfirmation) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmAccount not implemented")
+}
+func (UnimplementedAuthenticationServer) ResendRegistrationCode(context.Context, *ResendEmailRequest) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendRegistrationCode not implemented")
+}
+func (UnimplementedAuthenticationServer) RegisterAgent(context.Context, *RegistrationRequest) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterAgent not implemented")
+}
+func (UnimplementedAuthenticationServer) ValidateAgentAccount(context.Context, *AccountConfirmation) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateAgentAccount not implemented")
+}
+func (UnimplementedAuthenticationServer) ListAgentRequests(context.Context, *Empty) (*AgentList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAgentRequests not implemented")
+}
+func (UnimplementedAuthenticationServer) ConfirmAgentAccount(context.Context, *AgentConfirmation) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmAgentAccount not implemented")
+}
+func (UnimplementedAuthenticationServer) DeleteProfileInfo(context.Context, *Username) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProfileInfo not implemented")
+}
+func (UnimplementedAuthenticationServer) SendMagicLink(context.Context, *MagicLinkRequest) (*MagicLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMagicLink not implemented")
+}
+func (UnimplementedAuthenticationServer) MagicLogin(context.Context, *MagicLoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MagicLogin not implemented")
+}
+func (UnimplementedAuthenticationServer) RequestEmailChange(context.Context, *EmailChangeRequest) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestEmailChange not implemented")
+}
+func (UnimplementedAuthenticationServer) ConfirmEmailChange(context.Context, *EmailChangeConfirmation) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmEmailChange not implemented")
+}
+func (UnimplementedAuthenticationServer) CancelEmailChange(context.Context, *TokenRequest) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelEmailChange not implemented")
+}
+func (UnimplementedAuthenticationServer) LockAccount(context.Context, *TokenRequest) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockAccount not implemented")
+}
+func (UnimplementedAuthenticationServer) IssueClientToken(context.Context, *ClientCredentials) (*ClientToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueClientToken not implemented")
+}
+func (UnimplementedAuthenticationServer) GetActivity(context.Context, *ActivityRequest) (*LoginEventList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActivity not implemented")
+}
+func (UnimplementedAuthenticationServer) SearchActivity(context.Context, *ActivityFilter) (*LoginEventList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchActivity not implemented")
+}
+func (UnimplementedAuthenticationServer) ExportAuditLog(*AuditExportRequest, Authentication_ExportAuditLogServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAuditLog not implemented")
+}
+func (UnimplementedAuthenticationServer) StartImpersonation(context.Context, *ImpersonationRequest) (*Impersonation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartImpersonation not implemented")
+}
+func (UnimplementedAuthenticationServer) EndImpersonation(context.Context, *TokenRequest) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndImpersonation not implemented")
+}
+func (UnimplementedAuthenticationServer) ListScopes(context.Context, *Empty) (*ScopeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScopes not implemented")
+}
+func (UnimplementedAuthenticationServer) AddRoleScope(context.Context, *RoleScopeRequest) (*RoleScopes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRoleScope not implemented")
+}
+func (UnimplementedAuthenticationServer) RemoveRoleScope(context.Context, *RoleScopeRequest) (*RoleScopes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoleScope not implemented")
+}
+func (UnimplementedAuthenticationServer) ListOAuthClients(context.Context, *Empty) (*OAuthClientList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOAuthClients not implemented")
+}
+func (UnimplementedAuthenticationServer) CreateOAuthClient(context.Context, *OAuthClientRequest) (*OAuthClient, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOAuthClient not implemented")
+}
+func (UnimplementedAuthenticationServer) DeleteOAuthClient(context.Context, *OAuthClientRequest) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOAuthClient not implemented")
+}
+func (UnimplementedAuthenticationServer) ReloadPolicy(context.Context, *Empty) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadPolicy not implemented")
+}
+func (UnimplementedAuthenticationServer) ExplainPolicy(context.Context, *PolicyRequest) (*PolicyExplanationList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainPolicy not implemented")
+}
+func (UnimplementedAuthenticationServer) DiffPolicy(context.Context, *PolicyDiffRequest) (*PolicyDiff, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffPolicy not implemented")
+}
+func (UnimplementedAuthenticationServer) ListOutboxMessages(context.Context, *OutboxListRequest) (*OutboxMessageList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOutboxMessages not implemented")
+}
+func (UnimplementedAuthenticationServer) RedriveOutboxMessage(context.Context, *OutboxMessageRequest) (*BooleanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedriveOutboxMessage not implemented")
+}
+func (UnimplementedAuthenticationServer) mustEmbedUnimplementedAuthenticationServer() {}
+
+// UnsafeAuthenticationServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthenticationServer will
+// result in compilation errors.
+type UnsafeAuthenticationServer interface {
 	mustEmbedUnimplementedAuthenticationServer()
 }
 
-// UnimplementedAuthenticationServer must be embedded to have forward compatible implementations.
-type UnimplementedAuthenticationServer struct {
+func RegisterAuthenticationServer(s grpc.ServiceRegistrar, srv AuthenticationServer) {
+	s.RegisterService(&Authentication_ServiceDesc, srv)
+}
+
+func _Authentication_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginCredentials)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).Login(ctx, req.(*LoginCredentials))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Tokens)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/Logout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).Logout(ctx, req.(*Tokens))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ValidateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Tokens)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ValidateToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ValidateToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ValidateToken(ctx, req.(*Tokens))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ResendEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ResendEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ResendEmail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ResendEmail(ctx, req.(*ResendEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_GenerateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccessToken)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).GenerateSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/GenerateSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).GenerateSecret(ctx, req.(*AccessToken))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ValidateTemporaryToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccessToken)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ValidateTemporaryToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ValidateTemporaryToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ValidateTemporaryToken(ctx, req.(*AccessToken))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ValidateTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TotpValidation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ValidateTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ValidateTotp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ValidateTotp(ctx, req.(*TotpValidation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ResetPassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ChangePassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_SuspendAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).SuspendAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/SuspendAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).SuspendAccount(ctx, req.(*AccountStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_BanAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).BanAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/BanAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).BanAccount(ctx, req.(*AccountStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_UnlockAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).UnlockAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/UnlockAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).UnlockAccount(ctx, req.(*AccountStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ListPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ListPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ListPolicies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ListPolicies(ctx, req.(*PolicyListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_AddPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).AddPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/AddPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).AddPolicy(ctx, req.(*PolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_RemovePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).RemovePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/RemovePolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).RemovePolicy(ctx, req.(*PolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_AddRoleInheritance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleInheritanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).AddRoleInheritance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/AddRoleInheritance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).AddRoleInheritance(ctx, req.(*RoleInheritanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_RemoveRoleInheritance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleInheritanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).RemoveRoleInheritance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/RemoveRoleInheritance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).RemoveRoleInheritance(ctx, req.(*RoleInheritanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_GrantRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).GrantRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/GrantRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).GrantRole(ctx, req.(*RoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_RevokeRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).RevokeRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/RevokeRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).RevokeRole(ctx, req.(*RoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_Authorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).Authorize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/Authorize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).Authorize(ctx, req.(*AuthorizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_AuthorizeBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizeBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).AuthorizeBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/AuthorizeBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).AuthorizeBatch(ctx, req.(*AuthorizeBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_LogoutAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).LogoutAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/LogoutAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).LogoutAll(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/RefreshToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).RefreshToken(ctx, req.(*TokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/Register",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).Register(ctx, req.(*RegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ConfirmAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountConfirmation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ConfirmAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ConfirmAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ConfirmAccount(ctx, req.(*AccountConfirmation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ResendRegistrationCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ResendRegistrationCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ResendRegistrationCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ResendRegistrationCode(ctx, req.(*ResendEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_RegisterAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).RegisterAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/RegisterAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).RegisterAgent(ctx, req.(*RegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ValidateAgentAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountConfirmation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ValidateAgentAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ValidateAgentAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ValidateAgentAccount(ctx, req.(*AccountConfirmation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ListAgentRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ListAgentRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ListAgentRequests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ListAgentRequests(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ConfirmAgentAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentConfirmation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ConfirmAgentAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ConfirmAgentAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ConfirmAgentAccount(ctx, req.(*AgentConfirmation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_DeleteProfileInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Username)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).DeleteProfileInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/DeleteProfileInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).DeleteProfileInfo(ctx, req.(*Username))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_SendMagicLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MagicLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).SendMagicLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/SendMagicLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).SendMagicLink(ctx, req.(*MagicLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_MagicLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MagicLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).MagicLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/MagicLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).MagicLogin(ctx, req.(*MagicLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_RequestEmailChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmailChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).RequestEmailChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/RequestEmailChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).RequestEmailChange(ctx, req.(*EmailChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ConfirmEmailChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmailChangeConfirmation)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ConfirmEmailChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ConfirmEmailChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ConfirmEmailChange(ctx, req.(*EmailChangeConfirmation))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_CancelEmailChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).CancelEmailChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/CancelEmailChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).CancelEmailChange(ctx, req.(*TokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_LockAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).LockAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/LockAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).LockAccount(ctx, req.(*TokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_IssueClientToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientCredentials)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).IssueClientToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/IssueClientToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).IssueClientToken(ctx, req.(*ClientCredentials))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_GetActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).GetActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/GetActivity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).GetActivity(ctx, req.(*ActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (UnimplementedAuthenticationServer) Login(context.Context, *LoginCredentials) (*LoginResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+func _Authentication_SearchActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivityFilter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).SearchActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/SearchActivity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).SearchActivity(ctx, req.(*ActivityFilter))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedAuthenticationServer) Logout(context.Context, *Tokens) (*BooleanResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+
+func _Authentication_ExportAuditLog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AuditExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AuthenticationServer).ExportAuditLog(m, &authenticationExportAuditLogServer{stream})
 }
-func (UnimplementedAuthenticationServer) ValidateToken(context.Context, *Tokens) (*TokenValidationResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ValidateToken not implemented")
+
+type Authentication_ExportAuditLogServer interface {
+	Send(*AuditEntry) error
+	grpc.ServerStream
 }
-func (UnimplementedAuthenticationServer) ResendEmail(context.Context, *ResendEmailRequest) (*BooleanResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ResendEmail not implemented")
+
+type authenticationExportAuditLogServer struct {
+	grpc.ServerStream
 }
-func (UnimplementedAuthenticationServer) GenerateSecret(context.Context, *AccessToken) (*ScanTotp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GenerateSecret not implemented")
+
+func (x *authenticationExportAuditLogServer) Send(m *AuditEntry) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (UnimplementedAuthenticationServer) ValidateTemporaryToken(context.Context, *AccessToken) (*AccessToken, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ValidateTemporaryToken not implemented")
+
+func _Authentication_StartImpersonation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImpersonationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).StartImpersonation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/StartImpersonation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).StartImpersonation(ctx, req.(*ImpersonationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedAuthenticationServer) ValidateTotp(context.Context, *TotpValidation) (*LoginResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ValidateTotp not implemented")
+
+func _Authentication_EndImpersonation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).EndImpersonation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/EndImpersonation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).EndImpersonation(ctx, req.(*TokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedAuthenticationServer) mustEmbedUnimplementedAuthenticationServer() {}
 
-// UnsafeAuthenticationServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to AuthenticationServer will
-// result in compilation errors.
-type UnsafeAuthenticationServer interface {
-	mustEmbedUnimplementedAuthenticationServer()
+func _Authentication_ListScopes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ListScopes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ListScopes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ListScopes(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterAuthenticationServer(s grpc.ServiceRegistrar, srv AuthenticationServer) {
-	s.RegisterService(&Authentication_ServiceDesc, srv)
+func _Authentication_AddRoleScope_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleScopeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).AddRoleScope(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/AddRoleScope",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).AddRoleScope(ctx, req.(*RoleScopeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Authentication_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginCredentials)
+func _Authentication_RemoveRoleScope_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleScopeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthenticationServer).Login(ctx, in)
+		return srv.(AuthenticationServer).RemoveRoleScope(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Authentication/Login",
+		FullMethod: "/Authentication/RemoveRoleScope",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthenticationServer).Login(ctx, req.(*LoginCredentials))
+		return srv.(AuthenticationServer).RemoveRoleScope(ctx, req.(*RoleScopeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Authentication_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Tokens)
+func _Authentication_ListOAuthClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthenticationServer).Logout(ctx, in)
+		return srv.(AuthenticationServer).ListOAuthClients(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Authentication/Logout",
+		FullMethod: "/Authentication/ListOAuthClients",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthenticationServer).Logout(ctx, req.(*Tokens))
+		return srv.(AuthenticationServer).ListOAuthClients(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Authentication_ValidateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Tokens)
+func _Authentication_CreateOAuthClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthClientRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthenticationServer).ValidateToken(ctx, in)
+		return srv.(AuthenticationServer).CreateOAuthClient(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Authentication/ValidateToken",
+		FullMethod: "/Authentication/CreateOAuthClient",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthenticationServer).ValidateToken(ctx, req.(*Tokens))
+		return srv.(AuthenticationServer).CreateOAuthClient(ctx, req.(*OAuthClientRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Authentication_ResendEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ResendEmailRequest)
+func _Authentication_DeleteOAuthClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OAuthClientRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthenticationServer).ResendEmail(ctx, in)
+		return srv.(AuthenticationServer).DeleteOAuthClient(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Authentication/ResendEmail",
+		FullMethod: "/Authentication/DeleteOAuthClient",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthenticationServer).ResendEmail(ctx, req.(*ResendEmailRequest))
+		return srv.(AuthenticationServer).DeleteOAuthClient(ctx, req.(*OAuthClientRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Authentication_GenerateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccessToken)
+func _Authentication_ReloadPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthenticationServer).GenerateSecret(ctx, in)
+		return srv.(AuthenticationServer).ReloadPolicy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Authentication/GenerateSecret",
+		FullMethod: "/Authentication/ReloadPolicy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthenticationServer).GenerateSecret(ctx, req.(*AccessToken))
+		return srv.(AuthenticationServer).ReloadPolicy(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Authentication_ValidateTemporaryToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccessToken)
+func _Authentication_ExplainPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthenticationServer).ValidateTemporaryToken(ctx, in)
+		return srv.(AuthenticationServer).ExplainPolicy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Authentication/ValidateTemporaryToken",
+		FullMethod: "/Authentication/ExplainPolicy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthenticationServer).ValidateTemporaryToken(ctx, req.(*AccessToken))
+		return srv.(AuthenticationServer).ExplainPolicy(ctx, req.(*PolicyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Authentication_ValidateTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TotpValidation)
+func _Authentication_DiffPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyDiffRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthenticationServer).ValidateTotp(ctx, in)
+		return srv.(AuthenticationServer).DiffPolicy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Authentication/ValidateTotp",
+		FullMethod: "/Authentication/DiffPolicy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthenticationServer).ValidateTotp(ctx, req.(*TotpValidation))
+		return srv.(AuthenticationServer).DiffPolicy(ctx, req.(*PolicyDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_ListOutboxMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OutboxListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).ListOutboxMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/ListOutboxMessages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).ListOutboxMessages(ctx, req.(*OutboxListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authentication_RedriveOutboxMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OutboxMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServer).RedriveOutboxMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Authentication/RedriveOutboxMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServer).RedriveOutboxMessage(ctx, req.(*OutboxMessageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -311,8 +1841,198 @@ var Authentication_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ValidateTotp",
 			Handler:    _Authentication_ValidateTotp_Handler,
 		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _Authentication_ResetPassword_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _Authentication_ChangePassword_Handler,
+		},
+		{
+			MethodName: "SuspendAccount",
+			Handler:    _Authentication_SuspendAccount_Handler,
+		},
+		{
+			MethodName: "BanAccount",
+			Handler:    _Authentication_BanAccount_Handler,
+		},
+		{
+			MethodName: "UnlockAccount",
+			Handler:    _Authentication_UnlockAccount_Handler,
+		},
+		{
+			MethodName: "ListPolicies",
+			Handler:    _Authentication_ListPolicies_Handler,
+		},
+		{
+			MethodName: "AddPolicy",
+			Handler:    _Authentication_AddPolicy_Handler,
+		},
+		{
+			MethodName: "RemovePolicy",
+			Handler:    _Authentication_RemovePolicy_Handler,
+		},
+		{
+			MethodName: "AddRoleInheritance",
+			Handler:    _Authentication_AddRoleInheritance_Handler,
+		},
+		{
+			MethodName: "RemoveRoleInheritance",
+			Handler:    _Authentication_RemoveRoleInheritance_Handler,
+		},
+		{
+			MethodName: "GrantRole",
+			Handler:    _Authentication_GrantRole_Handler,
+		},
+		{
+			MethodName: "RevokeRole",
+			Handler:    _Authentication_RevokeRole_Handler,
+		},
+		{
+			MethodName: "Authorize",
+			Handler:    _Authentication_Authorize_Handler,
+		},
+		{
+			MethodName: "AuthorizeBatch",
+			Handler:    _Authentication_AuthorizeBatch_Handler,
+		},
+		{
+			MethodName: "LogoutAll",
+			Handler:    _Authentication_LogoutAll_Handler,
+		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _Authentication_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Register",
+			Handler:    _Authentication_Register_Handler,
+		},
+		{
+			MethodName: "ConfirmAccount",
+			Handler:    _Authentication_ConfirmAccount_Handler,
+		},
+		{
+			MethodName: "ResendRegistrationCode",
+			Handler:    _Authentication_ResendRegistrationCode_Handler,
+		},
+		{
+			MethodName: "RegisterAgent",
+			Handler:    _Authentication_RegisterAgent_Handler,
+		},
+		{
+			MethodName: "ValidateAgentAccount",
+			Handler:    _Authentication_ValidateAgentAccount_Handler,
+		},
+		{
+			MethodName: "ListAgentRequests",
+			Handler:    _Authentication_ListAgentRequests_Handler,
+		},
+		{
+			MethodName: "ConfirmAgentAccount",
+			Handler:    _Authentication_ConfirmAgentAccount_Handler,
+		},
+		{
+			MethodName: "DeleteProfileInfo",
+			Handler:    _Authentication_DeleteProfileInfo_Handler,
+		},
+		{
+			MethodName: "SendMagicLink",
+			Handler:    _Authentication_SendMagicLink_Handler,
+		},
+		{
+			MethodName: "MagicLogin",
+			Handler:    _Authentication_MagicLogin_Handler,
+		},
+		{
+			MethodName: "RequestEmailChange",
+			Handler:    _Authentication_RequestEmailChange_Handler,
+		},
+		{
+			MethodName: "ConfirmEmailChange",
+			Handler:    _Authentication_ConfirmEmailChange_Handler,
+		},
+		{
+			MethodName: "CancelEmailChange",
+			Handler:    _Authentication_CancelEmailChange_Handler,
+		},
+		{
+			MethodName: "LockAccount",
+			Handler:    _Authentication_LockAccount_Handler,
+		},
+		{
+			MethodName: "IssueClientToken",
+			Handler:    _Authentication_IssueClientToken_Handler,
+		},
+		{
+			MethodName: "GetActivity",
+			Handler:    _Authentication_GetActivity_Handler,
+		},
+		{
+			MethodName: "SearchActivity",
+			Handler:    _Authentication_SearchActivity_Handler,
+		},
+		{
+			MethodName: "StartImpersonation",
+			Handler:    _Authentication_StartImpersonation_Handler,
+		},
+		{
+			MethodName: "EndImpersonation",
+			Handler:    _Authentication_EndImpersonation_Handler,
+		},
+		{
+			MethodName: "ListScopes",
+			Handler:    _Authentication_ListScopes_Handler,
+		},
+		{
+			MethodName: "AddRoleScope",
+			Handler:    _Authentication_AddRoleScope_Handler,
+		},
+		{
+			MethodName: "RemoveRoleScope",
+			Handler:    _Authentication_RemoveRoleScope_Handler,
+		},
+		{
+			MethodName: "ListOAuthClients",
+			Handler:    _Authentication_ListOAuthClients_Handler,
+		},
+		{
+			MethodName: "CreateOAuthClient",
+			Handler:    _Authentication_CreateOAuthClient_Handler,
+		},
+		{
+			MethodName: "DeleteOAuthClient",
+			Handler:    _Authentication_DeleteOAuthClient_Handler,
+		},
+		{
+			MethodName: "ReloadPolicy",
+			Handler:    _Authentication_ReloadPolicy_Handler,
+		},
+		{
+			MethodName: "ExplainPolicy",
+			Handler:    _Authentication_ExplainPolicy_Handler,
+		},
+		{
+			MethodName: "DiffPolicy",
+			Handler:    _Authentication_DiffPolicy_Handler,
+		},
+		{
+			MethodName: "ListOutboxMessages",
+			Handler:    _Authentication_ListOutboxMessages_Handler,
+		},
+		{
+			MethodName: "RedriveOutboxMessage",
+			Handler:    _Authentication_RedriveOutboxMessage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportAuditLog",
+			Handler:       _Authentication_ExportAuditLog_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "authentication.proto",
 }
 
@@ -0,0 +1,52 @@
+package implementation
+
+import (
+	"auth-service/domain"
+	helper2 "auth-service/grpc/helper"
+	pb "auth-service/grpc/server/authentication_server"
+	"context"
+	"github.com/microcosm-cc/bluemonday"
+	"strings"
+	"time"
+)
+
+func (s *AuthenticationServer) SuspendAccount(ctx context.Context, in *pb.AccountStatusRequest) (*pb.AccountStatus, error) {
+	userId, reason := sanitizeStatusRequest(in)
+	account, err := s.AccountStatusUsecase.Suspend(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), userId, time.Unix(in.Until, 0), reason)
+	return accountStatus(account, err)
+}
+
+func (s *AuthenticationServer) BanAccount(ctx context.Context, in *pb.AccountStatusRequest) (*pb.AccountStatus, error) {
+	userId, reason := sanitizeStatusRequest(in)
+	account, err := s.AccountStatusUsecase.Ban(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), userId, reason)
+	return accountStatus(account, err)
+}
+
+func (s *AuthenticationServer) UnlockAccount(ctx context.Context, in *pb.AccountStatusRequest) (*pb.AccountStatus, error) {
+	userId, reason := sanitizeStatusRequest(in)
+	account, err := s.AccountStatusUsecase.Unlock(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), userId, reason)
+	return accountStatus(account, err)
+}
+
+func sanitizeStatusRequest(in *pb.AccountStatusRequest) (string, string) {
+	policy := bluemonday.UGCPolicy()
+	return strings.TrimSpace(policy.Sanitize(in.UserId)), strings.TrimSpace(policy.Sanitize(in.Reason))
+}
+
+func accountStatus(account *domain.ProfileInfo, err error) (*pb.AccountStatus, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	status := &pb.AccountStatus{UserId: account.ID, Status: account.Status(), Reason: account.StatusReason}
+	if status.Status == domain.AccountSuspended {
+		status.SuspendedUntil = account.SuspendedUntil.Unix()
+	}
+
+	return status, nil
+}
+
+// LockAccount takes the token of the "this wasn't me" link from security alert mails.
+func (s *AuthenticationServer) LockAccount(ctx context.Context, in *pb.TokenRequest) (*pb.BooleanResponse, error) {
+	return booleanResponse(s.SecurityNotificationUsecase.LockAccount(ctx, strings.TrimSpace(in.Token)))
+}
@@ -0,0 +1,92 @@
+package implementation
+
+import (
+	"auth-service/domain"
+	helper2 "auth-service/grpc/helper"
+	pb "auth-service/grpc/server/authentication_server"
+	"auth-service/usecase"
+	"context"
+	"github.com/microcosm-cc/bluemonday"
+	"strings"
+	"time"
+)
+
+// GetActivity returns the recent login attempts of the caller.
+func (s *AuthenticationServer) GetActivity(ctx context.Context, in *pb.ActivityRequest) (*pb.LoginEventList, error) {
+	userId := helper2.CallerId(ctx, s.AuthenticationUsecase)
+	if userId == "" {
+		return nil, usecase.ErrInvalidToken
+	}
+
+	return loginEvents(s.LoginEventUsecase.GetActivity(ctx, userId, int(in.Limit)))
+}
+
+// SearchActivity lets admins query the login attempts of any account.
+func (s *AuthenticationServer) SearchActivity(ctx context.Context, in *pb.ActivityFilter) (*pb.LoginEventList, error) {
+	policy := bluemonday.UGCPolicy()
+	filter := domain.LoginEventFilter{
+		ProfileInfoId: strings.TrimSpace(policy.Sanitize(in.UserId)),
+		Username:      strings.TrimSpace(policy.Sanitize(in.Username)),
+		IpAddress:     strings.TrimSpace(policy.Sanitize(in.Ip)),
+		Limit:         int(in.Limit),
+	}
+	if in.Success != nil {
+		success := in.Success.Value
+		filter.Success = &success
+	}
+	if in.Since != 0 {
+		filter.Since = time.Unix(in.Since, 0)
+	}
+	if in.Until != 0 {
+		filter.Until = time.Unix(in.Until, 0)
+	}
+
+	return loginEvents(s.LoginEventUsecase.Search(ctx, filter))
+}
+
+// ExportAuditLog streams the audit log in chain order after the given entry.
+func (s *AuthenticationServer) ExportAuditLog(in *pb.AuditExportRequest, stream pb.Authentication_ExportAuditLogServer) error {
+	ctx := stream.Context()
+	s.AuditUsecase.Record(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), "audit_log", domain.AuditLogExported, nil)
+
+	return s.AuditUsecase.Export(ctx, uint(in.AfterId), func(entry domain.AuditEntry) error {
+		return stream.Send(&pb.AuditEntry{
+			Id:        uint64(entry.ID),
+			CreatedAt: entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+			Actor:     entry.Actor,
+			Target:    entry.Target,
+			Action:    entry.Action,
+			Outcome:   entry.Outcome,
+			Details:   entry.Details,
+			RequestId: entry.RequestId,
+			IpAddress: entry.IpAddress,
+			PrevHash:  entry.PrevHash,
+			Hash:      entry.Hash,
+		})
+	})
+}
+
+func loginEvents(events []domain.LoginEvent, err error) (*pb.LoginEventList, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	list := &pb.LoginEventList{}
+	for _, event := range events {
+		list.Events = append(list.Events, &pb.LoginEvent{
+			Id:            uint64(event.ID),
+			CreatedAt:     event.CreatedAt.Unix(),
+			ProfileInfoId: event.ProfileInfoId,
+			Username:      event.Username,
+			Success:       event.Success,
+			Reason:        event.Reason,
+			Method:        event.Method,
+			MfaMethod:     event.MfaMethod,
+			IpAddress:     event.IpAddress,
+			UserAgent:     event.UserAgent,
+			ClientId:      event.ClientId,
+		})
+	}
+
+	return list, nil
+}
@@ -4,20 +4,20 @@ import (
 	"auth-service/domain"
 	helper2 "auth-service/grpc/helper"
 	pb "auth-service/grpc/server/authentication_server"
-	"auth-service/helper"
+	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/saga"
 	"auth-service/usecase"
+	"bytes"
 	"context"
-	"fmt"
+	"encoding/base64"
 	"github.com/microcosm-cc/bluemonday"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"image/jpeg"
 	"strings"
-	"time"
 )
 
-const (
-	redisKeyPattern = "passwordResetRequest"
-)
-
-
 type AuthenticationServer struct {
 	pb.UnimplementedAuthenticationServer
 	ProfileInfoUsecase usecase.ProfileInfoUsecase
@@ -25,12 +25,34 @@ type AuthenticationServer struct {
 	JwtUsecase usecase.JwtUsecase
 	AuthenticationUsecase usecase.AuthenticationUsecase
 	RedisUsecase usecase.RedisUsecase
+	MailUsecase usecase.MailUsecase
+	KnownDeviceUsecase usecase.KnownDeviceUsecase
+	BruteForceUsecase usecase.BruteForceUsecase
+	LoginEventUsecase usecase.LoginEventUsecase
+	AuditUsecase usecase.AuditUsecase
+	AccountStatusUsecase usecase.AccountStatusUsecase
+	PolicyUsecase usecase.PolicyUsecase
+	RoleUsecase usecase.RoleUsecase
+	AuthorizationUsecase usecase.AuthorizationUsecase
+	RegistrationUsecase usecase.RegistrationUsecase
+	Orchestrator saga.Orchestrator
+	MagicLinkUsecase usecase.MagicLinkUsecase
+	EmailChangeUsecase usecase.EmailChangeUsecase
+	SecurityNotificationUsecase usecase.SecurityNotificationUsecase
+	SecurityEventUsecase usecase.SecurityEventUsecase
+	AccessUsecase usecase.AccessUsecase
+	ImpersonationUsecase usecase.ImpersonationUsecase
+	ScopeUsecase usecase.ScopeUsecase
+	OAuthClientUsecase usecase.OAuthClientUsecase
+	OutboxUsecase usecase.OutboxUsecase
+	SessionUsecase usecase.SessionUsecase
 }
 
 
 
-func NewAuthenticationServiceImpl(profileInfoUsecase usecase.ProfileInfoUsecase, totpUsecase usecase.TotpUsecase, jwtUsecase usecase.JwtUsecase, authenticationUsecase usecase.AuthenticationUsecase, redisUsecase usecase.RedisUsecase) *AuthenticationServer {
-	return &AuthenticationServer{ProfileInfoUsecase: profileInfoUsecase, TotpUsecase: totpUsecase, JwtUsecase: jwtUsecase, AuthenticationUsecase: authenticationUsecase, RedisUsecase: redisUsecase}
+func NewAuthenticationServiceImpl(profileInfoUsecase usecase.ProfileInfoUsecase, totpUsecase usecase.TotpUsecase, jwtUsecase usecase.JwtUsecase, authenticationUsecase usecase.AuthenticationUsecase, redisUsecase usecase.RedisUsecase, mailUsecase usecase.MailUsecase, knownDeviceUsecase usecase.KnownDeviceUsecase, bruteForceUsecase usecase.BruteForceUsecase, loginEventUsecase usecase.LoginEventUsecase, auditUsecase usecase.AuditUsecase, accountStatusUsecase usecase.AccountStatusUsecase, policyUsecase usecase.PolicyUsecase, roleUsecase usecase.RoleUsecase, authorizationUsecase usecase.AuthorizationUsecase,
+	registrationUsecase usecase.RegistrationUsecase, orchestrator saga.Orchestrator, magicLinkUsecase usecase.MagicLinkUsecase, emailChangeUsecase usecase.EmailChangeUsecase, securityNotificationUsecase usecase.SecurityNotificationUsecase, securityEventUsecase usecase.SecurityEventUsecase, accessUsecase usecase.AccessUsecase, impersonationUsecase usecase.ImpersonationUsecase, scopeUsecase usecase.ScopeUsecase, oauthClientUsecase usecase.OAuthClientUsecase, outboxUsecase usecase.OutboxUsecase, sessionUsecase usecase.SessionUsecase) *AuthenticationServer {
+	return &AuthenticationServer{ProfileInfoUsecase: profileInfoUsecase, TotpUsecase: totpUsecase, JwtUsecase: jwtUsecase, AuthenticationUsecase: authenticationUsecase, RedisUsecase: redisUsecase, MailUsecase: mailUsecase, KnownDeviceUsecase: knownDeviceUsecase, BruteForceUsecase: bruteForceUsecase, LoginEventUsecase: loginEventUsecase, AuditUsecase: auditUsecase, AccountStatusUsecase: accountStatusUsecase, PolicyUsecase: policyUsecase, RoleUsecase: roleUsecase, AuthorizationUsecase: authorizationUsecase, RegistrationUsecase: registrationUsecase, Orchestrator: orchestrator, MagicLinkUsecase: magicLinkUsecase, EmailChangeUsecase: emailChangeUsecase, SecurityNotificationUsecase: securityNotificationUsecase, SecurityEventUsecase: securityEventUsecase, AccessUsecase: accessUsecase, ImpersonationUsecase: impersonationUsecase, ScopeUsecase: scopeUsecase, OAuthClientUsecase: oauthClientUsecase, OutboxUsecase: outboxUsecase, SessionUsecase: sessionUsecase}
 }
 
 func (s *AuthenticationServer) Login(ctx context.Context, in *pb.LoginCredentials) (*pb.LoginResponse, error) {
@@ -38,38 +60,146 @@ func (s *AuthenticationServer) Login(ctx context.Context, in *pb.LoginCredential
 
 	policy := bluemonday.UGCPolicy()
 	in.Username = strings.TrimSpace(policy.Sanitize(in.Username))
-	in.Password = strings.TrimSpace(policy.Sanitize(in.Password))
+	in.Password = strings.TrimSpace(in.Password)
+
+	if err := s.BruteForceUsecase.Check(ctx, usecase.BruteForceLogin, in.Username); err != nil {
+		s.loginFailed(ctx, domain.ProfileInfo{Username: in.Username}, domain.LoginMethodPassword, domain.LoginThrottled)
+		return nil, err
+	}
 
 	profileInfo, err := s.ProfileInfoUsecase.GetProfileInfoByUsername(ctx, in.Username)
 
 	if err != nil {
-		return nil, err
+		s.BruteForceUsecase.Fail(ctx, usecase.BruteForceLogin, in.Username)
+		s.loginFailed(ctx, domain.ProfileInfo{Username: in.Username}, domain.LoginMethodPassword, domain.LoginUnknownUser)
+		return nil, usecase.ErrInvalidCredentials
 	}
 
 	if err := usecase.VerifyPassword(ctx, in.Password, profileInfo.Password); err != nil {
+		s.BruteForceUsecase.Fail(ctx, usecase.BruteForceLogin, in.Username)
+		s.loginFailed(ctx, profileInfo, domain.LoginMethodPassword, domain.LoginInvalidPassword)
 		return nil, err
 	}
 
-	_, err = s.TotpUsecase.GetSecretByProfileInfoId(ctx, profileInfo.ID)
+	s.BruteForceUsecase.Succeed(ctx, usecase.BruteForceLogin, in.Username)
+
+	return s.completeLogin(ctx, profileInfo, domain.LoginMethodPassword)
+}
+
+// completeLogin issues tokens, challenging accounts with TOTP enabled first.
+func (s *AuthenticationServer) completeLogin(ctx context.Context, profileInfo domain.ProfileInfo, method string) (*pb.LoginResponse, error) {
+	if err := usecase.CheckAccountStatus(profileInfo); err != nil {
+		s.loginFailed(ctx, profileInfo, method, domain.LoginStatusReasons[profileInfo.Status()])
+		return nil, err
+	}
+
+	_, err := s.TotpUsecase.GetSecretByProfileInfoId(ctx, profileInfo.ID)
 
 	if err == nil {
-		userInfo, err := s.CreateTemporaryToken(ctx, profileInfo)
+		session, err := s.SessionUsecase.IssueTemporaryToken(ctx, profileInfo)
 		if err != nil {
 			return nil, err
 		}
-		userInfo.Role = "temporary_user"
+		s.loginFailed(ctx, profileInfo, method, domain.LoginMfaChallenge)
+
+		return loginResponse(session), nil
+	}
+
+	session, err := s.SessionUsecase.IssueTokens(ctx, profileInfo)
+	if err != nil {
+		s.loginFailed(ctx, profileInfo, method, domain.LoginTokenError)
+		return nil, err
+	}
+
+	s.loginSucceeded(ctx, profileInfo, method, domain.MfaNone)
+	return loginResponse(session), nil
+}
 
-		return userInfo, err
+func loginResponse(session *domain.Session) *pb.LoginResponse {
+	return &pb.LoginResponse{
+		AccessToken:  session.TokenUuid,
+		RefreshToken: session.RefreshUuid,
+		Role:         session.Role,
+		Roles:        session.Roles,
+		Id:           session.UserId,
+		Username:     session.Username,
 	}
+}
 
-	val, err := s.generateToken(ctx, profileInfo, true)
+// LogoutAll revokes every session of the caller.
+func (s *AuthenticationServer) LogoutAll(ctx context.Context, in *pb.Empty) (*pb.BooleanResponse, error) {
+	userId := helper2.CallerId(ctx, s.AuthenticationUsecase)
+	if userId == "" {
+		return nil, usecase.ErrInvalidToken
+	}
 
+	err := s.AuthenticationUsecase.RevokeUserSessions(ctx, userId)
+	s.AuditUsecase.Record(ctx, userId, userId, domain.AuditSessionsRevoked, err)
+	if err != nil {
+		return nil, err
+	}
 
+	s.SecurityEventUsecase.Publish(ctx, domain.SecurityEvent{Type: domain.SecurityEventSessionsRevoked, ProfileInfoId: userId})
+	return &pb.BooleanResponse{Success: true}, nil
+}
+
+// DeleteProfileInfo deletes the profile with the given username if the resource policy allows it.
+func (s *AuthenticationServer) DeleteProfileInfo(ctx context.Context, in *pb.Username) (*pb.BooleanResponse, error) {
+	username := strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(in.Username))
+	actor := helper2.CallerId(ctx, s.AuthenticationUsecase)
+
+	profile := s.AccessUsecase.ProfileResource(ctx, username)
+	subject := s.AccessUsecase.Subject(ctx, actor, helper2.CallerSubjects(ctx, s.AuthenticationUsecase))
+	err := s.AccessUsecase.Authorize(ctx, subject, profile, domain.ResourceActionDelete)
+	if err == nil && profile.Id == "" {
+		return nil, domain.NotFound("user does not exist")
+	}
+	if err == nil {
+		err = s.ProfileInfoUsecase.DeleteProfileInfo(ctx, username)
+	}
+	s.AuditUsecase.Record(ctx, actor, username, domain.AuditProfileDeleted, err)
+
+	return booleanResponse(err)
+}
+
+// SendMagicLink mails a sign in link and returns the nonce to send with its token.
+func (s *AuthenticationServer) SendMagicLink(ctx context.Context, in *pb.MagicLinkRequest) (*pb.MagicLinkResponse, error) {
+	if !s.MagicLinkUsecase.Enabled() {
+		return nil, status.Error(codes.Unimplemented, "magic links are disabled")
+	}
+
+	email := strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(in.Email))
+	if email == "" {
+		return nil, domain.InvalidArgument("email is required", domain.FieldViolation{Field: "email", Description: "email is required"})
+	}
+
+	nonce, err := s.MagicLinkUsecase.SendLink(ctx, email)
 	if err != nil {
 		return nil, err
 	}
 
-	return val, nil
+	return &pb.MagicLinkResponse{Nonce: nonce}, nil
+}
+
+func (s *AuthenticationServer) MagicLogin(ctx context.Context, in *pb.MagicLoginRequest) (*pb.LoginResponse, error) {
+	if !s.MagicLinkUsecase.Enabled() {
+		return nil, status.Error(codes.Unimplemented, "magic links are disabled")
+	}
+
+	nonce := strings.TrimSpace(in.Nonce)
+	if headers, ok := metadata.FromIncomingContext(ctx); ok && nonce == "" && len(headers.Get(helper2.MagicLinkNonceKey)) == 1 {
+		nonce = headers.Get(helper2.MagicLinkNonceKey)[0]
+	}
+	if nonce == "" {
+		return nil, usecase.ErrInvalidMagicLink
+	}
+
+	profileInfo, err := s.MagicLinkUsecase.ConsumeLink(ctx, strings.TrimSpace(in.Token), nonce)
+	if err != nil {
+		return nil, usecase.ErrInvalidMagicLink
+	}
+
+	return s.completeLogin(ctx, *profileInfo, domain.LoginMethodMagicLink)
 }
 
 func (s *AuthenticationServer) Logout(ctx context.Context, in *pb.Tokens) (*pb.BooleanResponse, error) {
@@ -98,7 +228,7 @@ func (s *AuthenticationServer) ValidateToken(ctx context.Context, in *pb.Tokens)
 
 	//If token exists, but is not valid
 	if errAt == nil && errAtValidation != nil {
-		return nil, errAt
+		return nil, usecase.ErrInvalidToken
 	}
 
 	newToken := &pb.TokenValidationResponse{
@@ -110,111 +240,95 @@ func (s *AuthenticationServer) ValidateToken(ctx context.Context, in *pb.Tokens)
 	var errRt error
 	if errAt != nil || token == "" {
 
-		newToken, errRt = s.RefreshToken(ctx, in.RefreshToken)
+		newToken, errRt = s.refreshToken(ctx, in.RefreshToken)
 		if errRt != nil {
 			return nil, errRt
 		}
 	}
 
+	userId, err := helper2.ExtractUserIdFromToken(newToken.AccessToken)
+	if err != nil || userId == nil {
+		return nil, domain.Unauthenticated(domain.ReasonInvalidToken, "token has no user")
+	}
+	if err := s.AccountStatusUsecase.Check(ctx, *userId); err != nil {
+		return nil, err
+	}
+
 	return newToken, nil
 }
 
 func (s *AuthenticationServer) ResendEmail(ctx context.Context, in *pb.ResendEmailRequest) (*pb.BooleanResponse, error) {
+	email := strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(in.Email))
 
+	return booleanResponse(s.ProfileInfoUsecase.SendResetMail(ctx, email))
+}
 
-	policy := bluemonday.UGCPolicy()
-	in.Email = strings.TrimSpace(policy.Sanitize(in.Email))
-
-
-	exists := s.ProfileInfoUsecase.ExistsByUsernameOrEmail(ctx, "", in.Email)
-	if !exists {
-		return nil, fmt.Errorf("User does not exists")
+// GenerateSecret starts enabling TOTP for the owner of the access token.
+func (s *AuthenticationServer) GenerateSecret(ctx context.Context, in *pb.AccessToken) (*pb.ScanTotp, error) {
+	tokenUuid := strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(in.AccessToken))
+	if tokenUuid == "" {
+		if headers, ok := metadata.FromIncomingContext(ctx); ok && len(headers["authorization"]) == 1 {
+			tokenUuid = headers["authorization"][0]
+		}
 	}
 
-	user, _ := s.ProfileInfoUsecase.GetProfileInfoByEmail(ctx, in.Email)
-
-	code := helper.RandomStringGenerator(8)
-
-	expiration := 1000000000 * 3600 * 2 //2h
-
-	hash, _ := helper.Hash(code)
-
-	go usecase.SendRestartPasswordMail(user.Email, code)
-
-	redisKey := redisKeyPattern + user.Email
-
-	err := s.RedisUsecase.AddKeyValueSet(ctx, redisKey, hash, time.Duration(expiration))
+	at, err := s.AuthenticationUsecase.FetchAuthToken(ctx, tokenUuid)
 	if err != nil {
-		return nil, err
+		return nil, usecase.ErrInvalidToken
+	}
+	userId, err := helper2.ExtractUserIdFromToken(string(at))
+	if err != nil || userId == nil {
+		return nil, usecase.ErrInvalidToken
 	}
 
-	return &pb.BooleanResponse{Success: true}, nil
-}
-func (s *AuthenticationServer) CreateTemporaryToken(ctx context.Context, profileInfo domain.ProfileInfo) (*pb.LoginResponse, error) {
-
-
-
-	temporaryToken, err := s.JwtUsecase.CreateTemporaryToken(ctx, profileInfo.Role.RoleName, profileInfo.ID)
-
+	user, err := s.ProfileInfoUsecase.GetProfileInfoById(ctx, *userId)
 	if err != nil {
 		return nil, err
 	}
 
-	loginResponse := &pb.LoginResponse{
-		AccessToken: temporaryToken.TokenUuid,
-		Role:  profileInfo.Role.RoleName,
-		Id:    profileInfo.ID,
+	key, err := s.TotpUsecase.GenereateTotpSecret(ctx, user.Username)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.AuthenticationUsecase.SaveTemporaryToken(ctx, temporaryToken); err != nil {
-
+	if err := s.TotpUsecase.SaveSecretTemporarily(ctx, user.ID, key.Secret()); err != nil {
 		return nil, err
 	}
 
-
-
-	return loginResponse, nil
-}
-
-func (s *AuthenticationServer) generateToken(ctx context.Context, profileInfo domain.ProfileInfo, refresh bool) (*pb.LoginResponse, error) {
-
-
-	token, err := s.JwtUsecase.CreateToken(ctx, profileInfo.Role.RoleName, profileInfo.ID, true)
+	img, err := s.TotpUsecase.GetSecretImage(ctx, key, 300, 300)
 	if err != nil {
-
 		return nil, err
 	}
-	authenticatedUserInfo := pb.LoginResponse{
-		AccessToken: token.TokenUuid,
-		Role:  profileInfo.Role.RoleName,
-		Id:    profileInfo.ID,
-		RefreshToken: token.RefreshUuid,
+
+	var qrCode bytes.Buffer
+	if err := jpeg.Encode(&qrCode, *img, nil); err != nil {
+		return nil, err
 	}
 
-	return &authenticatedUserInfo, nil
+	return &pb.ScanTotp{QrCode: "data:image/jpg;base64," + base64.StdEncoding.EncodeToString(qrCode.Bytes()), Secret: key.Secret()}, nil
 }
 
+// ValidateTemporaryToken returns the TOTP challenge token while the account is available.
 func (s *AuthenticationServer) ValidateTemporaryToken(ctx context.Context, in *pb.AccessToken) (*pb.AccessToken, error) {
-
-
-	at, err := s.AuthenticationUsecase.FetchTemporaryToken(ctx, in.AccessToken)
-
+	at, err := s.AuthenticationUsecase.FetchTemporaryToken(ctx, strings.TrimSpace(in.AccessToken))
 	if err != nil {
 		return nil, err
 	}
 
 	token, err := s.JwtUsecase.ValidateToken(ctx, string(at))
+	if err != nil || token == "" {
+		return nil, usecase.ErrInvalidToken
+	}
 
-	if err != nil{
-		return nil, err
+	userId, err := helper2.ExtractUserIdFromToken(token)
+	if err != nil || userId == nil {
+		return nil, usecase.ErrInvalidToken
 	}
-	if token == "" {
-		return nil, fmt.Errorf("")
+	if err := s.AccountStatusUsecase.Check(ctx, *userId); err != nil {
+		return nil, err
 	}
 
-
 	return &pb.AccessToken{AccessToken: token}, nil
-
 }
 
 func (s *AuthenticationServer) ValidateTotp(ctx context.Context, in *pb.TotpValidation) (*pb.LoginResponse, error) {
@@ -228,28 +342,36 @@ func (s *AuthenticationServer) ValidateTotp(ctx context.Context, in *pb.TotpVali
 	}
 
 	token, err := s.JwtUsecase.ValidateToken(ctx, string(at))
-
-	if err != nil{
-		return nil, err
-	}
-	if token == "" {
-		return nil, fmt.Errorf("")
+	if err != nil || token == "" {
+		return nil, usecase.ErrInvalidToken
 	}
 
 	userId, err := helper2.ExtractUserIdFromToken(token)
+	if err != nil || userId == nil {
+		return nil, usecase.ErrInvalidToken
+	}
 
-	if err != nil {
+	if err := s.BruteForceUsecase.Check(ctx, usecase.BruteForceTotp, *userId); err != nil {
+		s.loginFailed(ctx, domain.ProfileInfo{ID: *userId}, domain.LoginMethodTotp, domain.LoginThrottled)
 		return nil, err
 	}
 
 	if !s.TotpUsecase.Validate(ctx, *userId, in.Passcode) {
-		return nil, err
+		s.BruteForceUsecase.Fail(ctx, usecase.BruteForceTotp, *userId)
+		s.loginFailed(ctx, domain.ProfileInfo{ID: *userId}, domain.LoginMethodTotp, domain.LoginInvalidTotp)
+		return nil, domain.Unauthenticated(domain.ReasonInvalidPasscode, "passcode is not valid")
 	}
+	s.BruteForceUsecase.Succeed(ctx, usecase.BruteForceTotp, *userId)
 
 	profileInfo, err := s.ProfileInfoUsecase.GetProfileInfoById(ctx, *userId)
-	//TODO: add refresh bool in structure
-	val, err := s.generateToken(ctx, *profileInfo, true)
-
+	if err != nil {
+		return nil, err
+	}
+	if err := usecase.CheckAccountStatus(*profileInfo); err != nil {
+		s.loginFailed(ctx, *profileInfo, domain.LoginMethodTotp, domain.LoginStatusReasons[profileInfo.Status()])
+		return nil, err
+	}
+	session, err := s.SessionUsecase.IssueTokens(ctx, *profileInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -264,10 +386,32 @@ func (s *AuthenticationServer) ValidateTotp(ctx context.Context, in *pb.TotpVali
 		return nil, err
 	}
 
-	return val, nil
+	s.loginSucceeded(ctx, *profileInfo, domain.LoginMethodTotp, domain.MfaTotp)
+	return loginResponse(session), nil
+}
+
+// loginSucceeded never fails a login, the repositories log their own errors.
+func (s *AuthenticationServer) loginSucceeded(ctx context.Context, profileInfo domain.ProfileInfo, method, mfaMethod string) {
+	s.LoginEventUsecase.Record(ctx, domain.LoginEvent{
+		ProfileInfoId: profileInfo.ID,
+		Username: profileInfo.Username,
+		Success: true,
+		Method: method,
+		MfaMethod: mfaMethod,
+	})
+	_ = s.KnownDeviceUsecase.RecordLogin(ctx, profileInfo.ID)
 }
 
-func (s *AuthenticationServer) RefreshToken(context context.Context, refreshTokenUuid string) (*pb.TokenValidationResponse, error) {
+func (s *AuthenticationServer) loginFailed(ctx context.Context, profileInfo domain.ProfileInfo, method, reason string) {
+	s.LoginEventUsecase.Record(ctx, domain.LoginEvent{
+		ProfileInfoId: profileInfo.ID,
+		Username: profileInfo.Username,
+		Method: method,
+		Reason: reason,
+	})
+}
+
+func (s *AuthenticationServer) refreshToken(context context.Context, refreshTokenUuid string) (*pb.TokenValidationResponse, error) {
 
 
 	rt, err := s.AuthenticationUsecase.FetchRefreshToken(context, refreshTokenUuid)
@@ -278,7 +422,7 @@ func (s *AuthenticationServer) RefreshToken(context context.Context, refreshToke
 	refreshToken, err := s.JwtUsecase.ValidateToken(context, string(rt))
 
 	if err != nil || refreshToken == "" {
-		return nil, err
+		return nil, usecase.ErrInvalidToken
 	}
 
 	token, uuid, err := s.JwtUsecase.RefreshToken(context, refreshToken)
@@ -294,3 +438,99 @@ func (s *AuthenticationServer) RefreshToken(context context.Context, refreshToke
 
 	return ret, nil
 }
+
+// RefreshToken issues a new access token for a single-use refresh token.
+func (s *AuthenticationServer) RefreshToken(ctx context.Context, in *pb.TokenRequest) (*pb.TokenValidationResponse, error) {
+	refreshTokenUuid := strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(in.Token))
+
+	rt, err := s.AuthenticationUsecase.FetchRefreshToken(ctx, refreshTokenUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	userId, err := helper2.ExtractUserIdFromToken(string(rt))
+	if err != nil || userId == nil {
+		return nil, usecase.ErrInvalidToken
+	}
+	if err := s.AccountStatusUsecase.Check(ctx, *userId); err != nil {
+		return nil, err
+	}
+
+	token, uuid, err := s.JwtUsecase.RefreshToken(ctx, string(rt))
+	if err != nil {
+		return nil, usecase.ErrInvalidToken
+	}
+
+	if err := s.JwtUsecase.DeleteRefreshToken(ctx, refreshTokenUuid); err != nil {
+		return nil, err
+	}
+
+	return &pb.TokenValidationResponse{AccessToken: *token, AccessTokenUuid: *uuid}, nil
+}
+
+func (s *AuthenticationServer) ResetPassword(ctx context.Context, in *pb.ResetPasswordRequest) (*pb.PasswordResponse, error) {
+
+	policy := bluemonday.UGCPolicy()
+	resetDto := dto.ResetPassDTO{
+		Email: strings.TrimSpace(policy.Sanitize(in.Email)),
+		Password: strings.TrimSpace(in.Password),
+		ConfirmedPassword: strings.TrimSpace(in.ConfirmedPassword),
+		VerificationCode: strings.TrimSpace(policy.Sanitize(in.Code)),
+	}
+
+	err := s.ProfileInfoUsecase.ResetPassword(ctx, resetDto)
+	s.AuditUsecase.Record(ctx, domain.AuditActorAnonymous, resetDto.Email, domain.AuditPasswordReset, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PasswordResponse{Success: true}, nil
+}
+
+func (s *AuthenticationServer) ChangePassword(ctx context.Context, in *pb.ChangePasswordRequest) (*pb.PasswordResponse, error) {
+
+	tokenUuid, token, err := s.fetchAuthToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userId, err := helper2.ExtractUserIdFromToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	changeDto := dto.ChangePasswordDto{
+		OldPassword: strings.TrimSpace(in.OldPassword),
+		Password: strings.TrimSpace(in.Password),
+		ConfirmedPassword: strings.TrimSpace(in.ConfirmedPassword),
+	}
+
+	err = s.ProfileInfoUsecase.ChangePassword(ctx, *userId, changeDto)
+	s.AuditUsecase.Record(ctx, *userId, *userId, domain.AuditPasswordChanged, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if role, err := s.JwtUsecase.ExtractRole(ctx, token); err == nil && *role == domain.RolePasswordExpired {
+		if err := s.AuthenticationUsecase.DeleteAuthToken(ctx, tokenUuid); err != nil {
+			return nil, err
+		}
+	}
+
+	return &pb.PasswordResponse{Success: true}, nil
+}
+
+func (s *AuthenticationServer) fetchAuthToken(ctx context.Context) (string, string, error) {
+	headers, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(headers["authorization"]) != 1 {
+		return "", "", domain.Unauthenticated(domain.ReasonMissingToken, "authorization metadata does not exist")
+	}
+
+	tokenUuid := headers["authorization"][0]
+	at, err := s.AuthenticationUsecase.FetchAuthToken(ctx, tokenUuid)
+	if err != nil {
+		return "", "", err
+	}
+
+	return tokenUuid, string(at), nil
+}
@@ -0,0 +1,141 @@
+package implementation
+
+import (
+	"auth-service/domain"
+	pb "auth-service/grpc/server/authentication_server"
+	"auth-service/infrastructure/policy"
+	"auth-service/repository"
+	"auth-service/usecase"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"google.golang.org/grpc/metadata"
+)
+
+type resourcePolicy struct {
+	policy.Enforcer
+	snapshot *policy.Snapshot
+}
+
+func (r resourcePolicy) EnforceResource(sub, obj interface{}, act string) (bool, []string, error) {
+	return r.snapshot.EnforceResource(sub, obj, act)
+}
+
+type profiles struct {
+	repository.ProfileInfoRepository
+	usernames map[string]string
+}
+
+func (p profiles) GetProfileInfoById(context context.Context, id string) (*domain.ProfileInfo, error) {
+	for username, profileId := range p.usernames {
+		if profileId == id {
+			return &domain.ProfileInfo{ID: id, Username: username}, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (p profiles) GetProfileInfoByUsername(context context.Context, username string) (domain.ProfileInfo, error) {
+	if id, ok := p.usernames[username]; ok {
+		return domain.ProfileInfo{ID: id, Username: username}, nil
+	}
+	return domain.ProfileInfo{}, errors.New("record not found")
+}
+
+type deletedProfiles struct {
+	usecase.ProfileInfoUsecase
+	usernames []string
+}
+
+func (d *deletedProfiles) DeleteProfileInfo(ctx context.Context, username string) error {
+	d.usernames = append(d.usernames, username)
+	return nil
+}
+
+type sessions struct {
+	usecase.AuthenticationUsecase
+	tokens map[string]string
+}
+
+func (s sessions) FetchAuthToken(ctx context.Context, tokenUuid string) ([]byte, error) {
+	if token, ok := s.tokens[tokenUuid]; ok {
+		return []byte(token), nil
+	}
+	return nil, errors.New("redis: nil")
+}
+
+type audit struct {
+	usecase.AuditUsecase
+}
+
+func (audit) Record(context context.Context, actor, target, action string, err error) {}
+
+func session(t *testing.T, userId, role string) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"access_uuid": userId, "user_id": userId, "role": role}).
+		SignedString([]byte(os.Getenv("ACCESS_SECRET")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestDeleteProfileInfo(t *testing.T) {
+	rules, err := os.Open("../../../../infrastructure/policy/resource_policy.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rules.Close()
+	snapshot, err := policy.LoadSnapshot("../../../../infrastructure/policy/resource_model.conf", rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log := logger.InitializeLogger("auth-service", context.Background())
+	accounts := profiles{usernames: map[string]string{"jelena": "1", "marko": "2"}}
+	tokens := sessions{tokens: map[string]string{"1": session(t, "1", "user"), "admin": session(t, "admin", "admin")}}
+
+	tests := []struct {
+		name        string
+		caller      string
+		username    string
+		wantKind    string
+		wantDeleted bool
+	}{
+		{name: "own profile", caller: "1", username: "jelena", wantDeleted: true},
+		{name: "someone else's profile", caller: "1", username: "marko", wantKind: domain.ErrorPermissionDenied},
+		{name: "admin", caller: "admin", username: "marko", wantDeleted: true},
+		{name: "admin deleting a missing profile", caller: "admin", username: "nobody", wantKind: domain.ErrorNotFound},
+		{name: "anonymous", username: "jelena", wantKind: domain.ErrorPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := &deletedProfiles{}
+			server := &AuthenticationServer{
+				ProfileInfoUsecase:    deleted,
+				AuthenticationUsecase: tokens,
+				AuditUsecase:          audit{},
+				AccessUsecase:         usecase.NewAccessUsecase(resourcePolicy{snapshot: snapshot}, accounts, log),
+			}
+			ctx := context.Background()
+			if tt.caller != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.caller))
+			}
+
+			_, err := server.DeleteProfileInfo(ctx, &pb.Username{Username: tt.username})
+			if tt.wantKind == "" && err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if tt.wantKind != "" && domain.AsError(err).Kind != tt.wantKind {
+				t.Fatalf("err = %v, want %v", err, tt.wantKind)
+			}
+			if tt.wantDeleted != (len(deleted.usernames) == 1) {
+				t.Errorf("deleted %v", deleted.usernames)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package implementation
+
+import (
+	"auth-service/domain"
+	pb "auth-service/grpc/server/authentication_server"
+	"context"
+	"github.com/microcosm-cc/bluemonday"
+	"strings"
+)
+
+// Authorize is the gRPC side of the policy decision point.
+func (s *AuthenticationServer) Authorize(ctx context.Context, in *pb.AuthorizeRequest) (*pb.AuthorizeResponse, error) {
+	checks := sanitizeAuthorizationChecks([]*pb.AuthorizationCheck{{Object: in.Object, Action: in.Action}})
+	decisions, ttl, err := s.AuthorizationUsecase.Authorize(ctx, authorizationTransport(in.Transport), strings.TrimSpace(in.Token), checks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AuthorizeResponse{Decision: authorizationDecision(decisions[0]), TtlSeconds: int64(ttl.Seconds())}, nil
+}
+
+func (s *AuthenticationServer) AuthorizeBatch(ctx context.Context, in *pb.AuthorizeBatchRequest) (*pb.AuthorizeBatchResponse, error) {
+	decisions, ttl, err := s.AuthorizationUsecase.Authorize(ctx, authorizationTransport(in.Transport), strings.TrimSpace(in.Token), sanitizeAuthorizationChecks(in.Checks))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.AuthorizeBatchResponse{TtlSeconds: int64(ttl.Seconds())}
+	for _, decision := range decisions {
+		response.Decisions = append(response.Decisions, authorizationDecision(decision))
+	}
+	return response, nil
+}
+
+func authorizationTransport(transport string) string {
+	return strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(transport))
+}
+
+func sanitizeAuthorizationChecks(in []*pb.AuthorizationCheck) []domain.AuthorizationCheck {
+	policy := bluemonday.UGCPolicy()
+	checks := make([]domain.AuthorizationCheck, 0, len(in))
+	for _, check := range in {
+		checks = append(checks, domain.AuthorizationCheck{
+			Object: strings.TrimSpace(policy.Sanitize(check.Object)),
+			Action: strings.TrimSpace(policy.Sanitize(check.Action)),
+		})
+	}
+	return checks
+}
+
+func authorizationDecision(decision domain.AuthorizationDecision) *pb.AuthorizationDecision {
+	return &pb.AuthorizationDecision{
+		Object:      decision.Object,
+		Action:      decision.Action,
+		Allowed:     decision.Allowed,
+		Subject:     decision.Subject,
+		MatchedRule: decision.MatchedRule,
+		Reason:      decision.Reason,
+	}
+}
@@ -0,0 +1,46 @@
+package implementation
+
+import (
+	"auth-service/domain"
+	helper2 "auth-service/grpc/helper"
+	pb "auth-service/grpc/server/authentication_server"
+	"auth-service/infrastructure/dto"
+	"auth-service/usecase"
+	"context"
+	"github.com/microcosm-cc/bluemonday"
+	"strings"
+)
+
+func (s *AuthenticationServer) RequestEmailChange(ctx context.Context, in *pb.EmailChangeRequest) (*pb.BooleanResponse, error) {
+	policy := bluemonday.UGCPolicy()
+	changeDto := dto.EmailChangeDto{
+		Email:    strings.TrimSpace(policy.Sanitize(in.Email)),
+		Password: strings.TrimSpace(in.Password),
+	}
+	if changeDto.Email == "" || changeDto.Password == "" {
+		return nil, domain.InvalidArgument("email and password are required")
+	}
+
+	userId := helper2.CallerId(ctx, s.AuthenticationUsecase)
+	if userId == "" {
+		return nil, usecase.ErrInvalidToken
+	}
+
+	return booleanResponse(s.EmailChangeUsecase.RequestChange(ctx, userId, changeDto))
+}
+
+func (s *AuthenticationServer) ConfirmEmailChange(ctx context.Context, in *pb.EmailChangeConfirmation) (*pb.BooleanResponse, error) {
+	code := strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(in.Code))
+
+	userId := helper2.CallerId(ctx, s.AuthenticationUsecase)
+	if userId == "" {
+		return nil, usecase.ErrInvalidToken
+	}
+
+	return booleanResponse(s.EmailChangeUsecase.ConfirmChange(ctx, userId, code))
+}
+
+// CancelEmailChange takes the token from the mail sent to the old address.
+func (s *AuthenticationServer) CancelEmailChange(ctx context.Context, in *pb.TokenRequest) (*pb.BooleanResponse, error) {
+	return booleanResponse(s.EmailChangeUsecase.CancelChange(ctx, strings.TrimSpace(in.Token)))
+}
@@ -0,0 +1,33 @@
+package implementation
+
+import (
+	helper2 "auth-service/grpc/helper"
+	pb "auth-service/grpc/server/authentication_server"
+	"context"
+	"github.com/microcosm-cc/bluemonday"
+	"strings"
+)
+
+// StartImpersonation issues a short-lived token that lets the admin act as the user.
+func (s *AuthenticationServer) StartImpersonation(ctx context.Context, in *pb.ImpersonationRequest) (*pb.Impersonation, error) {
+	policy := bluemonday.UGCPolicy()
+	userId := strings.TrimSpace(policy.Sanitize(in.UserId))
+	reason := strings.TrimSpace(policy.Sanitize(in.Reason))
+
+	impersonation, err := s.ImpersonationUsecase.Start(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), userId, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Impersonation{
+		TokenUuid: impersonation.TokenUuid,
+		Token:     impersonation.AccessToken,
+		UserId:    impersonation.UserId,
+		ExpiresAt: impersonation.ExpiresAt.Unix(),
+	}, nil
+}
+
+func (s *AuthenticationServer) EndImpersonation(ctx context.Context, in *pb.TokenRequest) (*pb.BooleanResponse, error) {
+	tokenUuid := strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(in.Token))
+	return booleanResponse(s.ImpersonationUsecase.End(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), tokenUuid))
+}
@@ -0,0 +1,75 @@
+package implementation
+
+import (
+	"auth-service/domain"
+	helper2 "auth-service/grpc/helper"
+	pb "auth-service/grpc/server/authentication_server"
+	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/mapper"
+	"context"
+	"github.com/microcosm-cc/bluemonday"
+	"strings"
+)
+
+// IssueClientToken is the client credentials grant.
+func (s *AuthenticationServer) IssueClientToken(ctx context.Context, in *pb.ClientCredentials) (*pb.ClientToken, error) {
+	policy := bluemonday.UGCPolicy()
+	grantType := strings.TrimSpace(policy.Sanitize(in.GrantType))
+	clientId := strings.TrimSpace(policy.Sanitize(in.ClientId))
+	scope := strings.TrimSpace(policy.Sanitize(in.Scope))
+
+	td, scopes, err := s.OAuthClientUsecase.IssueToken(ctx, grantType, clientId, in.ClientSecret, domain.SplitScopes(scope))
+	if err != nil {
+		return nil, err
+	}
+
+	token := mapper.MapTokenDetailsToOAuthTokenDto(*td, scopes)
+	return &pb.ClientToken{AccessToken: token.AccessToken, TokenType: token.TokenType, ExpiresIn: token.ExpiresIn, Scope: token.Scope}, nil
+}
+
+func (s *AuthenticationServer) ListOAuthClients(ctx context.Context, in *pb.Empty) (*pb.OAuthClientList, error) {
+	clients, err := s.OAuthClientUsecase.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &pb.OAuthClientList{}
+	for _, client := range clients {
+		list.Clients = append(list.Clients, oauthClient(mapper.MapOAuthClientToOAuthClientDto(client, "")))
+	}
+
+	return list, nil
+}
+
+// CreateOAuthClient responds with the client's secret, which can't be retrieved later.
+func (s *AuthenticationServer) CreateOAuthClient(ctx context.Context, in *pb.OAuthClientRequest) (*pb.OAuthClient, error) {
+	policy := bluemonday.UGCPolicy()
+	name := strings.TrimSpace(policy.Sanitize(in.Name))
+	scopes := make([]string, 0, len(in.Scopes))
+	for _, scope := range in.Scopes {
+		scopes = append(scopes, policy.Sanitize(scope))
+	}
+
+	client, secret, err := s.OAuthClientUsecase.Create(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), name, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	return oauthClient(mapper.MapOAuthClientToOAuthClientDto(*client, secret)), nil
+}
+
+func (s *AuthenticationServer) DeleteOAuthClient(ctx context.Context, in *pb.OAuthClientRequest) (*pb.BooleanResponse, error) {
+	clientId := strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(in.ClientId))
+	return booleanResponse(s.OAuthClientUsecase.Delete(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), clientId))
+}
+
+func oauthClient(client dto.OAuthClientDto) *pb.OAuthClient {
+	return &pb.OAuthClient{
+		ClientId:     client.ClientId,
+		ClientSecret: client.ClientSecret,
+		Name:         client.Name,
+		Scopes:       client.Scopes,
+		CreatedBy:    client.CreatedBy,
+		CreatedAt:    client.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,54 @@
+package implementation
+
+import (
+	"auth-service/domain"
+	helper2 "auth-service/grpc/helper"
+	pb "auth-service/grpc/server/authentication_server"
+	"context"
+	"github.com/microcosm-cc/bluemonday"
+	"strconv"
+	"strings"
+)
+
+func (s *AuthenticationServer) ListOutboxMessages(ctx context.Context, in *pb.OutboxListRequest) (*pb.OutboxMessageList, error) {
+	status := strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(in.Status))
+	if status == "" {
+		status = domain.OutboxDead
+	}
+	if status == "all" {
+		status = ""
+	}
+
+	messages, err := s.OutboxUsecase.GetByStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &pb.OutboxMessageList{}
+	for _, message := range messages {
+		outboxMessage := &pb.OutboxMessage{
+			Id:            uint64(message.ID),
+			CreatedAt:     message.CreatedAt.Unix(),
+			Category:      message.Category,
+			Recipient:     message.Recipient,
+			Subject:       message.Subject,
+			Status:        message.Status,
+			Attempts:      int32(message.Attempts),
+			NextAttemptAt: message.NextAttemptAt.Unix(),
+			LastError:     message.LastError,
+		}
+		if message.SentAt != nil {
+			outboxMessage.SentAt = message.SentAt.Unix()
+		}
+		list.Messages = append(list.Messages, outboxMessage)
+	}
+
+	return list, nil
+}
+
+func (s *AuthenticationServer) RedriveOutboxMessage(ctx context.Context, in *pb.OutboxMessageRequest) (*pb.BooleanResponse, error) {
+	err := s.OutboxUsecase.Redrive(ctx, uint(in.Id))
+	s.AuditUsecase.Record(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), "outbox/"+strconv.FormatUint(in.Id, 10), domain.AuditOutboxRedriven, err)
+
+	return booleanResponse(err)
+}
@@ -0,0 +1,136 @@
+package implementation
+
+import (
+	"auth-service/domain"
+	helper2 "auth-service/grpc/helper"
+	pb "auth-service/grpc/server/authentication_server"
+	"auth-service/infrastructure/mapper"
+	"context"
+	"github.com/microcosm-cc/bluemonday"
+	"strings"
+)
+
+func (s *AuthenticationServer) ListPolicies(ctx context.Context, in *pb.PolicyListRequest) (*pb.PolicyList, error) {
+	transport := policyTransport(in.Transport)
+	policies, inheritances, err := s.PolicyUsecase.List(ctx, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &pb.PolicyList{}
+	for _, policy := range policies {
+		list.Policies = append(list.Policies, &pb.PolicyRequest{Transport: transport, Subject: policy.Subject, Object: policy.Object, Action: policy.Action, Condition: policy.Condition})
+	}
+	for _, inheritance := range inheritances {
+		list.Inheritances = append(list.Inheritances, &pb.RoleInheritanceRequest{Transport: transport, Role: inheritance.Role, Parent: inheritance.Parent})
+	}
+
+	return list, nil
+}
+
+func (s *AuthenticationServer) AddPolicy(ctx context.Context, in *pb.PolicyRequest) (*pb.BooleanResponse, error) {
+	transport, policy := sanitizePolicyRequest(in)
+	return booleanResponse(s.PolicyUsecase.AddPolicy(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), transport, policy))
+}
+
+func (s *AuthenticationServer) RemovePolicy(ctx context.Context, in *pb.PolicyRequest) (*pb.BooleanResponse, error) {
+	transport, policy := sanitizePolicyRequest(in)
+	return booleanResponse(s.PolicyUsecase.RemovePolicy(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), transport, policy))
+}
+
+func (s *AuthenticationServer) AddRoleInheritance(ctx context.Context, in *pb.RoleInheritanceRequest) (*pb.BooleanResponse, error) {
+	transport, inheritance := sanitizeRoleInheritanceRequest(in)
+	return booleanResponse(s.PolicyUsecase.AddInheritance(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), transport, inheritance))
+}
+
+func (s *AuthenticationServer) RemoveRoleInheritance(ctx context.Context, in *pb.RoleInheritanceRequest) (*pb.BooleanResponse, error) {
+	transport, inheritance := sanitizeRoleInheritanceRequest(in)
+	return booleanResponse(s.PolicyUsecase.RemoveInheritance(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), transport, inheritance))
+}
+
+// ReloadPolicy reloads every policy from the database.
+func (s *AuthenticationServer) ReloadPolicy(ctx context.Context, in *pb.Empty) (*pb.BooleanResponse, error) {
+	return booleanResponse(s.PolicyUsecase.Reload(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase)))
+}
+
+// ExplainPolicy tells which routes the subject may call and by which rule.
+func (s *AuthenticationServer) ExplainPolicy(ctx context.Context, in *pb.PolicyRequest) (*pb.PolicyExplanationList, error) {
+	transport, policy := sanitizePolicyRequest(in)
+	explanations, err := s.PolicyUsecase.Explain(ctx, transport, policy.Subject, policy.Object, policy.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	listDto := mapper.MapPolicyExplanationsToPolicyExplanationListDto(transport, explanations)
+	list := &pb.PolicyExplanationList{Transport: transport}
+	for _, explanation := range listDto.Explanations {
+		list.Explanations = append(list.Explanations, &pb.PolicyExplanation{
+			Subject:  explanation.Subject,
+			Inherits: explanation.Roles,
+			Object:   explanation.Object,
+			Action:   explanation.Action,
+			Routed:   explanation.Routed,
+			Allowed:  explanation.Allowed,
+			Rule:     explanation.Rule,
+		})
+	}
+
+	return list, nil
+}
+
+// DiffPolicy lists the routes each subject would gain or lose with the given policy.
+func (s *AuthenticationServer) DiffPolicy(ctx context.Context, in *pb.PolicyDiffRequest) (*pb.PolicyDiff, error) {
+	transport := policyTransport(in.Transport)
+	changes, err := s.PolicyUsecase.Diff(ctx, transport, strings.NewReader(bluemonday.UGCPolicy().Sanitize(in.Policy)))
+	if err != nil {
+		return nil, err
+	}
+
+	diffDto := mapper.MapAccessChangesToPolicyDiffDto(transport, changes)
+	diff := &pb.PolicyDiff{Transport: transport}
+	for _, policy := range diffDto.Gained {
+		diff.Gained = append(diff.Gained, &pb.PolicyRequest{Transport: transport, Subject: policy.Subject, Object: policy.Object, Action: policy.Action})
+	}
+	for _, policy := range diffDto.Lost {
+		diff.Lost = append(diff.Lost, &pb.PolicyRequest{Transport: transport, Subject: policy.Subject, Object: policy.Object, Action: policy.Action})
+	}
+
+	return diff, nil
+}
+
+// policyTransport defaults to the gRPC policy.
+func policyTransport(transport string) string {
+	transport = strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(transport))
+	if transport == "" {
+		return domain.PolicyTransportGrpc
+	}
+
+	return transport
+}
+
+func sanitizePolicyRequest(in *pb.PolicyRequest) (string, domain.Policy) {
+	policy := bluemonday.UGCPolicy()
+	return policyTransport(in.Transport), domain.Policy{
+		Subject: strings.TrimSpace(policy.Sanitize(in.Subject)),
+		Object:  strings.TrimSpace(policy.Sanitize(in.Object)),
+		Action:  strings.TrimSpace(policy.Sanitize(in.Action)),
+		// Conditions are expressions, escaping them would change their meaning.
+		Condition: strings.TrimSpace(in.Condition),
+	}
+}
+
+func sanitizeRoleInheritanceRequest(in *pb.RoleInheritanceRequest) (string, domain.RoleInheritance) {
+	policy := bluemonday.UGCPolicy()
+	return policyTransport(in.Transport), domain.RoleInheritance{
+		Role:   strings.TrimSpace(policy.Sanitize(in.Role)),
+		Parent: strings.TrimSpace(policy.Sanitize(in.Parent)),
+	}
+}
+
+func booleanResponse(err error) (*pb.BooleanResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.BooleanResponse{Success: true}, nil
+}
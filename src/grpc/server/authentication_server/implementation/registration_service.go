@@ -0,0 +1,158 @@
+package implementation
+
+import (
+	"auth-service/domain"
+	helper2 "auth-service/grpc/helper"
+	pb "auth-service/grpc/server/authentication_server"
+	"auth-service/infrastructure/mapper"
+	"auth-service/infrastructure/saga"
+	validator2 "auth-service/infrastructure/validator"
+	"context"
+	"github.com/microcosm-cc/bluemonday"
+	"strings"
+)
+
+func (s *AuthenticationServer) Register(ctx context.Context, in *pb.RegistrationRequest) (*pb.BooleanResponse, error) {
+	user, err := s.validateRegistration(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return booleanResponse(s.RegistrationUsecase.Register(ctx, user))
+}
+
+func (s *AuthenticationServer) ConfirmAccount(ctx context.Context, in *pb.AccountConfirmation) (*pb.BooleanResponse, error) {
+	email, code, err := sanitizeAccountConfirmation(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return booleanResponse(s.RegistrationUsecase.ConfirmAccount(ctx, code, email))
+}
+
+func (s *AuthenticationServer) ResendRegistrationCode(ctx context.Context, in *pb.ResendEmailRequest) (*pb.BooleanResponse, error) {
+	email := strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(in.Email))
+	if err := s.RegistrationUsecase.ResendCode(ctx, email); err != nil {
+		return nil, domain.InvalidArgument("invalid email", domain.FieldViolation{Field: "email", Description: "no registration is waiting for this email"})
+	}
+
+	return &pb.BooleanResponse{Success: true}, nil
+}
+
+func (s *AuthenticationServer) RegisterAgent(ctx context.Context, in *pb.RegistrationRequest) (*pb.BooleanResponse, error) {
+	user, err := s.validateRegistration(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return booleanResponse(s.RegistrationUsecase.RegisterAgent(ctx, user))
+}
+
+func (s *AuthenticationServer) ValidateAgentAccount(ctx context.Context, in *pb.AccountConfirmation) (*pb.BooleanResponse, error) {
+	email, code, err := sanitizeAccountConfirmation(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return booleanResponse(s.RegistrationUsecase.ValidateAgentAccount(ctx, code, email))
+}
+
+func (s *AuthenticationServer) ListAgentRequests(ctx context.Context, in *pb.Empty) (*pb.AgentList, error) {
+	users, err := s.RegistrationUsecase.GetAgentRequests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &pb.AgentList{}
+	for _, agent := range mapper.MapUserToAgentInformationDto(users) {
+		list.Agents = append(list.Agents, &pb.Agent{Name: agent.Name, Surname: agent.Surname, Username: agent.Username, Email: agent.Email, Web: agent.Web})
+	}
+
+	return list, nil
+}
+
+// ConfirmAgentAccount approves or declines an agent.
+func (s *AuthenticationServer) ConfirmAgentAccount(ctx context.Context, in *pb.AgentConfirmation) (*pb.BooleanResponse, error) {
+	email := strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(in.Email))
+
+	user, err := s.RegistrationUsecase.ConfirmAgentAccount(ctx, email, in.Confirm)
+	action := domain.AuditAgentApproved
+	if !in.Confirm {
+		action = domain.AuditAgentDeclined
+	}
+	s.AuditUsecase.Record(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), email, action, err)
+	if err != nil || !in.Confirm {
+		return booleanResponse(err)
+	}
+
+	m := saga.Message{
+		Action:        saga.ActionStart,
+		Service:       saga.UserService,
+		SenderService: saga.AuthService,
+		Payload:       *user,
+		Confirm:       in.Confirm,
+	}
+	s.Orchestrator.Next(ctx, saga.UserChannel, saga.UserService, m)
+
+	return &pb.BooleanResponse{Success: true}, nil
+}
+
+// validateRegistration applies the checks of the HTTP registration routes.
+func (s *AuthenticationServer) validateRegistration(ctx context.Context, in *pb.RegistrationRequest) (domain.User, error) {
+	policy := bluemonday.UGCPolicy()
+	user := domain.User{
+		Name:     strings.TrimSpace(policy.Sanitize(in.Name)),
+		Surname:  strings.TrimSpace(policy.Sanitize(in.Surname)),
+		Username: strings.TrimSpace(policy.Sanitize(in.Username)),
+		Password: strings.TrimSpace(in.Password),
+		Email:    strings.TrimSpace(policy.Sanitize(in.Email)),
+		Address:  strings.TrimSpace(policy.Sanitize(in.Address)),
+		Phone:    strings.TrimSpace(policy.Sanitize(in.Phone)),
+		Birthday: strings.TrimSpace(policy.Sanitize(in.Birthday)),
+		Gender:   strings.TrimSpace(policy.Sanitize(in.Gender)),
+		Web:      strings.TrimSpace(policy.Sanitize(in.Web)),
+		Bio:      strings.TrimSpace(policy.Sanitize(in.Bio)),
+		Image:    strings.TrimSpace(policy.Sanitize(in.Image)),
+		Locale:   strings.TrimSpace(policy.Sanitize(in.Locale)),
+	}
+
+	var violations []domain.FieldViolation
+	required := []struct{ field, value string }{{"name", user.Name}, {"surname", user.Surname}, {"username", user.Username}, {"password", user.Password},
+		{"email", user.Email}, {"address", user.Address}, {"phone", user.Phone}, {"birthday", user.Birthday}, {"gender", user.Gender}, {"web", user.Web}, {"bio", user.Bio}}
+	for _, it := range required {
+		if it.value == "" {
+			violations = append(violations, domain.FieldViolation{Field: it.field, Description: it.field + " is required"})
+		}
+	}
+	if len(violations) > 0 {
+		return user, domain.InvalidArgument("fields are empty or xss attack happened", violations...)
+	}
+
+	customValidator := validator2.NewCustomValidator()
+	translator, _ := customValidator.RegisterEnTranslation()
+	if errValidation := customValidator.Validator.Struct(user); errValidation != nil {
+		errorsString := customValidator.GetErrorsString(customValidator.TranslateError(errValidation, translator))
+		return user, domain.InvalidArgument(errorsString[0])
+	}
+
+	if strings.Contains(user.Username, " ") {
+		return user, domain.InvalidArgument("username is not in valid format", domain.FieldViolation{Field: "username", Description: "username can't contain spaces"})
+	}
+
+	if s.RegistrationUsecase.IsAlreadyRegistered(ctx, user.Username, user.Email) {
+		return user, domain.InvalidArgument("user already exists", domain.FieldViolation{Field: "username", Description: "username or email is taken"})
+	}
+
+	return user, nil
+}
+
+func sanitizeAccountConfirmation(in *pb.AccountConfirmation) (string, string, error) {
+	policy := bluemonday.UGCPolicy()
+	email := strings.TrimSpace(policy.Sanitize(in.Email))
+	code := strings.TrimSpace(policy.Sanitize(in.Code))
+	if email == "" || code == "" {
+		return "", "", domain.InvalidArgument("email and code are required")
+	}
+
+	return email, code, nil
+}
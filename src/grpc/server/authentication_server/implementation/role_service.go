@@ -0,0 +1,35 @@
+package implementation
+
+import (
+	"auth-service/domain"
+	helper2 "auth-service/grpc/helper"
+	pb "auth-service/grpc/server/authentication_server"
+	"context"
+	"github.com/microcosm-cc/bluemonday"
+	"strings"
+)
+
+func (s *AuthenticationServer) GrantRole(ctx context.Context, in *pb.RoleRequest) (*pb.UserRoles, error) {
+	userId, role := sanitizeRoleRequest(in)
+	account, err := s.RoleUsecase.Grant(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), userId, role)
+	return userRoles(account, err)
+}
+
+func (s *AuthenticationServer) RevokeRole(ctx context.Context, in *pb.RoleRequest) (*pb.UserRoles, error) {
+	userId, role := sanitizeRoleRequest(in)
+	account, err := s.RoleUsecase.Revoke(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), userId, role)
+	return userRoles(account, err)
+}
+
+func sanitizeRoleRequest(in *pb.RoleRequest) (string, string) {
+	policy := bluemonday.UGCPolicy()
+	return strings.TrimSpace(policy.Sanitize(in.UserId)), strings.TrimSpace(policy.Sanitize(in.Role))
+}
+
+func userRoles(account *domain.ProfileInfo, err error) (*pb.UserRoles, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UserRoles{UserId: account.ID, Roles: account.RoleNames()}, nil
+}
@@ -0,0 +1,58 @@
+package implementation
+
+import (
+	helper2 "auth-service/grpc/helper"
+	pb "auth-service/grpc/server/authentication_server"
+	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/mapper"
+	"context"
+	"github.com/microcosm-cc/bluemonday"
+	"strings"
+)
+
+func (s *AuthenticationServer) ListScopes(ctx context.Context, in *pb.Empty) (*pb.ScopeList, error) {
+	scopes, roles, err := s.ScopeUsecase.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listDto := mapper.MapScopesToScopeListDto(scopes, roles)
+	list := &pb.ScopeList{}
+	for _, scope := range listDto.Scopes {
+		list.Scopes = append(list.Scopes, &pb.Scope{Name: scope.Name, Description: scope.Description})
+	}
+	for _, role := range listDto.Roles {
+		list.Roles = append(list.Roles, roleScopes(role))
+	}
+
+	return list, nil
+}
+
+func (s *AuthenticationServer) AddRoleScope(ctx context.Context, in *pb.RoleScopeRequest) (*pb.RoleScopes, error) {
+	roleName, scopeName := sanitizeRoleScopeRequest(in)
+	role, err := s.ScopeUsecase.AddToRole(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), roleName, scopeName)
+	if err != nil {
+		return nil, err
+	}
+
+	return roleScopes(mapper.MapRoleToRoleScopesDto(*role)), nil
+}
+
+func (s *AuthenticationServer) RemoveRoleScope(ctx context.Context, in *pb.RoleScopeRequest) (*pb.RoleScopes, error) {
+	roleName, scopeName := sanitizeRoleScopeRequest(in)
+	role, err := s.ScopeUsecase.RemoveFromRole(ctx, helper2.CallerId(ctx, s.AuthenticationUsecase), roleName, scopeName)
+	if err != nil {
+		return nil, err
+	}
+
+	return roleScopes(mapper.MapRoleToRoleScopesDto(*role)), nil
+}
+
+func sanitizeRoleScopeRequest(in *pb.RoleScopeRequest) (string, string) {
+	policy := bluemonday.UGCPolicy()
+	return strings.TrimSpace(policy.Sanitize(in.Role)), strings.TrimSpace(policy.Sanitize(in.Scope))
+}
+
+func roleScopes(role dto.RoleScopesDto) *pb.RoleScopes {
+	return &pb.RoleScopes{Role: role.Role, Scopes: role.Scopes}
+}
@@ -0,0 +1,53 @@
+package helper
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// BloomFilter keeps set membership for pre-hashed values of at least 16 bytes.
+type BloomFilter struct {
+	bits []uint64
+	m    uint64
+	k    uint64
+}
+
+func NewBloomFilter(expectedItems uint64, falsePositiveRate float64) *BloomFilter {
+	if expectedItems == 0 {
+		expectedItems = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.001
+	}
+
+	m := uint64(math.Ceil(-float64(expectedItems) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	k := uint64(math.Round(float64(m) / float64(expectedItems) * math.Ln2))
+	if k == 0 {
+		k = 1
+	}
+
+	return &BloomFilter{bits: make([]uint64, (m+63)/64), m: m, k: k}
+}
+
+func (b *BloomFilter) AddDigest(digest []byte) {
+	h1, h2 := splitDigest(digest)
+	for i := uint64(0); i < b.k; i++ {
+		idx := (h1 + i*h2) % b.m
+		b.bits[idx/64] |= 1 << (idx % 64)
+	}
+}
+
+func (b *BloomFilter) ContainsDigest(digest []byte) bool {
+	h1, h2 := splitDigest(digest)
+	for i := uint64(0); i < b.k; i++ {
+		idx := (h1 + i*h2) % b.m
+		if b.bits[idx/64]&(1<<(idx%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func splitDigest(digest []byte) (uint64, uint64) {
+	return binary.BigEndian.Uint64(digest[0:8]), binary.BigEndian.Uint64(digest[8:16]) | 1
+}
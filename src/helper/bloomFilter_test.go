@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"testing"
+)
+
+func digest(i uint64) []byte {
+	var value [8]byte
+	binary.BigEndian.PutUint64(value[:], i)
+	sum := sha1.Sum(value[:])
+	return sum[:]
+}
+
+func TestNewBloomFilter(t *testing.T) {
+	tests := []struct {
+		name          string
+		expectedItems uint64
+		fpRate        float64
+		wantM         uint64
+		wantK         uint64
+	}{
+		{name: "sized for the items and rate", expectedItems: 1000, fpRate: 0.01, wantM: 9586, wantK: 7},
+		{name: "lower rate needs more bits and hashes", expectedItems: 1000, fpRate: 0.001, wantM: 14378, wantK: 10},
+		{name: "no items is sized for one", expectedItems: 0, fpRate: 0.01, wantM: 10, wantK: 7},
+		{name: "rate out of range falls back", expectedItems: 1000, fpRate: 1.5, wantM: 14378, wantK: 10},
+		{name: "zero rate falls back", expectedItems: 1000, fpRate: 0, wantM: 14378, wantK: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := NewBloomFilter(tt.expectedItems, tt.fpRate)
+			if filter.m != tt.wantM || filter.k != tt.wantK {
+				t.Errorf("m, k = %v, %v, want %v, %v", filter.m, filter.k, tt.wantM, tt.wantK)
+			}
+			if uint64(len(filter.bits))*64 < filter.m {
+				t.Errorf("%v words cannot hold %v bits", len(filter.bits), filter.m)
+			}
+		})
+	}
+}
+
+func TestBloomFilterMembership(t *testing.T) {
+	const items, fpRate = 20000, 0.01
+	filter := NewBloomFilter(items, fpRate)
+	for i := uint64(0); i < items; i++ {
+		filter.AddDigest(digest(i))
+	}
+
+	for i := uint64(0); i < items; i++ {
+		if !filter.ContainsDigest(digest(i)) {
+			t.Fatalf("added digest %v is missing", i)
+		}
+	}
+
+	probes := uint64(100000)
+	var falsePositives uint64
+	for i := uint64(items); i < items+probes; i++ {
+		if filter.ContainsDigest(digest(i)) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / float64(probes); rate > 2*fpRate {
+		t.Errorf("false positive rate %v, want at most %v", rate, 2*fpRate)
+	}
+}
+
+func TestEmptyBloomFilterContainsNothing(t *testing.T) {
+	filter := NewBloomFilter(100, 0.01)
+	for i := uint64(0); i < 1000; i++ {
+		if filter.ContainsDigest(digest(i)) {
+			t.Fatalf("empty filter contains digest %v", i)
+		}
+	}
+}
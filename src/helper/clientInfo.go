@@ -0,0 +1,147 @@
+package helper
+
+import (
+	"context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
+	"strings"
+)
+
+// Gin context keys of the request's client details.
+const (
+	ClientIpKey  = "client_ip"
+	UserAgentKey = "user_agent"
+	ClientIdKey  = "client_id"
+	RequestIdKey = "request_id"
+)
+
+// gatewayUserAgent carries the REST client's user agent, gRPC replaces user-agent with its own.
+const gatewayUserAgent = "grpcgateway-user-agent"
+
+var trustedProxies []*net.IPNet
+
+// SetTrustedProxies sets the proxies and CIDR ranges whose X-Forwarded-For is trusted.
+func SetTrustedProxies(proxies []string) error {
+	var cidrs []*net.IPNet
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			if strings.Contains(proxy, ":") {
+				proxy += "/128"
+			} else {
+				proxy += "/32"
+			}
+		}
+
+		_, cidr, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return err
+		}
+		cidrs = append(cidrs, cidr)
+	}
+
+	trustedProxies = cidrs
+	return nil
+}
+
+func isTrustedProxy(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	for _, cidr := range trustedProxies {
+		if cidr.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientInfoFromContext returns the client address and user agent of the current request.
+func ClientInfoFromContext(ctx context.Context) (ip, userAgent string) {
+	ip, _ = ctx.Value(ClientIpKey).(string)
+	userAgent, _ = ctx.Value(UserAgentKey).(string)
+	if ip != "" {
+		return ip, userAgent
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("user-agent"); len(values) > 0 && userAgent == "" {
+		userAgent = values[0]
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	if values := md.Get(gatewayUserAgent); len(values) > 0 && isTrustedProxy(ip) {
+		userAgent = values[0]
+	}
+
+	return forwardedFor(ip, md.Get("x-forwarded-for")), userAgent
+}
+
+// forwardedFor returns the nearest x-forwarded-for address that is not a trusted proxy.
+func forwardedFor(remote string, headers []string) string {
+	if !isTrustedProxy(remote) {
+		return remote
+	}
+
+	var hops []string
+	for _, header := range headers {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+
+	ip := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		ip = hop
+		if !isTrustedProxy(hop) {
+			break
+		}
+	}
+
+	return ip
+}
+
+// ClientIp returns the address of the client behind the trusted proxies.
+func ClientIp(remoteAddr string, forwarded []string) string {
+	ip := strings.TrimSpace(remoteAddr)
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+
+	return forwardedFor(ip, forwarded)
+}
+
+// ClientIdFromContext returns the client application id sent with the request.
+func ClientIdFromContext(ctx context.Context) string {
+	if clientId, ok := ctx.Value(ClientIdKey).(string); ok {
+		return clientId
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-client-id"); len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
+
+func RequestIdFromContext(ctx context.Context) string {
+	if requestId, ok := ctx.Value(RequestIdKey).(string); ok {
+		return requestId
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-request-id"); len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
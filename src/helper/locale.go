@@ -0,0 +1,32 @@
+package helper
+
+import (
+	"context"
+	"google.golang.org/grpc/metadata"
+)
+
+// LocaleKey is the gin context key of the request's Accept-Language header.
+const LocaleKey = "locale"
+
+type localeContextKey struct{}
+
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext returns the locale preference of the current request.
+func LocaleFromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeContextKey{}).(string); ok {
+		return locale
+	}
+	if locale, ok := ctx.Value(LocaleKey).(string); ok {
+		return locale
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("accept-language"); len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
@@ -1,6 +1,10 @@
 package helper
 
-import "math/rand"
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"math/rand"
+)
 
 const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 const (
@@ -25,3 +29,12 @@ func RandomStringGenerator(n int) string {
 
 	return string(b)
 }
+
+// RandomToken returns n hex encoded bytes from a cryptographically secure source.
+func RandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := crand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,42 @@
+package helper
+
+import (
+	"auth-service/domain"
+	"strings"
+)
+
+// RolesFromClaims returns the upper-cased roles of decoded token claims.
+func RolesFromClaims(claims map[string]interface{}) []string {
+	var roles []string
+	if values, ok := claims["roles"].([]interface{}); ok {
+		for _, value := range values {
+			if role, ok := value.(string); ok && role != "" {
+				roles = append(roles, strings.ToUpper(role))
+			}
+		}
+	}
+
+	if len(roles) == 0 {
+		if role, ok := claims["role"].(string); ok && role != "" {
+			roles = append(roles, strings.ToUpper(role))
+		}
+	}
+
+	return roles
+}
+
+// ScopesFromClaims returns the scopes of decoded token claims.
+func ScopesFromClaims(claims map[string]interface{}) []string {
+	scope, _ := claims[domain.ScopeClaim].(string)
+	return domain.SplitScopes(scope)
+}
+
+// SubjectsFromClaims returns a token's roles followed by its scopes.
+func SubjectsFromClaims(claims map[string]interface{}) []string {
+	subjects := RolesFromClaims(claims)
+	for _, scope := range ScopesFromClaims(claims) {
+		subjects = append(subjects, domain.ScopeSubject(scope))
+	}
+
+	return subjects
+}
@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"auth-service/domain"
+	"auth-service/helper"
 	"auth-service/http/middleware"
 	"auth-service/infrastructure/dto"
 	"auth-service/infrastructure/tracer"
 	"auth-service/usecase"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	logger "github.com/jelena-vlajkov/logger/logger"
@@ -29,6 +32,9 @@ const (
 type totpHandler struct {
 	TotpUsecase usecase.TotpUsecase
 	ProfileInfoUsecase usecase.ProfileInfoUsecase
+	SecurityEventUsecase usecase.SecurityEventUsecase
+	BruteForceUsecase usecase.BruteForceUsecase
+	AuditUsecase usecase.AuditUsecase
 	Tracer opentracing.Tracer
 	logger *logger.Logger
 }
@@ -41,8 +47,8 @@ type TotpHandler interface {
 	 Disable(ctx *gin.Context)
 }
 
-func NewTotpHandler(totpUsecase usecase.TotpUsecase, tracer opentracing.Tracer, profileInfoUsecase usecase.ProfileInfoUsecase, logger *logger.Logger) TotpHandler {
-	return &totpHandler{totpUsecase,  profileInfoUsecase ,tracer, logger}
+func NewTotpHandler(totpUsecase usecase.TotpUsecase, tracer opentracing.Tracer, profileInfoUsecase usecase.ProfileInfoUsecase, securityEventUsecase usecase.SecurityEventUsecase, bruteForceUsecase usecase.BruteForceUsecase, auditUsecase usecase.AuditUsecase, logger *logger.Logger) TotpHandler {
+	return &totpHandler{totpUsecase,  profileInfoUsecase, securityEventUsecase, bruteForceUsecase, auditUsecase, tracer, logger}
 }
 
 func (t *totpHandler) GenerateSecret(ctx *gin.Context) {
@@ -149,20 +155,32 @@ func (t *totpHandler) Verify(ctx *gin.Context) {
 
 	ctx1 := tracer.ContextWithSpan(ctx, span)
 
+	if err := t.BruteForceUsecase.Check(ctx1, usecase.BruteForceTotp, totpSecretDto.UserId); tooManyAttempts(ctx, err) {
+		t.logger.Logger.Warnf("throttled totp verification for user %v from IP address %v\n", totpSecretDto.UserId, ctx.GetString(helper.ClientIpKey))
+		return
+	}
+
 	if !t.TotpUsecase.Verify(ctx1, totpSecretDto.Passcode, totpSecretDto.UserId) {
+		t.BruteForceUsecase.Fail(ctx1, usecase.BruteForceTotp, totpSecretDto.UserId)
+		t.AuditUsecase.Record(ctx1, auditActor(ctx), totpSecretDto.UserId, domain.AuditTotpEnabled, errors.New(totp_validation_error))
 		t.logger.Logger.Errorf("error while verifying totp for user %v", totpSecretDto.UserId)
 		tracer.LogError(span, fmt.Errorf("message=%s",totp_validation_error))
 		ctx.JSON(400, gin.H{"message" : totp_validation_error})
 		return
 	}
 
-	if err := t.TotpUsecase.SaveSecret(ctx1, totpSecretDto.UserId); err != nil {
+	t.BruteForceUsecase.Succeed(ctx1, usecase.BruteForceTotp, totpSecretDto.UserId)
+
+	err := t.TotpUsecase.SaveSecret(ctx1, totpSecretDto.UserId)
+	t.AuditUsecase.Record(ctx1, auditActor(ctx), totpSecretDto.UserId, domain.AuditTotpEnabled, err)
+	if err != nil {
 		t.logger.Logger.Errorf("error while saving totp secret for user %v, error: %v\n", totpSecretDto.UserId, err)
 		tracer.LogError(span, fmt.Errorf("message=%s",totp_validation_error))
 		ctx.JSON(400, gin.H{"message" : totp_validation_error})
 		return
 	}
 
+	t.SecurityEventUsecase.Publish(ctx1, domain.SecurityEvent{Type: domain.SecurityEventTotpEnabled, ProfileInfoId: totpSecretDto.UserId})
 	ctx.JSON(200, gin.H{"message" : "Two factor authentication enabled"})
 }
 
@@ -228,20 +246,32 @@ func (t *totpHandler) Disable(ctx *gin.Context) {
 
 	ctx1 := tracer.ContextWithSpan(ctx, span)
 
+	if err := t.BruteForceUsecase.Check(ctx1, usecase.BruteForceTotp, totpSecretDto.UserId); tooManyAttempts(ctx, err) {
+		t.logger.Logger.Warnf("throttled totp validation for user %v from IP address %v\n", totpSecretDto.UserId, ctx.GetString(helper.ClientIpKey))
+		return
+	}
+
 	if !t.TotpUsecase.Validate(ctx1, totpSecretDto.UserId, totpSecretDto.Passcode) {
+		t.BruteForceUsecase.Fail(ctx1, usecase.BruteForceTotp, totpSecretDto.UserId)
+		t.AuditUsecase.Record(ctx1, auditActor(ctx), totpSecretDto.UserId, domain.AuditTotpDisabled, errors.New(totp_validation_error))
 		t.logger.Logger.Errorf("error while validating secret for user %v, invalid passcode\n", totpSecretDto.UserId)
 		tracer.LogError(span, fmt.Errorf("message=%s",totp_validation_error))
 		ctx.JSON(400, gin.H{"message" : totp_validation_error})
 		return
 	}
 
-	if err := t.TotpUsecase.DeleteSecretByProfileId(ctx1, totpSecretDto.UserId); err != nil {
+	t.BruteForceUsecase.Succeed(ctx1, usecase.BruteForceTotp, totpSecretDto.UserId)
+
+	err := t.TotpUsecase.DeleteSecretByProfileId(ctx1, totpSecretDto.UserId)
+	t.AuditUsecase.Record(ctx1, auditActor(ctx), totpSecretDto.UserId, domain.AuditTotpDisabled, err)
+	if err != nil {
 		t.logger.Logger.Errorf("error while deleting secret for user %v, error: %v\n", totpSecretDto.UserId, err)
 		tracer.LogError(span, fmt.Errorf("message=%s; err=%s\n", totp_disable_error, err))
 		ctx.JSON(400, gin.H{"message" : totp_disable_error})
 		return
 	}
 
+	t.SecurityEventUsecase.Publish(ctx1, domain.SecurityEvent{Type: domain.SecurityEventTotpDisabled, ProfileInfoId: totpSecretDto.UserId})
 
 	ctx.JSON(200, gin.H{"message" : totp_disable})
 
@@ -251,7 +281,7 @@ func (t *totpHandler) Disable(ctx *gin.Context) {
 func (t *totpHandler) logMetadata(span opentracing.Span, ctx *gin.Context) {
 	span.LogFields(
 		tracer.LogString("handler: ", fmt.Sprintf("handling login at %s\n", ctx.Request.URL.Path)),
-		tracer.LogString("handler: ", fmt.Sprintf("client ip= %s\n", ctx.ClientIP())),
+		tracer.LogString("handler: ", fmt.Sprintf("client ip= %s\n", ctx.GetString(helper.ClientIpKey))),
 		tracer.LogString("handler", fmt.Sprintf("method= %s\n", ctx.Request.Method)),
 		tracer.LogString("handler", fmt.Sprintf("header= %s\n", ctx.Request.Header)),
 	)
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"auth-service/domain"
+	"auth-service/http/middleware"
+	"auth-service/usecase"
+	"errors"
+	"github.com/gin-gonic/gin"
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+// accessSubject is the caller of the request as the resource policy sees it.
+func accessSubject(ctx *gin.Context, accessUsecase usecase.AccessUsecase, logger *logger.Logger) domain.AccessSubject {
+	userId, _ := middleware.ExtractUserId(ctx, ctx.Request)
+	subjects, _ := middleware.ExtractSubjects(ctx, ctx.Request, logger)
+	return accessUsecase.Subject(ctx, userId, subjects)
+}
+
+func accessDenied(ctx *gin.Context, err error) bool {
+	var deniedErr *usecase.AccessDeniedError
+	if !errors.As(err, &deniedErr) {
+		return false
+	}
+
+	ctx.JSON(403, gin.H{"message": deniedErr.Error()})
+	return true
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"auth-service/helper"
+	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/tracer"
+	"auth-service/usecase"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/opentracing/opentracing-go"
+	"strings"
+)
+
+type accountLockHandler struct {
+	SecurityNotificationUsecase usecase.SecurityNotificationUsecase
+	Tracer                      opentracing.Tracer
+	logger                      *logger.Logger
+}
+
+type AccountLockHandler interface {
+	LockAccount(ctx *gin.Context)
+}
+
+func NewAccountLockHandler(securityNotificationUsecase usecase.SecurityNotificationUsecase, tracer opentracing.Tracer, logger *logger.Logger) AccountLockHandler {
+	return &accountLockHandler{SecurityNotificationUsecase: securityNotificationUsecase, Tracer: tracer, logger: logger}
+}
+
+// LockAccount handles the "this wasn't me" link from security alert mails.
+func (a *accountLockHandler) LockAccount(ctx *gin.Context) {
+	a.logger.Logger.Println("Handling LOCK ACCOUNT")
+	span := tracer.StartSpanFromRequest("LockAccount", a.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var lockDto dto.AccountLockDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&lockDto); err != nil {
+		a.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	ctx1 := tracer.ContextWithSpan(ctx, span)
+	if err := a.SecurityNotificationUsecase.LockAccount(ctx1, strings.TrimSpace(lockDto.Token)); err != nil {
+		a.logger.Logger.Errorf("error while locking account from IP address %v, error: %v\n", ctx.GetString(helper.ClientIpKey), err)
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Your account is locked, reset your password to unlock it"})
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/mapper"
+	"auth-service/infrastructure/tracer"
+	"auth-service/usecase"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/opentracing/opentracing-go"
+	"strings"
+)
+
+type accountStatusHandler struct {
+	AccountStatusUsecase usecase.AccountStatusUsecase
+	Tracer               opentracing.Tracer
+	logger               *logger.Logger
+}
+
+type AccountStatusHandler interface {
+	SuspendAccount(ctx *gin.Context)
+	BanAccount(ctx *gin.Context)
+	UnlockAccount(ctx *gin.Context)
+}
+
+func NewAccountStatusHandler(accountStatusUsecase usecase.AccountStatusUsecase, tracer opentracing.Tracer, logger *logger.Logger) AccountStatusHandler {
+	return &accountStatusHandler{AccountStatusUsecase: accountStatusUsecase, Tracer: tracer, logger: logger}
+}
+
+func (a *accountStatusHandler) SuspendAccount(ctx *gin.Context) {
+	a.logger.Logger.Println("Handling SUSPEND ACCOUNT")
+	a.changeStatus(ctx, "SuspendAccount", func(ctx1 *gin.Context, request dto.AccountStatusRequestDto) (*domain.ProfileInfo, error) {
+		return a.AccountStatusUsecase.Suspend(ctx1, auditActor(ctx1), request.UserId, request.Until, request.Reason)
+	})
+}
+
+func (a *accountStatusHandler) BanAccount(ctx *gin.Context) {
+	a.logger.Logger.Println("Handling BAN ACCOUNT")
+	a.changeStatus(ctx, "BanAccount", func(ctx1 *gin.Context, request dto.AccountStatusRequestDto) (*domain.ProfileInfo, error) {
+		return a.AccountStatusUsecase.Ban(ctx1, auditActor(ctx1), request.UserId, request.Reason)
+	})
+}
+
+func (a *accountStatusHandler) UnlockAccount(ctx *gin.Context) {
+	a.logger.Logger.Println("Handling UNLOCK ACCOUNT")
+	a.changeStatus(ctx, "UnlockAccount", func(ctx1 *gin.Context, request dto.AccountStatusRequestDto) (*domain.ProfileInfo, error) {
+		return a.AccountStatusUsecase.Unlock(ctx1, auditActor(ctx1), request.UserId, request.Reason)
+	})
+}
+
+func (a *accountStatusHandler) changeStatus(ctx *gin.Context, operation string, change func(ctx *gin.Context, request dto.AccountStatusRequestDto) (*domain.ProfileInfo, error)) {
+	span := tracer.StartSpanFromRequest(operation, a.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var request dto.AccountStatusRequestDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&request); err != nil {
+		a.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	policy := bluemonday.UGCPolicy()
+	request.UserId = strings.TrimSpace(policy.Sanitize(request.UserId))
+	request.Reason = strings.TrimSpace(policy.Sanitize(request.Reason))
+
+	account, err := change(ctx, request)
+	if err != nil {
+		a.logger.Logger.Errorf("error while changing status of account %v, error: %v\n", request.UserId, err)
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, mapper.MapProfileInfoToAccountStatusDto(*account))
+}
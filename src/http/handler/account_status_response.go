@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"auth-service/usecase"
+	"errors"
+	"github.com/gin-gonic/gin"
+)
+
+func accountUnavailable(ctx *gin.Context, err error) bool {
+	var statusErr *usecase.AccountStatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+
+	response := gin.H{"message": statusErr.Error(), "status": statusErr.Status}
+	if statusErr.Until != nil {
+		response["suspended_until"] = statusErr.Until
+	}
+	ctx.JSON(403, response)
+	return true
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"auth-service/domain"
+	"auth-service/http/middleware"
+	"auth-service/infrastructure/tracer"
+	"auth-service/usecase"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/opentracing/opentracing-go"
+	"strconv"
+)
+
+type auditHandler struct {
+	AuditUsecase usecase.AuditUsecase
+	Tracer       opentracing.Tracer
+	logger       *logger.Logger
+}
+
+type AuditHandler interface {
+	ExportAuditLog(ctx *gin.Context)
+}
+
+func NewAuditHandler(auditUsecase usecase.AuditUsecase, tracer opentracing.Tracer, logger *logger.Logger) AuditHandler {
+	return &auditHandler{AuditUsecase: auditUsecase, Tracer: tracer, logger: logger}
+}
+
+// ExportAuditLog streams the audit log as JSON lines in chain order.
+func (a *auditHandler) ExportAuditLog(ctx *gin.Context) {
+	a.logger.Logger.Println("Handling EXPORT AUDIT LOG")
+	span := tracer.StartSpanFromRequest("ExportAuditLog", a.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var afterId uint64
+	if value := ctx.Query("after_id"); value != "" {
+		var err error
+		if afterId, err = strconv.ParseUint(value, 10, 64); err != nil {
+			ctx.JSON(400, gin.H{"message": "Invalid entry id"})
+			return
+		}
+	}
+
+	ctx1 := tracer.ContextWithSpan(ctx, span)
+	a.AuditUsecase.Record(ctx1, auditActor(ctx), "audit_log", domain.AuditLogExported, nil)
+
+	ctx.Header("Content-Type", "application/x-ndjson")
+	ctx.Status(200)
+	encoder := json.NewEncoder(ctx.Writer)
+	err := a.AuditUsecase.Export(ctx1, uint(afterId), func(entry domain.AuditEntry) error {
+		return encoder.Encode(entry)
+	})
+	if err != nil {
+		// The status is already sent, a truncated export fails verification.
+		a.logger.Logger.Errorf("error while exporting audit log, error: %v\n", err)
+		tracer.LogError(span, err)
+	}
+}
+
+// auditActor is the id of the signed in user making the request.
+func auditActor(ctx *gin.Context) string {
+	userId, _ := middleware.ExtractUserId(ctx, ctx.Request)
+	if actorId, _ := middleware.ExtractActorId(ctx, ctx.Request); actorId != "" {
+		return actorId + " as " + userId
+	}
+	return userId
+}
@@ -2,20 +2,20 @@ package handler
 
 import (
 	"auth-service/domain"
+	pb "auth-service/grpc/server/authentication_server"
 	"auth-service/helper"
 	"auth-service/http/middleware"
 	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/mapper"
 	"auth-service/infrastructure/tracer"
 	"auth-service/usecase"
 	"bytes"
-	"context"
 	"encoding/gob"
 	"encoding/json"
 	"fmt"
 	logger "github.com/jelena-vlajkov/logger/logger"
+	"net/http"
 	"strings"
-	"time"
-	"unicode"
 
 	"github.com/gin-gonic/gin"
 	"github.com/microcosm-cc/bluemonday"
@@ -24,23 +24,24 @@ import (
 
 const (
 	body_decoding_err       = "Body decoding error"
-	invalid_credentials_err = "Wrong username or password"
-	token_err               = "Can not create token"
 	totp_invalid_user_id    = "User id is not valid"
 	server_err 				= "Server error"
 
 )
 const (
-	redisKeyPattern = "passwordResetRequest"
+	magicLinkCookie = "magic_link_nonce"
 )
 
+// authenticateHandler serves the legacy HTTP routes, leaving logins to AuthenticationServer.
 type authenticateHandler struct {
 	AuthenticationUsecase usecase.AuthenticationUsecase
-	JwtUsecase            usecase.JwtUsecase
 	ProfileInfoUsecase    usecase.ProfileInfoUsecase
-	TotpUsecase           usecase.TotpUsecase
 	Tracer                opentracing.Tracer
-	RedisUsecase          usecase.RedisUsecase
+	MagicLinkUsecase      usecase.MagicLinkUsecase
+	SecurityEventUsecase  usecase.SecurityEventUsecase
+	AuditUsecase          usecase.AuditUsecase
+	AccessUsecase         usecase.AccessUsecase
+	AuthenticationServer  pb.AuthenticationServer
 	logger *logger.Logger
 }
 
@@ -53,16 +54,22 @@ type AuthenticationHandler interface {
 	ValidateTemporaryToken(ctx *gin.Context)
 	SendResetMail(ctx *gin.Context)
 	ResetPassword(ctx *gin.Context)
+	ChangePassword(ctx *gin.Context)
 	RefreshToken(ctx *gin.Context)
 	Login1(ctx *gin.Context)
 	DeleteProfileInfo(ctx *gin.Context)
+	SendMagicLink(ctx *gin.Context)
+	MagicLogin(ctx *gin.Context)
+	LogoutAll(ctx *gin.Context)
 }
 
-func NewAuthenticationHandler(authUsecase usecase.AuthenticationUsecase, jwtUSecase usecase.JwtUsecase, profileInfoUsecase usecase.ProfileInfoUsecase, tracer opentracing.Tracer, redis usecase.RedisUsecase, totpUsecase usecase.TotpUsecase, logger *logger.Logger) AuthenticationHandler {
-	return &authenticateHandler{authUsecase, jwtUSecase, profileInfoUsecase, totpUsecase, tracer, redis, logger}
+func NewAuthenticationHandler(authUsecase usecase.AuthenticationUsecase, profileInfoUsecase usecase.ProfileInfoUsecase, tracer opentracing.Tracer, magicLinkUsecase usecase.MagicLinkUsecase,
+	securityEventUsecase usecase.SecurityEventUsecase, auditUsecase usecase.AuditUsecase, accessUsecase usecase.AccessUsecase, authenticationServer pb.AuthenticationServer, logger *logger.Logger) AuthenticationHandler {
+	return &authenticateHandler{authUsecase, profileInfoUsecase, tracer, magicLinkUsecase, securityEventUsecase, auditUsecase, accessUsecase, authenticationServer, logger}
 
 }
 
+// DeleteProfileInfo deletes the profile with the given username if the resource policy allows it.
 func (a *authenticateHandler) DeleteProfileInfo(ctx *gin.Context) {
 	a.logger.Logger.Println("Handling DELETING PROFILE INFO")
 	var usernameDto dto.AuthenticationDto
@@ -75,7 +82,24 @@ func (a *authenticateHandler) DeleteProfileInfo(ctx *gin.Context) {
 		return
 	}
 
-	err := a.ProfileInfoUsecase.DeleteProfileInfo(ctx, usernameDto.Username)
+	usernameDto.Username = strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(usernameDto.Username))
+
+	profile := a.AccessUsecase.ProfileResource(ctx, usernameDto.Username)
+	err := a.AccessUsecase.Authorize(ctx, accessSubject(ctx, a.AccessUsecase, a.logger), profile, domain.ResourceActionDelete)
+	if err == nil && profile.Id == "" {
+		ctx.JSON(404, gin.H{ "message" : "User does not exist"})
+		ctx.Abort()
+		return
+	}
+	if err == nil {
+		err = a.ProfileInfoUsecase.DeleteProfileInfo(ctx, usernameDto.Username)
+	}
+	a.AuditUsecase.Record(ctx, auditActor(ctx), usernameDto.Username, domain.AuditProfileDeleted, err)
+
+	if accessDenied(ctx, err) {
+		ctx.Abort()
+		return
+	}
 
 	if err != nil {
 		ctx.JSON(500, gin.H{ "message" : server_err})
@@ -88,7 +112,6 @@ func (a *authenticateHandler) DeleteProfileInfo(ctx *gin.Context) {
 
 func (a *authenticateHandler) Login(ctx *gin.Context) {
 	a.logger.Logger.Println("Handling LOGIN")
-	fmt.Println("tusam 1")
 	span := tracer.StartSpanFromRequest("Login", a.Tracer, ctx.Request)
 	defer span.Finish()
 	a.logMetadata(span, ctx)
@@ -104,82 +127,111 @@ func (a *authenticateHandler) Login(ctx *gin.Context) {
 		return
 	}
 
-	fmt.Println(authenticationDto)
-	policy := bluemonday.UGCPolicy()
-	authenticationDto.Username = strings.TrimSpace(policy.Sanitize(authenticationDto.Username))
-	authenticationDto.Password = strings.TrimSpace(policy.Sanitize(authenticationDto.Password))
+	span.LogFields(tracer.LogString("handler", fmt.Sprintf("request_username= %s", authenticationDto.Username)))
 
-	if authenticationDto.Password == " " || authenticationDto.Username == " " {
-		a.logger.Logger.Error("error while login, error: fields are empty")
-		a.logger.Logger.Warnf("possible xss attack from IP address %v\n", ctx.Request.Host)
-		ctx.JSON(400, gin.H{"message": "Field are empty or xss attack happened"})
+	ctx1 := tracer.ContextWithSpan(ctx, span)
+	response, err := a.AuthenticationServer.Login(ctx1, &pb.LoginCredentials{Username: authenticationDto.Username, Password: authenticationDto.Password})
+	if err != nil {
+		a.logger.Logger.Warnf("failed login for user %v from IP address %v, error: %v\n", authenticationDto.Username, ctx.GetString(helper.ClientIpKey), err)
+		tracer.LogError(span, err)
+		loginErrorResponse(ctx, err)
 		return
 	}
 
-	span.LogFields(tracer.LogString("handler", fmt.Sprintf("request_username= %s", authenticationDto.Username)))
+	ctx.JSON(200, mapper.MapLoginResponseToAuthenticatedUserInfoDto(response))
+}
 
-	ctx1 := tracer.ContextWithSpan(ctx, span)
-	profileInfo, err := a.ProfileInfoUsecase.GetProfileInfoByUsername(ctx1, authenticationDto.Username)
-	if err != nil {
-		a.logger.Logger.Errorf("error while getting profile info by for email %v, error: %v\n", authenticationDto.Username, err)
-		tracer.LogError(span, fmt.Errorf("message=%s", invalid_credentials_err))
-		ctx.JSON(400, gin.H{"message": invalid_credentials_err})
-		ctx.Abort()
+// loginErrorResponse responds to a failed login.
+func loginErrorResponse(ctx *gin.Context, err error) {
+	if tooManyAttempts(ctx, err) || accountUnavailable(ctx, err) {
 		return
 	}
+	errorResponse(ctx, err)
+}
 
-	span.LogFields(tracer.LogString("handler", fmt.Sprintf("username= %s; user_id= %s", profileInfo.Username, profileInfo.ID)))
-	if err := usecase.VerifyPassword(ctx1, authenticationDto.Password, profileInfo.Password); err != nil {
-		a.logger.Logger.Warnf("invalid password for user %v from IP address %v, error: %v\n", authenticationDto.Username, ctx.Request.Host, err)
-		ctx.JSON(400, gin.H{"message": invalid_credentials_err})
-		ctx.Abort()
+func (a *authenticateHandler) SendMagicLink(ctx *gin.Context) {
+	a.logger.Logger.Println("Handling SENDING MAGIC LINK")
+	if !a.MagicLinkUsecase.Enabled() {
+		ctx.JSON(404, gin.H{"message": "Not found"})
 		return
 	}
 
-	_, err = a.TotpUsecase.GetSecretByProfileInfoId(ctx1, profileInfo.ID)
+	span := tracer.StartSpanFromRequest("SendMagicLink", a.Tracer, ctx.Request)
+	defer span.Finish()
+	a.logMetadata(span, ctx)
+
+	var req dto.MagicLinkRequestDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&req); err != nil {
+		a.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
 
-	fmt.Println("generating secret")
-	if err == nil {
-		userInfo, err := a.CreateTemporaryToken(ctx1, profileInfo)
-		if err != nil {
-			a.logger.Logger.Errorf("error while getting temporary user token for %v, error: %v\n", profileInfo.ID, err)
-			tracer.LogError(span, err)
-			ctx.JSON(400, gin.H{"message": "Can not create temporary code"})
-			return
-		}
-		userInfo.Role = "temporary_user"
-		ctx.JSON(200, userInfo)
+	ctx1 := tracer.ContextWithSpan(ctx, span)
+	response, err := a.AuthenticationServer.SendMagicLink(ctx1, &pb.MagicLinkRequest{Email: req.Email})
+	if err != nil {
+		a.logger.Logger.Errorf("error while sending magic link, error: %v\n", err)
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
 		return
 	}
 
-	val, err := a.generateToken(ctx1, profileInfo, authenticationDto.Refresh)
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     magicLinkCookie,
+		Value:    response.Nonce,
+		Path:     "/magicLink",
+		MaxAge:   int(a.MagicLinkUsecase.Ttl().Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	})
 
-	fmt.Println("generating token")
-	if err != nil {
-		a.logger.Logger.Errorf("error while generating token for %v, error: %v\n", profileInfo.ID, err)
-		ctx.JSON(400, gin.H{"message": token_err})
-		ctx.Abort()
+	ctx.JSON(200, gin.H{"message": "If an account exists for this email, a sign in link has been sent"})
+}
+
+func (a *authenticateHandler) MagicLogin(ctx *gin.Context) {
+	a.logger.Logger.Println("Handling MAGIC LINK LOGIN")
+	if !a.MagicLinkUsecase.Enabled() {
+		ctx.JSON(404, gin.H{"message": "Not found"})
 		return
 	}
 
-	ctx.JSON(200, val)
+	span := tracer.StartSpanFromRequest("MagicLogin", a.Tracer, ctx.Request)
+	defer span.Finish()
+	a.logMetadata(span, ctx)
 
-	/*token, err := a.JwtUsecase.CreateToken(ctx1, profileInfo.Role.RoleName, profileInfo.ID)
-	if err != nil {
-		ctx.JSON(400, gin.H{"message" : token_err})
-		ctx.Abort()
+	var req dto.MagicLoginDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&req); err != nil {
+		a.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
 		return
 	}
-	authenticatedUserInfo := dto.AuthenticatedUserInfoDto{
-		Token: token.TokenUuid,
-		Role: profileInfo.Role.RoleName,
-		Id: profileInfo.ID,
+
+	nonce, _ := ctx.Cookie(magicLinkCookie)
+	ctx1 := tracer.ContextWithSpan(ctx, span)
+	response, err := a.AuthenticationServer.MagicLogin(ctx1, &pb.MagicLoginRequest{Token: req.Token, Nonce: nonce})
+	if err != nil {
+		a.logger.Logger.Errorf("error while logging in with magic link from IP address %v, error: %v\n", ctx.GetString(helper.ClientIpKey), err)
+		tracer.LogError(span, err)
+		loginErrorResponse(ctx, err)
+		return
 	}
-	a.AuthenticationUsecase.SaveAuthToken(ctx1, 12, token)
 
-	ctx.JSON(200, authenticatedUserInfo)*/
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     magicLinkCookie,
+		Path:     "/magicLink",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	ctx.JSON(200, mapper.MapLoginResponseToAuthenticatedUserInfoDto(response))
 }
 
+
 func (a *authenticateHandler) ValidateToken(ctx *gin.Context) {
 	a.logger.Logger.Println("Handling VALIDATING TOKEN")
 	var tokenDto dto.TokenDto
@@ -193,30 +245,17 @@ func (a *authenticateHandler) ValidateToken(ctx *gin.Context) {
 		return
 	}
 
-	policy := bluemonday.UGCPolicy()
-	tokenDto.TokenId = strings.TrimSpace(policy.Sanitize(tokenDto.TokenId))
-
-	at, err := a.AuthenticationUsecase.FetchAuthToken(ctx, tokenDto.TokenId)
-
+	response, err := a.AuthenticationServer.ValidateToken(ctx, &pb.Tokens{Token: tokenDto.TokenId})
 	if err != nil {
-		a.logger.Logger.Errorf("error while fetching token, error: %v\n", err)
-		ctx.JSON(401, gin.H{"message": "Invalid token"})
-		ctx.Abort()
-		return
-	}
-
-	token, err := a.JwtUsecase.ValidateToken(ctx, string(at))
-	if err != nil || token == "" {
 		a.logger.Logger.Errorf("error while validating token, error: %v\n", err)
-		ctx.JSON(401, gin.H{"message": "Invalid token"})
-		ctx.Abort()
+		loginErrorResponse(ctx, err)
 		return
 	}
 
-	ctx.JSON(200, token)
-
+	ctx.JSON(200, response.AccessToken)
 }
 
+
 func (a *authenticateHandler) Logout(ctx *gin.Context) {
 	a.logger.Logger.Println("Handling LOGOUT")
 	var tokenDto dto.TokenDto
@@ -240,6 +279,28 @@ func (a *authenticateHandler) Logout(ctx *gin.Context) {
 	ctx.JSON(200, gin.H{"message": "Sucessful logout"})
 }
 
+// LogoutAll revokes every session of the current user.
+func (a *authenticateHandler) LogoutAll(ctx *gin.Context) {
+	a.logger.Logger.Println("Handling LOGOUT ALL")
+	userId, err := middleware.ExtractUserId(ctx, ctx.Request)
+	if err != nil || userId == "" {
+		a.logger.Logger.Errorf("error while extracting user id, error: %v\n", err)
+		ctx.JSON(401, gin.H{"message": "Unauthorized"})
+		return
+	}
+
+	err = a.AuthenticationUsecase.RevokeUserSessions(ctx, userId)
+	a.AuditUsecase.Record(ctx, userId, userId, domain.AuditSessionsRevoked, err)
+	if err != nil {
+		a.logger.Logger.Errorf("error while revoking sessions for user %v, error: %v\n", userId, err)
+		ctx.JSON(500, gin.H{"message": server_err})
+		return
+	}
+
+	a.SecurityEventUsecase.Publish(ctx, domain.SecurityEvent{Type: domain.SecurityEventSessionsRevoked, ProfileInfoId: userId})
+	ctx.JSON(200, gin.H{"message": "Logged out on all devices"})
+}
+
 func (a *authenticateHandler) ResetPassword(ctx *gin.Context) {
 	a.logger.Logger.Println("Handling RESET PASSWORD")
 	decoder := json.NewDecoder(ctx.Request.Body)
@@ -250,8 +311,8 @@ func (a *authenticateHandler) ResetPassword(ctx *gin.Context) {
 
 	policy := bluemonday.UGCPolicy()
 	resetDto.Email = strings.TrimSpace(policy.Sanitize(resetDto.Email))
-	resetDto.Password = strings.TrimSpace(policy.Sanitize(resetDto.Password))
-	resetDto.ConfirmedPassword = strings.TrimSpace(policy.Sanitize(resetDto.ConfirmedPassword))
+	resetDto.Password = strings.TrimSpace(resetDto.Password)
+	resetDto.ConfirmedPassword = strings.TrimSpace(resetDto.ConfirmedPassword)
 	resetDto.VerificationCode = strings.TrimSpace(policy.Sanitize(resetDto.VerificationCode))
 
 	if err != nil {
@@ -260,22 +321,63 @@ func (a *authenticateHandler) ResetPassword(ctx *gin.Context) {
 		return
 	}
 
-	if pasval1, pasval2, pasval3, pasval4 := verifyAuthPassword(resetDto.Password); pasval1 == false || pasval2 == false || pasval3 == false || pasval4 == false {
-		a.logger.Logger.Error("error while verifying password, password is not matching pattern")
-		ctx.JSON(400, gin.H{"message": "Password must have minimum 1 uppercase letter, 1 lowercase letter, 1 digit and 1 special character and needs to be minimum 8 characters long"})
+	err = a.ProfileInfoUsecase.ResetPassword(ctx, resetDto)
+	a.AuditUsecase.Record(ctx, domain.AuditActorAnonymous, resetDto.Email, domain.AuditPasswordReset, err)
+	if err != nil {
+		a.logger.Logger.Errorf("error while reseting password, error: %v\n", err)
+		if passwordPolicyViolated(ctx, err) || tooManyAttempts(ctx, err) {
+			return
+		}
+		errorResponse(ctx, err)
 		return
 	}
 
-	errorMessage := a.ProfileInfoUsecase.ResetPassword(ctx, resetDto)
+	ctx.JSON(200, gin.H{"message": "Successfully changed password!"})
+	return
+}
+
+func (a *authenticateHandler) ChangePassword(ctx *gin.Context) {
+	a.logger.Logger.Println("Handling CHANGE PASSWORD")
+	decoder := json.NewDecoder(ctx.Request.Body)
 
-	if errorMessage != "" {
-		a.logger.Logger.Error(errorMessage)
-		ctx.JSON(400, gin.H{"message": errorMessage})
+	var changeDto dto.ChangePasswordDto
+	if err := decoder.Decode(&changeDto); err != nil {
+		a.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": "Decoding error"})
+		return
+	}
+
+	changeDto.OldPassword = strings.TrimSpace(changeDto.OldPassword)
+	changeDto.Password = strings.TrimSpace(changeDto.Password)
+	changeDto.ConfirmedPassword = strings.TrimSpace(changeDto.ConfirmedPassword)
+
+	userId, err := middleware.ExtractUserId(ctx, ctx.Request)
+	if err != nil || userId == "" {
+		a.logger.Logger.Errorf("error while extracting user id, error: %v\n", err)
+		ctx.JSON(401, gin.H{"message": "Unauthorized"})
 		return
 	}
 
+	err = a.ProfileInfoUsecase.ChangePassword(ctx, userId, changeDto)
+	a.AuditUsecase.Record(ctx, userId, userId, domain.AuditPasswordChanged, err)
+	if err != nil {
+		a.logger.Logger.Errorf("error while changing password for user %v, error: %v\n", userId, err)
+		if passwordPolicyViolated(ctx, err) {
+			return
+		}
+		errorResponse(ctx, err)
+		return
+	}
+
+	if role, err := middleware.ExtractUserRole(ctx, ctx.Request, a.logger); err == nil && role == "PASSWORD_EXPIRED" {
+		if accessUuid, err := middleware.ExtractAccessUuid(ctx, ctx.Request); err == nil {
+			if err := a.AuthenticationUsecase.DeleteAuthToken(ctx, accessUuid); err != nil {
+				a.logger.Logger.Errorf("error while deleting password change token, error: %v\n", err)
+			}
+		}
+	}
+
 	ctx.JSON(200, gin.H{"message": "Successfully changed password!"})
-	return
 }
 
 func (r *authenticateHandler) SendResetMail(ctx *gin.Context) {
@@ -298,29 +400,8 @@ func (r *authenticateHandler) SendResetMail(ctx *gin.Context) {
 		return
 	}
 
-	exists := r.ProfileInfoUsecase.ExistsByUsernameOrEmail(ctx, "", req.Email)
-	if !exists {
-		r.logger.Logger.Errorf("error while sending reset mail, error: no user with email %v\n", req.Email)
-		ctx.JSON(400, gin.H{"message": "User does not exist"})
-		return
-	}
-
-	user, _ := r.ProfileInfoUsecase.GetProfileInfoByEmail(ctx, req.Email)
-
-	code := helper.RandomStringGenerator(8)
-
-	expiration := 1000000000 * 3600 * 2 //2h
-
-	hash, _ := helper.Hash(code)
-
-	go usecase.SendRestartPasswordMail(user.Email, code)
-
-	redisKey := redisKeyPattern + user.Email
-
-	err = r.RedisUsecase.AddKeyValueSet(ctx, redisKey, hash, time.Duration(expiration))
-	if err != nil {
-		r.logger.Logger.Errorf("error while adding token to redis, error: %v\n", err)
-		ctx.JSON(500, gin.H{"message": "Error"})
+	if err := r.ProfileInfoUsecase.SendResetMail(ctx, req.Email); err != nil {
+		errorResponse(ctx, err)
 		return
 	}
 
@@ -342,36 +423,6 @@ func (a *authenticateHandler) Login1(ctx *gin.Context) {
 	ctx.JSON(200, gin.H{"username": authenticationDto.Username, "password" : authenticationDto.Password})
 }
 
-func (a *authenticateHandler) CreateTemporaryToken(ctx context.Context, profileInfo domain.ProfileInfo) (*dto.AuthenticatedUserInfoDto, error) {
-	a.logger.Logger.Println("Handling CREATING TEMPORARY TOKEN")
-	span := tracer.StartSpanFromContext(ctx, "handler/createTemporaryToken")
-	defer span.Finish()
-
-	ctx1 := tracer.ContextWithSpan(ctx, span)
-
-	temporaryToken, err := a.JwtUsecase.CreateTemporaryToken(ctx1, profileInfo.Role.RoleName, profileInfo.ID)
-
-	if err != nil {
-		a.logger.Logger.Errorf("error while creating temporary token, error: %v\n", err)
-		tracer.LogError(span, err)
-		return nil, err
-	}
-
-	authenticatedUserInfo := dto.AuthenticatedUserInfoDto{
-		Token: temporaryToken.TokenUuid,
-		Role:  profileInfo.Role.RoleName,
-		Id:    profileInfo.ID,
-	}
-
-	if err := a.AuthenticationUsecase.SaveTemporaryToken(ctx1, temporaryToken); err != nil {
-		a.logger.Logger.Errorf("error while saving temporary token, error %v\n", err)
-		tracer.LogError(span, err)
-		return nil, err
-	}
-
-	return &authenticatedUserInfo, nil
-}
-
 func (a *authenticateHandler) Validate(ctx *gin.Context) {
 	a.logger.Logger.Println("Handling VALIDATING TOTP")
 	span := tracer.StartSpanFromRequest("handler", a.Tracer, ctx.Request)
@@ -389,65 +440,25 @@ func (a *authenticateHandler) Validate(ctx *gin.Context) {
 	}
 
 	ctx1 := tracer.ContextWithSpan(ctx, span)
-	userId, err := middleware.ExtractUserId(ctx1, ctx.Request)
-
+	tokenUuid, err := middleware.ExtractTokenUuid(ctx1, ctx.Request)
 	if err != nil {
-		a.logger.Logger.Errorf("error while extracting user from token, error: %v\n", err)
+		a.logger.Logger.Errorf("error while extracting token uuid, error: %v\n", err)
 		tracer.LogError(span, fmt.Errorf("message=%s; err=%s\n", token_invalid, err))
 		ctx.JSON(400, gin.H{"message": token_invalid})
 		return
 	}
 
-	if !a.TotpUsecase.Validate(ctx1, userId, totpSecretDto.Passcode) {
-		a.logger.Logger.Error("error while validating totp passcode, error: passcode not valid")
-		tracer.LogError(span, fmt.Errorf("message= %s", totp_validation_error))
-		ctx.JSON(400, gin.H{"message": totp_validation_error})
-		return
-	}
-
-	profileInfo, err := a.ProfileInfoUsecase.GetProfileInfoById(ctx1, userId)
-	val, err := a.generateToken(ctx1, *profileInfo, totpSecretDto.Refresh)
-
+	response, err := a.AuthenticationServer.ValidateTotp(ctx1, &pb.TotpValidation{AccessToken: &pb.AccessToken{AccessToken: tokenUuid}, Passcode: totpSecretDto.Passcode})
 	if err != nil {
-		a.logger.Logger.Errorf("error while generating token, error: %v\n", err)
-		ctx.JSON(400, gin.H{"message": token_err})
-		ctx.Abort()
-		return
-	}
-
-	tokenUuid, err := middleware.ExtractTokenUuid(ctx1, ctx.Request)
-	if err := a.AuthenticationUsecase.DeleteTemporaryToken(ctx1, tokenUuid); err != nil {
-		a.logger.Logger.Errorf("error while extracting token uuid, error: %v\n", err)
-		tracer.LogError(span, fmt.Errorf("message= %s", totp_validation_error))
-		ctx.JSON(400, gin.H{"message": totp_validation_error})
+		a.logger.Logger.Errorf("error while validating totp passcode from IP address %v, error: %v\n", ctx.GetString(helper.ClientIpKey), err)
+		tracer.LogError(span, err)
+		loginErrorResponse(ctx, err)
 		return
 	}
 
-	ctx.JSON(200, val)
-
+	ctx.JSON(200, mapper.MapLoginResponseToAuthenticatedUserInfoDto(response))
 }
 
-func (a *authenticateHandler) generateToken(ctx context.Context, profileInfo domain.ProfileInfo, refresh bool) (*dto.AuthenticatedUserInfoDto, error) {
-	a.logger.Logger.Println("Handling GENERATING TOKEN")
-	span := tracer.StartSpanFromContext(ctx, "handler/generateToken")
-	defer span.Finish()
-
-	ctx1 := tracer.ContextWithSpan(ctx, span)
-
-	token, err := a.JwtUsecase.CreateToken(ctx1, profileInfo.Role.RoleName, profileInfo.ID, true)
-	if err != nil {
-		a.logger.Logger.Errorf("error while creating token, error: %v\n", err)
-		return nil, err
-	}
-	authenticatedUserInfo := dto.AuthenticatedUserInfoDto{
-		Token: token.TokenUuid,
-		Role:  profileInfo.Role.RoleName,
-		Id:    profileInfo.ID,
-	}
-
-
-	return &authenticatedUserInfo, nil
-}
 
 func (a *authenticateHandler) ValidateTemporaryToken(ctx *gin.Context) {
 	a.logger.Logger.Println("Handling VALIDATING TEMPORARY TOKEN")
@@ -465,26 +476,17 @@ func (a *authenticateHandler) ValidateTemporaryToken(ctx *gin.Context) {
 		return
 	}
 
-	at, err := a.AuthenticationUsecase.FetchTemporaryToken(ctx, tokenDto.TokenId)
-
+	response, err := a.AuthenticationServer.ValidateTemporaryToken(tracer.ContextWithSpan(ctx, span), &pb.AccessToken{AccessToken: tokenDto.TokenId})
 	if err != nil {
-		a.logger.Logger.Errorf("error while fetch temporary token, error: %v\n", err)
-		ctx.JSON(401, gin.H{"message": "Invalid token"})
-		ctx.Abort()
+		a.logger.Logger.Errorf("error while validating temporary token, error: %v\n", err)
+		loginErrorResponse(ctx, err)
 		return
 	}
 
-	token, err := a.JwtUsecase.ValidateToken(ctx, string(at))
-	if err != nil || token == "" {
-		a.logger.Logger.Errorf("error while validating token, error: %v\n", err)
-		ctx.JSON(401, gin.H{"message": "Invalid token"})
-		ctx.Abort()
-		return
-	}
-
-	ctx.JSON(200, token)
+	ctx.JSON(200, response.AccessToken)
 }
 
+
 func serialize(value string) ([]byte, error) {
 	b := bytes.Buffer{}
 	e := gob.NewEncoder(&b)
@@ -497,38 +499,15 @@ func serialize(value string) ([]byte, error) {
 func (a *authenticateHandler) logMetadata(span opentracing.Span, ctx *gin.Context) {
 	span.LogFields(
 		tracer.LogString("handler: ", fmt.Sprintf("handling login at %s\n", ctx.Request.URL.Path)),
-		tracer.LogString("handler: ", fmt.Sprintf("client ip= %s\n", ctx.ClientIP())),
+		tracer.LogString("handler: ", fmt.Sprintf("client ip= %s\n", ctx.GetString(helper.ClientIpKey))),
 		tracer.LogString("handler", fmt.Sprintf("method= %s\n", ctx.Request.Method)),
 		tracer.LogString("handler", fmt.Sprintf("header= %s\n", ctx.Request.Header)),
 	)
 }
 
-func verifyAuthPassword(s string) (eightOrMore, number, upper, special bool) {
-	letters := 0
-	for _, c := range s {
-		switch {
-		case unicode.IsNumber(c):
-			number = true
-			letters++
-		case unicode.IsUpper(c):
-			upper = true
-			letters++
-		case unicode.IsPunct(c) || unicode.IsSymbol(c):
-			special = true
-			letters++
-		case unicode.IsLetter(c) || c == ' ':
-			letters++
-		default:
-			return false, false, false, false
-		}
-	}
-	eightOrMore = letters >= 8
-	return
-}
-
 func (a *authenticateHandler) RefreshToken(ctx *gin.Context) {
-	a.logger.Logger.Println("Handling LOGIN")
-	span := tracer.StartSpanFromRequest("Login", a.Tracer, ctx.Request)
+	a.logger.Logger.Println("Handling REFRESH TOKEN")
+	span := tracer.StartSpanFromRequest("RefreshToken", a.Tracer, ctx.Request)
 	defer span.Finish()
 	a.logMetadata(span, ctx)
 
@@ -543,36 +522,12 @@ func (a *authenticateHandler) RefreshToken(ctx *gin.Context) {
 		return
 	}
 
-	policy := bluemonday.UGCPolicy()
-	tokenDto.TokenId = strings.TrimSpace(policy.Sanitize(tokenDto.TokenId))
-
-	ctx1 := tracer.ContextWithSpan(ctx, span)
-	rt, err := a.AuthenticationUsecase.FetchRefreshToken(ctx1, tokenDto.TokenId)
-
-	if err != nil {
-		a.logger.Logger.Errorf("error while fetching refresh token, error: %v\n", err)
-		ctx.JSON(401, gin.H{"message": "Invalid token"})
-		return
-	}
-
-	token, uuid, err := a.JwtUsecase.RefreshToken(ctx1, string(rt))
-
+	response, err := a.AuthenticationServer.RefreshToken(tracer.ContextWithSpan(ctx, span), &pb.TokenRequest{Token: tokenDto.TokenId})
 	if err != nil {
 		a.logger.Logger.Errorf("error while refreshing token, error: %v\n", err)
-		ctx.JSON(401, gin.H{"message": "Invalid token"})
+		loginErrorResponse(ctx, err)
 		return
 	}
 
-	err = a.JwtUsecase.DeleteRefreshToken(ctx1, tokenDto.TokenId)
-
-	if err != nil {
-		a.logger.Logger.Errorf("error while refreshing token, error: %v\n", err)
-		ctx.JSON(401, gin.H{"message": "Invalid token"})
-		return
-	}
-
-
-	refreshTokenDto := dto.RefreshTokenDto{TokenUuid: *uuid, Token: *token}
-	a.logger.Logger.Infof("token refreshed for token %v\n", string(rt))
-	ctx.JSON(200, refreshTokenDto)
-}
\ No newline at end of file
+	ctx.JSON(200, dto.RefreshTokenDto{TokenUuid: response.AccessTokenUuid, Token: response.AccessToken})
+}
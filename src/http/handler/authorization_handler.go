@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/mapper"
+	"auth-service/infrastructure/tracer"
+	"auth-service/usecase"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/opentracing/opentracing-go"
+	"strings"
+	"time"
+)
+
+type authorizationHandler struct {
+	AuthorizationUsecase usecase.AuthorizationUsecase
+	Tracer               opentracing.Tracer
+	logger               *logger.Logger
+}
+
+type AuthorizationHandler interface {
+	Authorize(ctx *gin.Context)
+	AuthorizeBatch(ctx *gin.Context)
+}
+
+func NewAuthorizationHandler(authorizationUsecase usecase.AuthorizationUsecase, tracer opentracing.Tracer, logger *logger.Logger) AuthorizationHandler {
+	return &authorizationHandler{AuthorizationUsecase: authorizationUsecase, Tracer: tracer, logger: logger}
+}
+
+// Authorize tells another service whether the token in the body may perform the action.
+func (a *authorizationHandler) Authorize(ctx *gin.Context) {
+	a.logger.Logger.Println("Handling AUTHORIZE")
+	span := tracer.StartSpanFromRequest("Authorize", a.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var request dto.AuthorizeRequestDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&request); err != nil {
+		a.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	checks := []dto.AuthorizationCheckDto{{Object: request.Object, Action: request.Action}}
+	decisions, ttl, err := a.AuthorizationUsecase.Authorize(ctx, sanitizeTransport(request.Transport), strings.TrimSpace(request.Token), mapper.MapAuthorizationCheckDtos(sanitizeChecks(checks)))
+	if err != nil {
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	cacheDecision(ctx, ttl)
+	ctx.JSON(200, dto.AuthorizeResponseDto{
+		AuthorizationDecisionDto: mapper.MapAuthorizationDecisionToDto(decisions[0]),
+		TtlSeconds:               int64(ttl.Seconds()),
+	})
+}
+
+// AuthorizeBatch makes several checks for the same token at once.
+func (a *authorizationHandler) AuthorizeBatch(ctx *gin.Context) {
+	a.logger.Logger.Println("Handling AUTHORIZE BATCH")
+	span := tracer.StartSpanFromRequest("AuthorizeBatch", a.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var request dto.AuthorizeBatchRequestDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&request); err != nil {
+		a.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	decisions, ttl, err := a.AuthorizationUsecase.Authorize(ctx, sanitizeTransport(request.Transport), strings.TrimSpace(request.Token), mapper.MapAuthorizationCheckDtos(sanitizeChecks(request.Checks)))
+	if err != nil {
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	cacheDecision(ctx, ttl)
+	ctx.JSON(200, dto.AuthorizeBatchResponseDto{
+		Decisions:  mapper.MapAuthorizationDecisionsToDtos(decisions),
+		TtlSeconds: int64(ttl.Seconds()),
+	})
+}
+
+func sanitizeTransport(transport string) string {
+	return strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(transport))
+}
+
+func sanitizeChecks(checks []dto.AuthorizationCheckDto) []dto.AuthorizationCheckDto {
+	policy := bluemonday.UGCPolicy()
+	for i := range checks {
+		checks[i].Object = strings.TrimSpace(policy.Sanitize(checks[i].Object))
+		checks[i].Action = strings.TrimSpace(policy.Sanitize(checks[i].Action))
+	}
+
+	return checks
+}
+
+// cacheDecision repeats ttl_seconds for clients that go by Cache-Control.
+func cacheDecision(ctx *gin.Context, ttl time.Duration) {
+	if ttl <= 0 {
+		ctx.Header("Cache-Control", "no-store")
+		return
+	}
+	ctx.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", int64(ttl.Seconds())))
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"auth-service/usecase"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+func tooManyAttempts(ctx *gin.Context, err error) bool {
+	var attemptsErr *usecase.TooManyAttemptsError
+	if !errors.As(err, &attemptsErr) {
+		return false
+	}
+
+	ctx.Header("Retry-After", strconv.Itoa(int(attemptsErr.RetryAfter.Seconds())+1))
+	ctx.JSON(429, gin.H{"message": attemptsErr.Error()})
+	return true
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"auth-service/http/middleware"
+	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/tracer"
+	"auth-service/usecase"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/opentracing/opentracing-go"
+	"strings"
+)
+
+type emailChangeHandler struct {
+	EmailChangeUsecase usecase.EmailChangeUsecase
+	Tracer             opentracing.Tracer
+	logger             *logger.Logger
+}
+
+type EmailChangeHandler interface {
+	RequestEmailChange(ctx *gin.Context)
+	ConfirmEmailChange(ctx *gin.Context)
+	CancelEmailChange(ctx *gin.Context)
+}
+
+func NewEmailChangeHandler(emailChangeUsecase usecase.EmailChangeUsecase, tracer opentracing.Tracer, logger *logger.Logger) EmailChangeHandler {
+	return &emailChangeHandler{EmailChangeUsecase: emailChangeUsecase, Tracer: tracer, logger: logger}
+}
+
+func (e *emailChangeHandler) RequestEmailChange(ctx *gin.Context) {
+	e.logger.Logger.Println("Handling REQUEST EMAIL CHANGE")
+	span := tracer.StartSpanFromRequest("RequestEmailChange", e.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var changeDto dto.EmailChangeDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&changeDto); err != nil {
+		e.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	policy := bluemonday.UGCPolicy()
+	changeDto.Email = strings.TrimSpace(policy.Sanitize(changeDto.Email))
+	changeDto.Password = strings.TrimSpace(changeDto.Password)
+	if changeDto.Email == "" || changeDto.Password == "" {
+		ctx.JSON(400, gin.H{"message": "Email and password are required"})
+		return
+	}
+
+	ctx1 := tracer.ContextWithSpan(ctx, span)
+	userId, err := middleware.ExtractUserId(ctx1, ctx.Request)
+	if err != nil || userId == "" {
+		e.logger.Logger.Errorf("error while extracting user id, error: %v\n", err)
+		ctx.JSON(401, gin.H{"message": "Unauthorized"})
+		return
+	}
+
+	if err := e.EmailChangeUsecase.RequestChange(ctx1, userId, changeDto); err != nil {
+		e.logger.Logger.Errorf("error while requesting email change for user %v, error: %v\n", userId, err)
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Check your new email for the confirmation code!"})
+}
+
+func (e *emailChangeHandler) ConfirmEmailChange(ctx *gin.Context) {
+	e.logger.Logger.Println("Handling CONFIRM EMAIL CHANGE")
+	span := tracer.StartSpanFromRequest("ConfirmEmailChange", e.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var confirmDto dto.EmailChangeConfirmDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&confirmDto); err != nil {
+		e.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	policy := bluemonday.UGCPolicy()
+	confirmDto.Code = strings.TrimSpace(policy.Sanitize(confirmDto.Code))
+
+	ctx1 := tracer.ContextWithSpan(ctx, span)
+	userId, err := middleware.ExtractUserId(ctx1, ctx.Request)
+	if err != nil || userId == "" {
+		e.logger.Logger.Errorf("error while extracting user id, error: %v\n", err)
+		ctx.JSON(401, gin.H{"message": "Unauthorized"})
+		return
+	}
+
+	if err := e.EmailChangeUsecase.ConfirmChange(ctx1, userId, confirmDto.Code); err != nil {
+		e.logger.Logger.Errorf("error while confirming email change for user %v, error: %v\n", userId, err)
+		tracer.LogError(span, err)
+		if tooManyAttempts(ctx, err) {
+			return
+		}
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Email successfully changed, please log in again"})
+}
+
+func (e *emailChangeHandler) CancelEmailChange(ctx *gin.Context) {
+	e.logger.Logger.Println("Handling CANCEL EMAIL CHANGE")
+	span := tracer.StartSpanFromRequest("CancelEmailChange", e.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var cancelDto dto.EmailChangeCancelDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&cancelDto); err != nil {
+		e.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	ctx1 := tracer.ContextWithSpan(ctx, span)
+	if err := e.EmailChangeUsecase.CancelChange(ctx1, strings.TrimSpace(cancelDto.Token)); err != nil {
+		e.logger.Logger.Errorf("error while cancelling email change, error: %v\n", err)
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Email change cancelled"})
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"auth-service/domain"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+var errorStatuses = map[string]int{
+	domain.ErrorInvalidArgument:   400,
+	domain.ErrorUnauthenticated:   401,
+	domain.ErrorPermissionDenied:  403,
+	domain.ErrorNotFound:          404,
+	domain.ErrorResourceExhausted: 429,
+	domain.ErrorInternal:          500,
+}
+
+// errorResponse responds with the status, reason and details of the error's kind.
+func errorResponse(ctx *gin.Context, err error) {
+	domainErr := domain.AsError(err)
+	statusCode, ok := errorStatuses[domainErr.Kind]
+	if !ok {
+		statusCode = 500
+	}
+
+	response := gin.H{"message": domainErr.Message, "reason": domainErr.Reason}
+	if len(domainErr.Metadata) > 0 {
+		response["metadata"] = domainErr.Metadata
+	}
+	if len(domainErr.Violations) > 0 {
+		violations := make([]gin.H, 0, len(domainErr.Violations))
+		for _, violation := range domainErr.Violations {
+			violations = append(violations, gin.H{"field": violation.Field, "description": violation.Description})
+		}
+		response["violations"] = violations
+	}
+	if domainErr.RetryAfter > 0 {
+		ctx.Header("Retry-After", strconv.Itoa(int(domainErr.RetryAfter.Seconds())+1))
+	}
+
+	ctx.JSON(statusCode, response)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"auth-service/domain"
+	"auth-service/usecase"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestErrorResponse(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		respond        func(ctx *gin.Context, err error)
+		wantStatus     int
+		wantBody       map[string]interface{}
+		wantRetryAfter string
+	}{
+		{
+			name: "invalid argument with violations", err: domain.InvalidArgument("invalid request", domain.FieldViolation{Field: "email", Description: "email is required"}),
+			respond: errorResponse, wantStatus: 400,
+			wantBody: map[string]interface{}{"message": "invalid request", "reason": domain.ReasonInvalidArgument,
+				"violations": []interface{}{map[string]interface{}{"field": "email", "description": "email is required"}}},
+		},
+		{
+			name: "unauthenticated", err: domain.Unauthenticated(domain.ReasonInvalidToken, "token is invalid"),
+			respond: errorResponse, wantStatus: 401,
+			wantBody: map[string]interface{}{"message": "token is invalid", "reason": domain.ReasonInvalidToken},
+		},
+		{
+			name: "permission denied with metadata", err: &domain.Error{Kind: domain.ErrorPermissionDenied, Reason: domain.ReasonAccountSuspended, Message: "account is suspended", Metadata: map[string]string{"until": "2021-06-01T00:00:00Z"}},
+			respond: errorResponse, wantStatus: 403,
+			wantBody: map[string]interface{}{"message": "account is suspended", "reason": domain.ReasonAccountSuspended, "metadata": map[string]interface{}{"until": "2021-06-01T00:00:00Z"}},
+		},
+		{
+			name: "wrapped not found", err: fmt.Errorf("profile: %w", domain.NotFound("user not found")),
+			respond: errorResponse, wantStatus: 404,
+			wantBody: map[string]interface{}{"message": "user not found", "reason": domain.ReasonNotFound},
+		},
+		{
+			name: "resource exhausted rounds retry after up", err: domain.ResourceExhausted(domain.ReasonRateLimited, "too many requests", 1500*time.Millisecond),
+			respond: errorResponse, wantStatus: 429, wantRetryAfter: "2",
+			wantBody: map[string]interface{}{"message": "too many requests", "reason": domain.ReasonRateLimited},
+		},
+		{
+			name: "raw error hides its cause", err: errors.New("pq: connection refused"),
+			respond: errorResponse, wantStatus: 500,
+			wantBody: map[string]interface{}{"message": "internal error", "reason": domain.ReasonInternal},
+		},
+		{
+			name: "unknown kind is internal", err: &domain.Error{Kind: "conflict", Reason: "CONFLICT", Message: "conflict"},
+			respond: errorResponse, wantStatus: 500,
+			wantBody: map[string]interface{}{"message": "conflict", "reason": "CONFLICT"},
+		},
+		{
+			name: "login with too many attempts", err: &usecase.TooManyAttemptsError{RetryAfter: 30 * time.Second},
+			respond: loginErrorResponse, wantStatus: 429, wantRetryAfter: "31",
+			wantBody: map[string]interface{}{"message": "too many failed attempts, try again in 30s"},
+		},
+		{
+			name: "login to a banned account", err: &usecase.AccountStatusError{Status: domain.AccountBanned},
+			respond: loginErrorResponse, wantStatus: 403,
+			wantBody: map[string]interface{}{"message": "account is banned", "status": domain.AccountBanned},
+		},
+		{
+			name: "login with invalid credentials", err: domain.Unauthenticated(domain.ReasonInvalidCredentials, "invalid credentials"),
+			respond: loginErrorResponse, wantStatus: 401,
+			wantBody: map[string]interface{}{"message": "invalid credentials", "reason": domain.ReasonInvalidCredentials},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			tt.respond(ctx, tt.err)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", recorder.Code, tt.wantStatus)
+			}
+			if got := recorder.Header().Get("Retry-After"); got != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatalf("response is not json: %v", err)
+			}
+			if !reflect.DeepEqual(body, tt.wantBody) {
+				t.Errorf("body = %v, want %v", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/mapper"
+	"auth-service/infrastructure/tracer"
+	"auth-service/usecase"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/opentracing/opentracing-go"
+	"strings"
+)
+
+type impersonationHandler struct {
+	ImpersonationUsecase usecase.ImpersonationUsecase
+	Tracer               opentracing.Tracer
+	logger               *logger.Logger
+}
+
+type ImpersonationHandler interface {
+	StartImpersonation(ctx *gin.Context)
+	EndImpersonation(ctx *gin.Context)
+}
+
+func NewImpersonationHandler(impersonationUsecase usecase.ImpersonationUsecase, tracer opentracing.Tracer, logger *logger.Logger) ImpersonationHandler {
+	return &impersonationHandler{ImpersonationUsecase: impersonationUsecase, Tracer: tracer, logger: logger}
+}
+
+// StartImpersonation issues a short-lived token that lets the admin act as the user.
+func (i *impersonationHandler) StartImpersonation(ctx *gin.Context) {
+	i.logger.Logger.Println("Handling START IMPERSONATION")
+	span := tracer.StartSpanFromRequest("StartImpersonation", i.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var request dto.ImpersonationRequestDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&request); err != nil {
+		i.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	policy := bluemonday.UGCPolicy()
+	request.UserId = strings.TrimSpace(policy.Sanitize(request.UserId))
+	request.Reason = strings.TrimSpace(policy.Sanitize(request.Reason))
+
+	impersonation, err := i.ImpersonationUsecase.Start(ctx, auditActor(ctx), request.UserId, request.Reason)
+	if err != nil {
+		i.logger.Logger.Errorf("error while impersonating user %v, error: %v\n", request.UserId, err)
+		tracer.LogError(span, err)
+		if accountUnavailable(ctx, err) {
+			return
+		}
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, mapper.MapImpersonationToDto(*impersonation))
+}
+
+// EndImpersonation revokes an impersonation token before it expires.
+func (i *impersonationHandler) EndImpersonation(ctx *gin.Context) {
+	i.logger.Logger.Println("Handling END IMPERSONATION")
+	span := tracer.StartSpanFromRequest("EndImpersonation", i.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var tokenDto dto.TokenDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&tokenDto); err != nil {
+		i.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	policy := bluemonday.UGCPolicy()
+	tokenDto.TokenId = strings.TrimSpace(policy.Sanitize(tokenDto.TokenId))
+
+	if err := i.ImpersonationUsecase.End(ctx, auditActor(ctx), tokenDto.TokenId); err != nil {
+		i.logger.Logger.Errorf("error while ending impersonation, error: %v\n", err)
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Impersonation ended"})
+}
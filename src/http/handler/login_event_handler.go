@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"auth-service/domain"
+	"auth-service/http/middleware"
+	"auth-service/infrastructure/tracer"
+	"auth-service/usecase"
+	"github.com/gin-gonic/gin"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/opentracing/opentracing-go"
+	"strconv"
+	"time"
+)
+
+const invalid_activity_filter = "Invalid activity filter"
+
+type loginEventHandler struct {
+	LoginEventUsecase usecase.LoginEventUsecase
+	Tracer            opentracing.Tracer
+	logger            *logger.Logger
+}
+
+type LoginEventHandler interface {
+	GetActivity(ctx *gin.Context)
+	SearchActivity(ctx *gin.Context)
+}
+
+func NewLoginEventHandler(loginEventUsecase usecase.LoginEventUsecase, tracer opentracing.Tracer, logger *logger.Logger) LoginEventHandler {
+	return &loginEventHandler{LoginEventUsecase: loginEventUsecase, Tracer: tracer, logger: logger}
+}
+
+// GetActivity returns the recent login attempts of the calling user.
+func (l *loginEventHandler) GetActivity(ctx *gin.Context) {
+	l.logger.Logger.Println("Handling GET ACTIVITY")
+	span := tracer.StartSpanFromRequest("GetActivity", l.Tracer, ctx.Request)
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(ctx, span)
+	userId, err := middleware.ExtractUserId(ctx1, ctx.Request)
+	if err != nil || userId == "" {
+		l.logger.Logger.Errorf("error while extracting user id, error: %v\n", err)
+		ctx.JSON(401, gin.H{"message": "Unauthorized"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(ctx.Query("limit"))
+	events, err := l.LoginEventUsecase.GetActivity(ctx1, userId, limit)
+	if err != nil {
+		l.logger.Logger.Errorf("error while getting activity for user %v, error: %v\n", userId, err)
+		tracer.LogError(span, err)
+		ctx.JSON(500, gin.H{"message": server_err})
+		return
+	}
+
+	ctx.JSON(200, events)
+}
+
+// SearchActivity lets admins query the login attempts of any account.
+func (l *loginEventHandler) SearchActivity(ctx *gin.Context) {
+	l.logger.Logger.Println("Handling SEARCH ACTIVITY")
+	span := tracer.StartSpanFromRequest("SearchActivity", l.Tracer, ctx.Request)
+	defer span.Finish()
+
+	filter, err := activityFilter(ctx)
+	if err != nil {
+		l.logger.Logger.Errorf("error while parsing activity filter, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": invalid_activity_filter})
+		return
+	}
+
+	events, err := l.LoginEventUsecase.Search(tracer.ContextWithSpan(ctx, span), filter)
+	if err != nil {
+		l.logger.Logger.Errorf("error while searching activity, error: %v\n", err)
+		tracer.LogError(span, err)
+		ctx.JSON(500, gin.H{"message": server_err})
+		return
+	}
+
+	ctx.JSON(200, events)
+}
+
+func activityFilter(ctx *gin.Context) (domain.LoginEventFilter, error) {
+	filter := domain.LoginEventFilter{
+		ProfileInfoId: ctx.Query("user_id"),
+		Username:      ctx.Query("username"),
+		IpAddress:     ctx.Query("ip"),
+	}
+
+	var err error
+	if value := ctx.Query("success"); value != "" {
+		success, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Success = &success
+	}
+	if value := ctx.Query("since"); value != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, value); err != nil {
+			return filter, err
+		}
+	}
+	if value := ctx.Query("until"); value != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, value); err != nil {
+			return filter, err
+		}
+	}
+	if value := ctx.Query("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/mapper"
+	"auth-service/infrastructure/tracer"
+	"auth-service/usecase"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/opentracing/opentracing-go"
+	"strings"
+)
+
+type oauthHandler struct {
+	OAuthClientUsecase usecase.OAuthClientUsecase
+	Tracer             opentracing.Tracer
+	logger             *logger.Logger
+}
+
+type OAuthHandler interface {
+	IssueClientToken(ctx *gin.Context)
+	GetOAuthClients(ctx *gin.Context)
+	CreateOAuthClient(ctx *gin.Context)
+	DeleteOAuthClient(ctx *gin.Context)
+}
+
+func NewOAuthHandler(oauthClientUsecase usecase.OAuthClientUsecase, tracer opentracing.Tracer, logger *logger.Logger) OAuthHandler {
+	return &oauthHandler{OAuthClientUsecase: oauthClientUsecase, Tracer: tracer, logger: logger}
+}
+
+// IssueClientToken is the OAuth 2.0 token endpoint for the client credentials grant.
+func (o *oauthHandler) IssueClientToken(ctx *gin.Context) {
+	o.logger.Logger.Println("Handling ISSUE CLIENT TOKEN")
+	span := tracer.StartSpanFromRequest("IssueClientToken", o.Tracer, ctx.Request)
+	defer span.Finish()
+
+	ctx.Header("Cache-Control", "no-store")
+
+	var request dto.OAuthTokenRequestDto
+	if err := ctx.ShouldBind(&request); err != nil {
+		ctx.JSON(400, gin.H{"error": usecase.OAuthInvalidRequest, "error_description": body_decoding_err})
+		return
+	}
+
+	policy := bluemonday.UGCPolicy()
+	request.GrantType = strings.TrimSpace(policy.Sanitize(request.GrantType))
+	request.ClientId = strings.TrimSpace(policy.Sanitize(request.ClientId))
+	request.Scope = strings.TrimSpace(policy.Sanitize(request.Scope))
+
+	td, scopes, err := o.OAuthClientUsecase.IssueToken(ctx, request.GrantType, request.ClientId, request.ClientSecret, domain.SplitScopes(request.Scope))
+	if err != nil {
+		tracer.LogError(span, err)
+		if tooManyAttempts(ctx, err) {
+			return
+		}
+
+		var oauthErr *usecase.OAuthError
+		if !errors.As(err, &oauthErr) {
+			ctx.JSON(500, gin.H{"error": "server_error"})
+			return
+		}
+
+		o.logger.Logger.Warnf("token request of oauth client %v denied: %v\n", request.ClientId, oauthErr.Code)
+		status := 400
+		if oauthErr.Code == usecase.OAuthInvalidClient {
+			status = 401
+		}
+		ctx.JSON(status, gin.H{"error": oauthErr.Code, "error_description": oauthErr.Description})
+		return
+	}
+
+	ctx.JSON(200, mapper.MapTokenDetailsToOAuthTokenDto(*td, scopes))
+}
+
+func (o *oauthHandler) GetOAuthClients(ctx *gin.Context) {
+	o.logger.Logger.Println("Handling GET OAUTH CLIENTS")
+	span := tracer.StartSpanFromRequest("GetOAuthClients", o.Tracer, ctx.Request)
+	defer span.Finish()
+
+	clients, err := o.OAuthClientUsecase.List(ctx)
+	if err != nil {
+		tracer.LogError(span, err)
+		ctx.JSON(500, gin.H{"message": server_err})
+		return
+	}
+
+	response := make([]dto.OAuthClientDto, 0, len(clients))
+	for _, client := range clients {
+		response = append(response, mapper.MapOAuthClientToOAuthClientDto(client, ""))
+	}
+	ctx.JSON(200, response)
+}
+
+// CreateOAuthClient responds with the client's secret, which can't be retrieved later.
+func (o *oauthHandler) CreateOAuthClient(ctx *gin.Context) {
+	o.logger.Logger.Println("Handling CREATE OAUTH CLIENT")
+	span := tracer.StartSpanFromRequest("CreateOAuthClient", o.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var request dto.OAuthClientRequestDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&request); err != nil {
+		o.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	policy := bluemonday.UGCPolicy()
+	request.Name = strings.TrimSpace(policy.Sanitize(request.Name))
+	for i := range request.Scopes {
+		request.Scopes[i] = policy.Sanitize(request.Scopes[i])
+	}
+
+	client, secret, err := o.OAuthClientUsecase.Create(ctx, auditActor(ctx), request.Name, request.Scopes)
+	if err != nil {
+		o.logger.Logger.Errorf("error while creating oauth client %v, error: %v\n", request.Name, err)
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(201, mapper.MapOAuthClientToOAuthClientDto(*client, secret))
+}
+
+func (o *oauthHandler) DeleteOAuthClient(ctx *gin.Context) {
+	o.logger.Logger.Println("Handling DELETE OAUTH CLIENT")
+	span := tracer.StartSpanFromRequest("DeleteOAuthClient", o.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var request dto.OAuthClientIdDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&request); err != nil {
+		o.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	request.ClientId = strings.TrimSpace(bluemonday.UGCPolicy().Sanitize(request.ClientId))
+	if err := o.OAuthClientUsecase.Delete(ctx, auditActor(ctx), request.ClientId); err != nil {
+		o.logger.Logger.Errorf("error while deleting oauth client %v, error: %v\n", request.ClientId, err)
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "ok"})
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"auth-service/domain"
+	"auth-service/usecase"
+	"github.com/gin-gonic/gin"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"strconv"
+)
+
+type outboxHandler struct {
+	OutboxUsecase usecase.OutboxUsecase
+	AuditUsecase  usecase.AuditUsecase
+	logger        *logger.Logger
+}
+
+type OutboxHandler interface {
+	GetOutboxMessages(ctx *gin.Context)
+	RedriveOutboxMessage(ctx *gin.Context)
+}
+
+func NewOutboxHandler(outboxUsecase usecase.OutboxUsecase, auditUsecase usecase.AuditUsecase, logger *logger.Logger) OutboxHandler {
+	return &outboxHandler{OutboxUsecase: outboxUsecase, AuditUsecase: auditUsecase, logger: logger}
+}
+
+func (o *outboxHandler) GetOutboxMessages(ctx *gin.Context) {
+	o.logger.Logger.Println("Handling GET OUTBOX MESSAGES")
+	status := ctx.DefaultQuery("status", domain.OutboxDead)
+	if status == "all" {
+		status = ""
+	}
+
+	messages, err := o.OutboxUsecase.GetByStatus(ctx, status)
+	if err != nil {
+		o.logger.Logger.Errorf("error while getting outbox messages, error: %v\n", err)
+		ctx.JSON(500, gin.H{"message": server_err})
+		return
+	}
+
+	ctx.JSON(200, messages)
+}
+
+func (o *outboxHandler) RedriveOutboxMessage(ctx *gin.Context) {
+	o.logger.Logger.Println("Handling REDRIVE OUTBOX MESSAGE")
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(400, gin.H{"message": "Invalid message id"})
+		return
+	}
+
+	err = o.OutboxUsecase.Redrive(ctx, uint(id))
+	o.AuditUsecase.Record(ctx, auditActor(ctx), "outbox/"+ctx.Param("id"), domain.AuditOutboxRedriven, err)
+	if err != nil {
+		o.logger.Logger.Errorf("error while re-driving outbox message %v, error: %v\n", id, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Message queued for delivery"})
+}
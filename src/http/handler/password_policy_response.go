@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"auth-service/usecase"
+	"errors"
+	"github.com/gin-gonic/gin"
+)
+
+func passwordPolicyViolated(ctx *gin.Context, err error) bool {
+	var policyErr *usecase.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return false
+	}
+
+	ctx.JSON(400, gin.H{"message": policyErr.Error(), "violations": policyErr.Violations})
+	return true
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/mapper"
+	"auth-service/infrastructure/tracer"
+	"auth-service/usecase"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/opentracing/opentracing-go"
+	"strings"
+)
+
+type policyHandler struct {
+	PolicyUsecase usecase.PolicyUsecase
+	Tracer        opentracing.Tracer
+	logger        *logger.Logger
+}
+
+type PolicyHandler interface {
+	ReloadPolicy(ctx *gin.Context)
+	GetPolicies(ctx *gin.Context)
+	AddPolicy(ctx *gin.Context)
+	RemovePolicy(ctx *gin.Context)
+	AddRoleInheritance(ctx *gin.Context)
+	RemoveRoleInheritance(ctx *gin.Context)
+	ExplainPolicy(ctx *gin.Context)
+	DiffPolicy(ctx *gin.Context)
+}
+
+func NewPolicyHandler(policyUsecase usecase.PolicyUsecase, tracer opentracing.Tracer, logger *logger.Logger) PolicyHandler {
+	return &policyHandler{PolicyUsecase: policyUsecase, Tracer: tracer, logger: logger}
+}
+
+// ReloadPolicy reloads the HTTP and gRPC policies without a restart.
+func (p *policyHandler) ReloadPolicy(ctx *gin.Context) {
+	p.logger.Logger.Println("Handling RELOAD POLICY")
+	span := tracer.StartSpanFromRequest("ReloadPolicy", p.Tracer, ctx.Request)
+	defer span.Finish()
+
+	if err := p.PolicyUsecase.Reload(ctx, auditActor(ctx)); err != nil {
+		p.logger.Logger.Errorf("error while reloading policy, error: %v\n", err)
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Policy reloaded"})
+}
+
+// GetPolicies lists the policy of the transport query parameter, http by default.
+func (p *policyHandler) GetPolicies(ctx *gin.Context) {
+	p.logger.Logger.Println("Handling GET POLICIES")
+	span := tracer.StartSpanFromRequest("GetPolicies", p.Tracer, ctx.Request)
+	defer span.Finish()
+
+	transport := ctx.DefaultQuery("transport", domain.PolicyTransportHttp)
+	policies, inheritances, err := p.PolicyUsecase.List(ctx, transport)
+	if err != nil {
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, mapper.MapPoliciesToPolicyListDto(transport, policies, inheritances))
+}
+
+func (p *policyHandler) AddPolicy(ctx *gin.Context) {
+	p.logger.Logger.Println("Handling ADD POLICY")
+	p.changePolicy(ctx, "AddPolicy", p.PolicyUsecase.AddPolicy)
+}
+
+func (p *policyHandler) RemovePolicy(ctx *gin.Context) {
+	p.logger.Logger.Println("Handling REMOVE POLICY")
+	p.changePolicy(ctx, "RemovePolicy", p.PolicyUsecase.RemovePolicy)
+}
+
+func (p *policyHandler) AddRoleInheritance(ctx *gin.Context) {
+	p.logger.Logger.Println("Handling ADD ROLE INHERITANCE")
+	p.changeInheritance(ctx, "AddRoleInheritance", p.PolicyUsecase.AddInheritance)
+}
+
+func (p *policyHandler) RemoveRoleInheritance(ctx *gin.Context) {
+	p.logger.Logger.Println("Handling REMOVE ROLE INHERITANCE")
+	p.changeInheritance(ctx, "RemoveRoleInheritance", p.PolicyUsecase.RemoveInheritance)
+}
+
+// ExplainPolicy tells whether a role or scope may call a path and which rule decides it.
+func (p *policyHandler) ExplainPolicy(ctx *gin.Context) {
+	p.logger.Logger.Println("Handling EXPLAIN POLICY")
+	span := tracer.StartSpanFromRequest("ExplainPolicy", p.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var policyDto dto.PolicyDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&policyDto); err != nil {
+		p.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	policy := bluemonday.UGCPolicy()
+	policyDto.Transport = strings.TrimSpace(policy.Sanitize(policyDto.Transport))
+	policyDto.Subject = strings.TrimSpace(policy.Sanitize(policyDto.Subject))
+	policyDto.Object = strings.TrimSpace(policy.Sanitize(policyDto.Object))
+	policyDto.Action = strings.TrimSpace(policy.Sanitize(policyDto.Action))
+	if policyDto.Transport == "" {
+		policyDto.Transport = domain.PolicyTransportHttp
+	}
+
+	explanations, err := p.PolicyUsecase.Explain(ctx, policyDto.Transport, policyDto.Subject, policyDto.Object, policyDto.Action)
+	if err != nil {
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, mapper.MapPolicyExplanationsToPolicyExplanationListDto(policyDto.Transport, explanations))
+}
+
+// DiffPolicy lists the routes each subject would gain or lose with the policy in the body.
+func (p *policyHandler) DiffPolicy(ctx *gin.Context) {
+	p.logger.Logger.Println("Handling DIFF POLICY")
+	span := tracer.StartSpanFromRequest("DiffPolicy", p.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var diffDto dto.PolicyDiffRequestDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&diffDto); err != nil {
+		p.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	policy := bluemonday.UGCPolicy()
+	diffDto.Transport = strings.TrimSpace(policy.Sanitize(diffDto.Transport))
+	diffDto.Policy = policy.Sanitize(diffDto.Policy)
+	if diffDto.Transport == "" {
+		diffDto.Transport = domain.PolicyTransportHttp
+	}
+
+	changes, err := p.PolicyUsecase.Diff(ctx, diffDto.Transport, strings.NewReader(diffDto.Policy))
+	if err != nil {
+		p.logger.Logger.Errorf("error while diffing %v policy, error: %v\n", diffDto.Transport, err)
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, mapper.MapAccessChangesToPolicyDiffDto(diffDto.Transport, changes))
+}
+
+func (p *policyHandler) changePolicy(ctx *gin.Context, operation string, change func(context context.Context, actor, transport string, rule domain.Policy) error) {
+	span := tracer.StartSpanFromRequest(operation, p.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var policyDto dto.PolicyDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&policyDto); err != nil {
+		p.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	policy := bluemonday.UGCPolicy()
+	policyDto.Transport = strings.TrimSpace(policy.Sanitize(policyDto.Transport))
+	policyDto.Subject = strings.TrimSpace(policy.Sanitize(policyDto.Subject))
+	policyDto.Object = strings.TrimSpace(policy.Sanitize(policyDto.Object))
+	policyDto.Action = strings.TrimSpace(policy.Sanitize(policyDto.Action))
+	policyDto.Condition = strings.TrimSpace(policyDto.Condition)
+	if policyDto.Transport == "" {
+		policyDto.Transport = domain.PolicyTransportHttp
+	}
+
+	if err := change(ctx, auditActor(ctx), policyDto.Transport, mapper.MapPolicyDtoToPolicy(policyDto)); err != nil {
+		p.logger.Logger.Errorf("error while changing %v policy, error: %v\n", policyDto.Transport, err)
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "ok"})
+}
+
+func (p *policyHandler) changeInheritance(ctx *gin.Context, operation string, change func(context context.Context, actor, transport string, inheritance domain.RoleInheritance) error) {
+	span := tracer.StartSpanFromRequest(operation, p.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var inheritanceDto dto.RoleInheritanceDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&inheritanceDto); err != nil {
+		p.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	policy := bluemonday.UGCPolicy()
+	inheritanceDto.Transport = strings.TrimSpace(policy.Sanitize(inheritanceDto.Transport))
+	inheritanceDto.Role = strings.TrimSpace(policy.Sanitize(inheritanceDto.Role))
+	inheritanceDto.Parent = strings.TrimSpace(policy.Sanitize(inheritanceDto.Parent))
+	if inheritanceDto.Transport == "" {
+		inheritanceDto.Transport = domain.PolicyTransportHttp
+	}
+
+	if err := change(ctx, auditActor(ctx), inheritanceDto.Transport, mapper.MapRoleInheritanceDtoToRoleInheritance(inheritanceDto)); err != nil {
+		p.logger.Logger.Errorf("error while changing %v role inheritance, error: %v\n", inheritanceDto.Transport, err)
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "ok"})
+}
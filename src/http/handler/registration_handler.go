@@ -2,6 +2,7 @@ package handler
 
 import (
 	"auth-service/domain"
+	"auth-service/helper"
 	"auth-service/infrastructure/dto"
 	"auth-service/infrastructure/mapper"
 	"auth-service/infrastructure/saga"
@@ -13,11 +14,11 @@ import (
 	"github.com/microcosm-cc/bluemonday"
 	"net/http"
 	"strings"
-	"unicode"
 )
 
 type registrationHandler struct {
 	RegistrationUsecase usecase.RegistrationUsecase
+	AuditUsecase usecase.AuditUsecase
 	logger *logger.Logger
 	Orchestrator saga.Orchestrator
 }
@@ -33,8 +34,8 @@ type RegistrationHandler interface {
 	ConfirmAgentAccount(ctx *gin.Context)
 }
 
-func NewRegistrationHandler(registrationUsecase usecase.RegistrationUsecase, logger *logger.Logger, orchestrator saga.Orchestrator) RegistrationHandler {
-	return &registrationHandler{RegistrationUsecase: registrationUsecase, logger: logger, Orchestrator: orchestrator}
+func NewRegistrationHandler(registrationUsecase usecase.RegistrationUsecase, auditUsecase usecase.AuditUsecase, logger *logger.Logger, orchestrator saga.Orchestrator) RegistrationHandler {
+	return &registrationHandler{RegistrationUsecase: registrationUsecase, AuditUsecase: auditUsecase, logger: logger, Orchestrator: orchestrator}
 }
 
 func (r *registrationHandler) Register(ctx *gin.Context) {
@@ -53,7 +54,7 @@ func (r *registrationHandler) Register(ctx *gin.Context) {
 	user.Name = strings.TrimSpace(policy.Sanitize(user.Name))
 	user.Surname = strings.TrimSpace(policy.Sanitize(user.Surname))
 	user.Username = strings.TrimSpace(policy.Sanitize(user.Username))
-	user.Password = strings.TrimSpace(policy.Sanitize(user.Password))
+	user.Password = strings.TrimSpace(user.Password)
 	user.Email = strings.TrimSpace(policy.Sanitize(user.Email))
 	user.Address = strings.TrimSpace(policy.Sanitize(user.Address))
 	user.Phone = strings.TrimSpace(policy.Sanitize(user.Phone))
@@ -67,7 +68,7 @@ func (r *registrationHandler) Register(ctx *gin.Context) {
 	if user.Name == "" || user.Surname == "" || user.Email == "" || user.Address == "" || user.Phone == "" || user.Birthday  == "" ||
 		user.Gender == "" || user.Web == "" || user.Bio  == "" || user.Username == "" || user.Password == ""{
 		r.logger.Logger.Errorf("error while verifying and validating registration fields\n")
-		r.logger.Logger.Warnf("possible xss attack from IP address: %v\n", ctx.Request.Host)
+		r.logger.Logger.Warnf("possible xss attack from IP address: %v\n", ctx.GetString(helper.ClientIpKey))
 		ctx.JSON(400, gin.H{"message" : "Fields are empty or xss attack happened"})
 		return
 	}
@@ -84,13 +85,6 @@ func (r *registrationHandler) Register(ctx *gin.Context) {
 		return
 	}
 
-	if pasval1, pasval2, pasval3, pasval4 := verifyPassword(user.Password); pasval1 == false || pasval2 == false || pasval3 == false || pasval4 == false {
-		r.logger.Logger.Errorf("error while veryfing password, error: password not matching pattern")
-		ctx.JSON(400, gin.H{"message" : "Password must have minimum 1 uppercase letter, 1 lowercase letter, 1 digit and 1 special character and needs to be minimum 8 characters long"})
-		return
-	}
-
-
 	if user.Birthday == "" {
 		r.logger.Logger.Errorf("error while registrating user, error: no birthday")
 		ctx.JSON(400, gin.H{"message" : "Enter birthday!"})
@@ -111,7 +105,11 @@ func (r *registrationHandler) Register(ctx *gin.Context) {
 	}
 	if err := r.RegistrationUsecase.Register(ctx, user); err != nil {
 		r.logger.Logger.Errorf("error while registrating user, error: %v\n", err)
-		ctx.JSON(402, gin.H{"message" : err.Error()})
+		if passwordPolicyViolated(ctx, err) {
+			return
+		}
+		errorResponse(ctx, err)
+		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message" : "Please check your email to confirm registration"})
@@ -135,13 +133,16 @@ func (r *registrationHandler) ConfirmAccount(ctx *gin.Context) {
 
 	if dto.Code == "" || dto.Email == ""{
 		r.logger.Logger.Errorf("error while verifying and validating registration fields\n")
-		r.logger.Logger.Warnf("possible xss attack from IP address: %v\n", ctx.Request.Host)
+		r.logger.Logger.Warnf("possible xss attack from IP address: %v\n", ctx.GetString(helper.ClientIpKey))
 		ctx.JSON(400, gin.H{"message" : "Field are empty or xss attack happened"})
 		return
 	}
 
 	if err := r.RegistrationUsecase.ConfirmAccount(ctx, dto.Code, dto.Email); err != nil {
-		ctx.JSON(400, gin.H{"message" : err.Error()})
+		if tooManyAttempts(ctx, err) {
+			return
+		}
+		errorResponse(ctx, err)
 		return
 	}
 
@@ -167,51 +168,24 @@ func (r *registrationHandler) ResendCode(ctx *gin.Context) {
 	email := strings.TrimSpace(policy.Sanitize(req.Email))
 	if err != nil {
 		r.logger.Logger.Errorf("error while verifying and validating registration fields, error: %v\n", err)
-		r.logger.Logger.Warnf("possible xss attack from IP address: %v\n", ctx.Request.Host)
+		r.logger.Logger.Warnf("possible xss attack from IP address: %v\n", ctx.GetString(helper.ClientIpKey))
 		ctx.JSON(400, gin.H{"message" : "Field are empty or xss attack happened"})
 		return
 	}
 
-	err, email, code := r.RegistrationUsecase.ResendCode(ctx, email)
-
-	if err != nil {
+	if err := r.RegistrationUsecase.ResendCode(ctx, email); err != nil {
+		r.logger.Logger.Errorf("error while resending code to %v, error: %v\n", email, err)
 		ctx.JSON(400, gin.H{"message" : "Invalid email"})
 		return
 	}
 
 
-	go usecase.SendMail(email, email, code)
-
-
 	ctx.JSON(200, gin.H{"message" : "Resend request successful, please check your email"})
 	return
 
 }
 
 
-func verifyPassword(s string) (eightOrMore, number, upper, special bool)  {
-	letters := 0
-	for _, c := range s {
-		switch {
-		case unicode.IsNumber(c):
-			number = true
-			letters++
-		case unicode.IsUpper(c):
-			upper = true
-			letters++
-		case unicode.IsPunct(c) || unicode.IsSymbol(c):
-			special = true
-			letters++
-		case unicode.IsLetter(c) || c == ' ':
-			letters++
-		default:
-			return false, false, false, false
-		}
-	}
-	eightOrMore = letters >= 8
-	return
-}
-
 func (r *registrationHandler) RegisterAgent(ctx *gin.Context) {
 	r.logger.Logger.Println("Handling REGISTER USER")
 	decoder := json.NewDecoder(ctx.Request.Body)
@@ -228,7 +202,7 @@ func (r *registrationHandler) RegisterAgent(ctx *gin.Context) {
 	user.Name = strings.TrimSpace(policy.Sanitize(user.Name))
 	user.Surname = strings.TrimSpace(policy.Sanitize(user.Surname))
 	user.Username = strings.TrimSpace(policy.Sanitize(user.Username))
-	user.Password = strings.TrimSpace(policy.Sanitize(user.Password))
+	user.Password = strings.TrimSpace(user.Password)
 	user.Email = strings.TrimSpace(policy.Sanitize(user.Email))
 	user.Address = strings.TrimSpace(policy.Sanitize(user.Address))
 	user.Phone = strings.TrimSpace(policy.Sanitize(user.Phone))
@@ -242,7 +216,7 @@ func (r *registrationHandler) RegisterAgent(ctx *gin.Context) {
 	if user.Name == "" || user.Surname == "" || user.Email == "" || user.Address == "" || user.Phone == "" || user.Birthday  == "" ||
 		user.Gender == "" || user.Web == "" || user.Bio  == "" || user.Username == "" || user.Password == ""{
 		r.logger.Logger.Errorf("error while verifying and validating registration fields\n")
-		r.logger.Logger.Warnf("possible xss attack from IP address: %v\n", ctx.Request.Host)
+		r.logger.Logger.Warnf("possible xss attack from IP address: %v\n", ctx.GetString(helper.ClientIpKey))
 		ctx.JSON(400, gin.H{"message" : "Fields are empty or xss attack happened"})
 		return
 	}
@@ -259,13 +233,6 @@ func (r *registrationHandler) RegisterAgent(ctx *gin.Context) {
 		return
 	}
 
-	if pasval1, pasval2, pasval3, pasval4 := verifyPassword(user.Password); pasval1 == false || pasval2 == false || pasval3 == false || pasval4 == false {
-		r.logger.Logger.Errorf("error while veryfing password, error: password not matching pattern")
-		ctx.JSON(400, gin.H{"message" : "Password must have minimum 1 uppercase letter, 1 lowercase letter, 1 digit and 1 special character and needs to be minimum 8 characters long"})
-		return
-	}
-
-
 	if user.Birthday == "" {
 		r.logger.Logger.Errorf("error while registrating user, error: no birthday")
 		ctx.JSON(400, gin.H{"message" : "Enter birthday!"})
@@ -287,7 +254,11 @@ func (r *registrationHandler) RegisterAgent(ctx *gin.Context) {
 
 	if err := r.RegistrationUsecase.RegisterAgent(ctx, user); err != nil {
 		r.logger.Logger.Errorf("error while registrating user, error: %v\n", err)
-		ctx.JSON(402, gin.H{"message" : err.Error()})
+		if passwordPolicyViolated(ctx, err) {
+			return
+		}
+		errorResponse(ctx, err)
+		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message" : "Please check your email to confirm registration"})
@@ -314,7 +285,10 @@ func (r *registrationHandler) ValidateAgentAccount(ctx *gin.Context) {
 	}
 
 	if err := r.RegistrationUsecase.ValidateAgentAccount(ctx, dto.Code, dto.Email); err != nil {
-		ctx.JSON(400, gin.H{"message" : err.Error()})
+		if tooManyAttempts(ctx, err) {
+			return
+		}
+		errorResponse(ctx, err)
 		return
 	}
 
@@ -348,6 +322,11 @@ func (r *registrationHandler) ConfirmAgentAccount(ctx *gin.Context) {
 
 
 	user, err := r.RegistrationUsecase.ConfirmAgentAccount(ctx, dto.Email, dto.Confirm)
+	action := domain.AuditAgentApproved
+	if !dto.Confirm {
+		action = domain.AuditAgentDeclined
+	}
+	r.AuditUsecase.Record(ctx, auditActor(ctx), dto.Email, action, err)
 	if err != nil {
 		ctx.JSON(400, gin.H{"message" : "Error confirming account"})
 		return
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/mapper"
+	"auth-service/infrastructure/tracer"
+	"auth-service/usecase"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/opentracing/opentracing-go"
+	"strings"
+)
+
+type roleHandler struct {
+	RoleUsecase usecase.RoleUsecase
+	Tracer      opentracing.Tracer
+	logger      *logger.Logger
+}
+
+type RoleHandler interface {
+	GrantRole(ctx *gin.Context)
+	RevokeRole(ctx *gin.Context)
+}
+
+func NewRoleHandler(roleUsecase usecase.RoleUsecase, tracer opentracing.Tracer, logger *logger.Logger) RoleHandler {
+	return &roleHandler{RoleUsecase: roleUsecase, Tracer: tracer, logger: logger}
+}
+
+func (r *roleHandler) GrantRole(ctx *gin.Context) {
+	r.logger.Logger.Println("Handling GRANT ROLE")
+	r.changeRole(ctx, "GrantRole", r.RoleUsecase.Grant)
+}
+
+func (r *roleHandler) RevokeRole(ctx *gin.Context) {
+	r.logger.Logger.Println("Handling REVOKE ROLE")
+	r.changeRole(ctx, "RevokeRole", r.RoleUsecase.Revoke)
+}
+
+func (r *roleHandler) changeRole(ctx *gin.Context, operation string, change func(context context.Context, actor, userId, roleName string) (*domain.ProfileInfo, error)) {
+	span := tracer.StartSpanFromRequest(operation, r.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var request dto.RoleRequestDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&request); err != nil {
+		r.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	policy := bluemonday.UGCPolicy()
+	request.UserId = strings.TrimSpace(policy.Sanitize(request.UserId))
+	request.Role = strings.TrimSpace(policy.Sanitize(request.Role))
+
+	account, err := change(ctx, auditActor(ctx), request.UserId, request.Role)
+	if err != nil {
+		r.logger.Logger.Errorf("error while changing roles of account %v, error: %v\n", request.UserId, err)
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, mapper.MapProfileInfoToUserRolesDto(*account))
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/mapper"
+	"auth-service/infrastructure/tracer"
+	"auth-service/usecase"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/opentracing/opentracing-go"
+	"strings"
+)
+
+type scopeHandler struct {
+	ScopeUsecase usecase.ScopeUsecase
+	Tracer       opentracing.Tracer
+	logger       *logger.Logger
+}
+
+type ScopeHandler interface {
+	GetScopes(ctx *gin.Context)
+	AddRoleScope(ctx *gin.Context)
+	RemoveRoleScope(ctx *gin.Context)
+}
+
+func NewScopeHandler(scopeUsecase usecase.ScopeUsecase, tracer opentracing.Tracer, logger *logger.Logger) ScopeHandler {
+	return &scopeHandler{ScopeUsecase: scopeUsecase, Tracer: tracer, logger: logger}
+}
+
+// GetScopes lists every scope and the scopes granted to each role.
+func (s *scopeHandler) GetScopes(ctx *gin.Context) {
+	s.logger.Logger.Println("Handling GET SCOPES")
+	span := tracer.StartSpanFromRequest("GetScopes", s.Tracer, ctx.Request)
+	defer span.Finish()
+
+	scopes, roles, err := s.ScopeUsecase.List(ctx)
+	if err != nil {
+		tracer.LogError(span, err)
+		ctx.JSON(500, gin.H{"message": server_err})
+		return
+	}
+
+	ctx.JSON(200, mapper.MapScopesToScopeListDto(scopes, roles))
+}
+
+func (s *scopeHandler) AddRoleScope(ctx *gin.Context) {
+	s.logger.Logger.Println("Handling ADD ROLE SCOPE")
+	s.changeRoleScope(ctx, "AddRoleScope", s.ScopeUsecase.AddToRole)
+}
+
+func (s *scopeHandler) RemoveRoleScope(ctx *gin.Context) {
+	s.logger.Logger.Println("Handling REMOVE ROLE SCOPE")
+	s.changeRoleScope(ctx, "RemoveRoleScope", s.ScopeUsecase.RemoveFromRole)
+}
+
+func (s *scopeHandler) changeRoleScope(ctx *gin.Context, operation string, change func(context context.Context, actor, roleName, scopeName string) (*domain.Role, error)) {
+	span := tracer.StartSpanFromRequest(operation, s.Tracer, ctx.Request)
+	defer span.Finish()
+
+	var request dto.RoleScopeRequestDto
+	decoder := json.NewDecoder(ctx.Request.Body)
+	if err := decoder.Decode(&request); err != nil {
+		s.logger.Logger.Errorf("error while decoding json, error: %v\n", err)
+		ctx.JSON(400, gin.H{"message": body_decoding_err})
+		return
+	}
+
+	policy := bluemonday.UGCPolicy()
+	request.Role = strings.TrimSpace(policy.Sanitize(request.Role))
+	request.Scope = strings.TrimSpace(policy.Sanitize(request.Scope))
+
+	role, err := change(ctx, auditActor(ctx), request.Role, request.Scope)
+	if err != nil {
+		s.logger.Logger.Errorf("error while changing scopes of role %v, error: %v\n", request.Role, err)
+		tracer.LogError(span, err)
+		errorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(200, mapper.MapRoleToRoleScopesDto(*role))
+}
@@ -1,13 +1,17 @@
 package middleware
 
 import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"auth-service/infrastructure/policy"
 	"auth-service/infrastructure/tracer"
+	"auth-service/usecase"
 	"context"
 	"fmt"
-	"github.com/casbin/casbin/v2"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
 	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/twinj/uuid"
 	"net/http"
 	"os"
 	"strings"
@@ -18,7 +22,7 @@ func CORSMiddleware() gin.HandlerFunc {
 
 		c.Header("Access-Control-Allow-Origin", "http://localhost:8080")
 		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Request-Id")
 		c.Header("Access-Control-Allow-Methods", "POST,HEAD,PATCH, OPTIONS, GET, PUT")
 
 		if c.Request.Method == "OPTIONS" {
@@ -29,26 +33,61 @@ func CORSMiddleware() gin.HandlerFunc {
 	}
 }
 
-func AuthMiddleware(logger *logger.Logger) gin.HandlerFunc {
+// LocaleMiddleware stores the request's Accept-Language header for helper.LocaleFromContext.
+func LocaleMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if locale := c.GetHeader("Accept-Language"); locale != "" {
+			c.Set(helper.LocaleKey, locale)
+		}
+		c.Next()
+	}
+}
+
+// RequestIdMiddleware keeps the caller's X-Request-Id or assigns a new one.
+func RequestIdMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestId := c.GetHeader("X-Request-Id")
+		if requestId == "" || len(requestId) > 128 {
+			requestId = uuid.NewV4().String()
+		}
+
+		c.Set(helper.RequestIdKey, requestId)
+		c.Header("X-Request-Id", requestId)
+		c.Next()
+	}
+}
+
+// ClientInfoMiddleware stores the client address, User-Agent and X-Client-Id for usecases.
+func ClientInfoMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(helper.ClientIpKey, helper.ClientIp(c.Request.RemoteAddr, c.Request.Header.Values("X-Forwarded-For")))
+		c.Set(helper.UserAgentKey, c.GetHeader("User-Agent"))
+		c.Set(helper.ClientIdKey, c.GetHeader("X-Client-Id"))
+		c.Next()
+	}
+}
+
+func AuthMiddleware(authenticationUsecase usecase.AuthenticationUsecase, enforcer policy.Enforcer, logger *logger.Logger) gin.HandlerFunc {
 	return func (c *gin.Context) {
-		role, err := ExtractUserRole(context.Background(), c.Request, logger)
+		subjects, err := ExtractSubjects(context.Background(), c.Request, logger)
 		if err != nil {
-			logger.Logger.Warnf("unauthorized request from IP address: %v", c.Request.Host)
+			logger.Logger.Warnf("unauthorized request from IP address: %v", c.GetString(helper.ClientIpKey))
 			c.JSON(401, gin.H{"message" : "Unauthorized"})
 			c.Abort()
 			return
 		}
 
-		if role == "" {
-			logger.Logger.Warnf("unauthorized request from IP address: %v", c.Request.Host)
+		if len(subjects) == 0 {
+			logger.Logger.Warnf("unauthorized request from IP address: %v", c.GetString(helper.ClientIpKey))
 			c.JSON(401, gin.H{"message" : "Unauthorized"})
 			c.Abort()
 			return
 		}
 
-		ok, err := enforce(role, c.Request.URL.Path, c.Request.Method, logger)
+		ok, err := policy.EnforceAny(enforcer, subjects, c.Request.URL.Path, c.Request.Method)
 
 		if err != nil {
+			logger.Logger.Errorf("error while enforcing policy, error: %v", err)
 			c.JSON(500, gin.H{"message" : "error occurred when authorizing user"})
 			c.Abort()
 			return
@@ -60,42 +99,50 @@ func AuthMiddleware(logger *logger.Logger) gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+
+		if actorId, _ := ExtractActorId(context.Background(), c.Request); actorId != "" {
+			if !authorizeImpersonation(c, authenticationUsecase, enforcer, actorId, logger) {
+				c.Abort()
+				return
+			}
+		}
 		c.Next()
 	}
 }
 
-
-func enforce(role string, obj string, act string, logger *logger.Logger) (bool, error) {
-	m, _ := os.Getwd()
-	fmt.Println(m)
-	fmt.Println(role)
-
-	if !strings.HasSuffix(m, "src")  {
-		splits := strings.Split(m, "src")
-		wd := splits[0] + "/src"
-		fmt.Println(wd)
-		os.Chdir(wd)
+// authorizeImpersonation checks that the token has not been ended and the route allows it.
+func authorizeImpersonation(c *gin.Context, authenticationUsecase usecase.AuthenticationUsecase, enforcer policy.Enforcer, actorId string, logger *logger.Logger) bool {
+	accessUuid, _ := ExtractAccessUuid(context.Background(), c.Request)
+	if _, err := authenticationUsecase.FetchAuthToken(c, accessUuid); accessUuid == "" || err != nil {
+		logger.Logger.Warnf("ended impersonation token of %v used from IP address: %v", actorId, c.GetString(helper.ClientIpKey))
+		c.JSON(401, gin.H{"message" : "Unauthorized"})
+		return false
 	}
-	enforcer, err := casbin.NewEnforcer("http/middleware/rbac_model.conf", "http/middleware/rbac_policy.csv")
+
+	ok, err := enforcer.Enforce(domain.ImpersonationSubject, c.Request.URL.Path, c.Request.Method)
 	if err != nil {
-		logger.Logger.Errorf("failed to load policy from file: %v", err)
-		return false, fmt.Errorf("failed to load policy from DB: %w", err)
+		logger.Logger.Errorf("error while enforcing policy, error: %v", err)
+		c.JSON(500, gin.H{"message" : "error occurred when authorizing user"})
+		return false
 	}
-	err = enforcer.LoadPolicy()
-	if err != nil {
-		logger.Logger.Errorf("failed to load policy from file: %v", err)
-		return false, fmt.Errorf("failed to load policy from DB: %w", err)
+
+	if !ok {
+		logger.Logger.Warnf("impersonation by %v blocked on %v %v", actorId, c.Request.Method, c.Request.URL.Path)
+		c.JSON(403, gin.H{"message" : "forbidden while impersonating"})
+		return false
 	}
-	ok, _ := enforcer.Enforce(role, obj, act)
-	return ok, nil
+
+	logger.Logger.Infof("impersonated request by %v: %v %v", actorId, c.Request.Method, c.Request.URL.Path)
+	return true
 }
 
+
 func GetTokenId(ctx context.Context, request *http.Request) *string {
 	span := tracer.StartSpanFromContext(ctx, "middleware/GetTokenId")
 	defer span.Finish()
 	authHeader := request.Header.Get("authorization")
 	if authHeader == "" {
-		tracer.LogError(span, fmt.Errorf("", "Cookie header does not exist"))
+		tracer.LogError(span, fmt.Errorf("message= %s", "Cookie header does not exist"))
 		return nil
 	}
 
@@ -150,7 +197,7 @@ func ExtractUserIdFromCookie(ctx context.Context, r *http.Request) (string, erro
 
 	if tokenString == "" {
 		tracer.LogError(span, fmt.Errorf("message= %s", "Authorization header does noe exist"))
-		return "", fmt.Errorf("", "message= %s", "Token does not exist")
+		return "", fmt.Errorf("message= %s", "Token does not exist")
 	}
 
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -179,7 +226,7 @@ func ExtractUserId(ctx context.Context, r *http.Request) (string, error) {
 
 	if tokenString == "" {
 		tracer.LogError(span, fmt.Errorf("message= %s", "Authorization header does noe exist"))
-		return "", fmt.Errorf("", "message= %s", "Authorization header does not exist")
+		return "", fmt.Errorf("message= %s", "Authorization header does not exist")
 	}
 
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -209,7 +256,7 @@ func ExtractTokenUuid(ctx context.Context, r *http.Request) (string, error) {
 
 	if tokenString == "" {
 		tracer.LogError(span, fmt.Errorf("message= %s", "Authorization header does noe exist"))
-		return "", fmt.Errorf("", "message= %s", "Authorization header does noe exist")
+		return "", fmt.Errorf("message= %s", "Authorization header does noe exist")
 	}
 
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -228,6 +275,35 @@ func ExtractTokenUuid(ctx context.Context, r *http.Request) (string, error) {
 	}
 	return "", err
 }
+func ExtractAccessUuid(ctx context.Context, r *http.Request) (string, error) {
+	span := tracer.StartSpanFromContext(ctx, "middleware/ExtractAccessUuid")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(ctx, span)
+
+	tokenString := ExtractToken(ctx1, r)
+
+	if tokenString == "" {
+		tracer.LogError(span, fmt.Errorf("message= %s", "Authorization header does not exist"))
+		return "", fmt.Errorf("message= %s", "Authorization header does not exist")
+	}
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("ACCESS_SECRET")), nil
+	})
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+
+	if ok  {
+		accessUuid, ok := claims["access_uuid"].(string)
+		if !ok {
+			return "", err
+		}
+
+		return accessUuid, nil
+	}
+	return "", err
+}
 func ExtractUserRole(ctx context.Context, r *http.Request, logger *logger.Logger) (string, error) {
 	span := tracer.StartSpanFromContext(ctx, "middleware/ExtractUserRole")
 	defer span.Finish()
@@ -256,4 +332,55 @@ func ExtractUserRole(ctx context.Context, r *http.Request, logger *logger.Logger
 	return  "ANONYMOUS", err
 }
 
+// ExtractSubjects returns the roles and scopes of the request's token, or ANONYMOUS.
+func ExtractSubjects(ctx context.Context, r *http.Request, logger *logger.Logger) ([]string, error) {
+	span := tracer.StartSpanFromContext(ctx, "middleware/ExtractSubjects")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(ctx, span)
+
+	tokenString := ExtractToken(ctx1, r)
+	if tokenString == "" {
+		return []string{"ANONYMOUS"}, nil
+	}
 
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("ACCESS_SECRET")), nil
+	})
+
+	if err != nil {
+		return []string{"ANONYMOUS"}, err
+	}
+
+	if claims, ok := token.Claims.(jwt.MapClaims); ok {
+		if subjects := helper.SubjectsFromClaims(claims); len(subjects) > 0 {
+			return subjects, nil
+		}
+	}
+	return []string{"ANONYMOUS"}, nil
+}
+
+// ExtractActorId returns the admin impersonating the token's user, if any.
+func ExtractActorId(ctx context.Context, r *http.Request) (string, error) {
+	span := tracer.StartSpanFromContext(ctx, "middleware/ExtractActorId")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(ctx, span)
+
+	tokenString := ExtractToken(ctx1, r)
+	if tokenString == "" {
+		return "", nil
+	}
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("ACCESS_SECRET")), nil
+	})
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+
+	if ok  {
+		actorId, _ := claims[domain.ImpersonationClaim].(string)
+		return actorId, nil
+	}
+	return "", err
+}
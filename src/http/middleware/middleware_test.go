@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"auth-service/helper"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestClientInfoMiddleware(t *testing.T) {
+	if err := helper.SetTrustedProxies([]string{"10.0.0.1", "172.16.0.0/12"}); err != nil {
+		t.Fatal(err)
+	}
+	defer helper.SetTrustedProxies(nil)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		{name: "direct client", remoteAddr: "203.0.113.7:5000", want: "203.0.113.7"},
+		{name: "client behind a trusted proxy", remoteAddr: "10.0.0.1:5000", forwarded: []string{"203.0.113.7"}, want: "203.0.113.7"},
+		{name: "client behind a chain of trusted proxies", remoteAddr: "10.0.0.1:5000", forwarded: []string{"203.0.113.7, 172.16.0.5"}, want: "203.0.113.7"},
+		{name: "forged hops before the proxy are ignored", remoteAddr: "10.0.0.1:5000", forwarded: []string{"198.51.100.1, 203.0.113.7"}, want: "203.0.113.7"},
+		{name: "several headers", remoteAddr: "10.0.0.1:5000", forwarded: []string{"198.51.100.1", "203.0.113.7"}, want: "203.0.113.7"},
+		{name: "untrusted client can't forge its address", remoteAddr: "203.0.113.7:5000", forwarded: []string{"198.51.100.1"}, want: "203.0.113.7"},
+		{name: "hop that is not an address", remoteAddr: "10.0.0.1:5000", forwarded: []string{"unknown"}, want: "10.0.0.1"},
+		{name: "ipv6 client", remoteAddr: "[2001:db8::1]:5000", want: "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/login", nil)
+			c.Request.RemoteAddr = tt.remoteAddr
+			for _, forwarded := range tt.forwarded {
+				c.Request.Header.Add("X-Forwarded-For", forwarded)
+			}
+
+			ClientInfoMiddleware()(c)
+
+			if got, _ := helper.ClientInfoFromContext(c); got != tt.want {
+				t.Errorf("client ip = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"auth-service/usecase"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+const maxRateLimitBody = 1 << 20
+
+// RateLimitMiddleware applies the rate limit rules of the matched route.
+func RateLimitMiddleware(rateLimitUsecase usecase.RateLimitUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rules := rateLimitUsecase.Rules(c.FullPath(), c.Request.Method)
+		if len(rules) == 0 {
+			c.Next()
+			return
+		}
+
+		for _, rule := range rules {
+			err := rateLimitUsecase.Allow(c, rule, rateLimitKey(c, rule.Key))
+
+			var limitErr *usecase.RateLimitExceededError
+			if errors.As(err, &limitErr) {
+				c.Header("Retry-After", strconv.Itoa(int(limitErr.RetryAfter.Seconds())+1))
+				c.JSON(429, gin.H{"message": limitErr.Error()})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+func rateLimitKey(c *gin.Context, key string) string {
+	switch key {
+	case domain.RateLimitByIp:
+		return c.GetString(helper.ClientIpKey)
+	case domain.RateLimitByUser:
+		userId, _ := ExtractUserId(c, c.Request)
+		return userId
+	case domain.RateLimitByEmail:
+		return requestEmail(c)
+	}
+
+	return ""
+}
+
+func requestEmail(c *gin.Context) string {
+	if c.Request.Body == nil {
+		return ""
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(c.Request.Body, maxRateLimitBody))
+	c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	var request struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(request.Email)
+}
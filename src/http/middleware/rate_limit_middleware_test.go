@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"auth-service/domain"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRateLimitKey(t *testing.T) {
+	os.Setenv("ACCESS_SECRET", "secret")
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"user_id": "user-1"}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		key           string
+		body          string
+		authorization string
+		want          string
+	}{
+		{name: "client ip", key: domain.RateLimitByIp, want: "10.0.0.1"},
+		{name: "user of the token", key: domain.RateLimitByUser, authorization: "Bearer " + token, want: "user-1"},
+		{name: "anonymous user", key: domain.RateLimitByUser},
+		{name: "email of the body", key: domain.RateLimitByEmail, body: `{"email": " user@example.com ", "password": "x"}`, want: "user@example.com"},
+		{name: "body without email", key: domain.RateLimitByEmail, body: `{"password": "x"}`},
+		{name: "body that is not json", key: domain.RateLimitByEmail, body: "email=user@example.com"},
+		{name: "no body", key: domain.RateLimitByEmail},
+		{name: "unknown key", key: "header", body: `{"email": "user@example.com"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(tt.body))
+			c.Request.RemoteAddr = "10.0.0.1:5000"
+			if tt.body == "" {
+				c.Request.Body = nil
+			}
+			if tt.authorization != "" {
+				c.Request.Header.Set("Authorization", tt.authorization)
+			}
+			ClientInfoMiddleware()(c)
+
+			if got := rateLimitKey(c, tt.key); got != tt.want {
+				t.Errorf("key = %q, want %q", got, tt.want)
+			}
+
+			if c.Request.Body != nil {
+				body, _ := ioutil.ReadAll(c.Request.Body)
+				if string(body) != tt.body {
+					t.Errorf("body left for the handler = %q, want %q", body, tt.body)
+				}
+			}
+		})
+	}
+}
@@ -3,23 +3,30 @@ package router
 import (
 	"auth-service/http/middleware"
 	"auth-service/http/middleware/prometheus_middleware"
+	"auth-service/infrastructure/policy"
 	"auth-service/interactor"
+	"auth-service/usecase"
 	logger "github.com/jelena-vlajkov/logger/logger"
 
 	"github.com/gin-gonic/gin"
 )
 
-func NewRouter(handler interactor.AppHandler, logger *logger.Logger) *gin.Engine {
+func NewRouter(handler interactor.AppHandler, authenticationUsecase usecase.AuthenticationUsecase, enforcer policy.Enforcer, rateLimitUsecase usecase.RateLimitUsecase, logger *logger.Logger) *gin.Engine {
 	router := gin.Default()
 	counterReq := prometheus_middleware.GetHttpRequestsCounter()
 	router.Use(prometheus_middleware.PrometheusMiddleware(counterReq))
 	router.GET("/metrics", prometheus_middleware.PrometheusGinHandler())
-	router.Use(middleware.AuthMiddleware(logger))
+	router.Use(middleware.RequestIdMiddleware())
+	router.Use(middleware.LocaleMiddleware())
+	router.Use(middleware.ClientInfoMiddleware())
+	router.Use(middleware.AuthMiddleware(authenticationUsecase, enforcer, logger))
+	router.Use(middleware.RateLimitMiddleware(rateLimitUsecase))
 
 
 	router.POST("/validateToken", handler.ValidateToken)
 	router.POST("/login", handler.Login)
 	router.POST("/logout", handler.Logout)
+	router.POST("/logoutAll", handler.LogoutAll)
 	router.POST("/register", handler.Register)
 	router.POST("/confirmAccount", handler.ConfirmAccount)
 	router.GET("/generateSecret", handler.GenerateSecret)
@@ -31,7 +38,17 @@ func NewRouter(handler interactor.AppHandler, logger *logger.Logger) *gin.Engine
 	router.POST("/resendRegistrationCode", handler.ResendCode)
 	router.POST("/resetPasswordMail", handler.SendResetMail)
 	router.POST("/resetPassword", handler.ResetPassword)
+	router.POST("/changePassword", handler.ChangePassword)
+	router.POST("/magicLink", handler.SendMagicLink)
+	router.POST("/magicLink/login", handler.MagicLogin)
+	router.POST("/changeEmail", handler.RequestEmailChange)
+	router.POST("/changeEmail/confirm", handler.ConfirmEmailChange)
+	router.POST("/changeEmail/cancel", handler.CancelEmailChange)
+	router.POST("/lockAccount", handler.LockAccount)
 	router.POST("refreshToken", handler.RefreshToken)
+	router.POST("/oauth/token", handler.IssueClientToken)
+	router.POST("/authorize", handler.Authorize)
+	router.POST("/authorize/batch", handler.AuthorizeBatch)
 
 	router.POST("/agent", handler.RegisterAgent)
 	router.POST("/agent/validate", handler.ValidateAgentAccount)
@@ -39,6 +56,33 @@ func NewRouter(handler interactor.AppHandler, logger *logger.Logger) *gin.Engine
 	router.POST("/confirmAgentAccount", handler.ConfirmAgentAccount)
 	router.POST("/deleteProfileInfo", handler.DeleteProfileInfo)
 
+	router.GET("/activity", handler.GetActivity)
+	router.GET("/admin/activity", handler.SearchActivity)
+	router.GET("/admin/audit/export", handler.ExportAuditLog)
+	router.POST("/admin/account/suspend", handler.SuspendAccount)
+	router.POST("/admin/account/ban", handler.BanAccount)
+	router.POST("/admin/account/unlock", handler.UnlockAccount)
+	router.POST("/admin/impersonate", handler.StartImpersonation)
+	router.POST("/admin/impersonate/end", handler.EndImpersonation)
+	router.POST("/admin/roles/grant", handler.GrantRole)
+	router.POST("/admin/roles/revoke", handler.RevokeRole)
+	router.GET("/admin/scopes", handler.GetScopes)
+	router.POST("/admin/scopes/role", handler.AddRoleScope)
+	router.POST("/admin/scopes/role/remove", handler.RemoveRoleScope)
+	router.GET("/admin/clients", handler.GetOAuthClients)
+	router.POST("/admin/clients", handler.CreateOAuthClient)
+	router.POST("/admin/clients/remove", handler.DeleteOAuthClient)
+	router.POST("/admin/policy/reload", handler.ReloadPolicy)
+	router.GET("/admin/policies", handler.GetPolicies)
+	router.POST("/admin/policies", handler.AddPolicy)
+	router.POST("/admin/policies/remove", handler.RemovePolicy)
+	router.POST("/admin/policies/explain", handler.ExplainPolicy)
+	router.POST("/admin/policies/diff", handler.DiffPolicy)
+	router.POST("/admin/policies/inheritance", handler.AddRoleInheritance)
+	router.POST("/admin/policies/inheritance/remove", handler.RemoveRoleInheritance)
+	router.GET("/admin/outbox", handler.GetOutboxMessages)
+	router.POST("/admin/outbox/:id/redrive", handler.RedriveOutboxMessage)
+
 
 	return router
 }
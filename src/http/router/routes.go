@@ -0,0 +1,16 @@
+package router
+
+import (
+	"auth-service/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// Routes returns the routes registered on the router for policy checks.
+func Routes(router *gin.Engine) []domain.Route {
+	routes := make([]domain.Route, 0)
+	for _, route := range router.Routes() {
+		routes = append(routes, domain.Route{Object: route.Path, Action: route.Method})
+	}
+
+	return routes
+}
@@ -0,0 +1,34 @@
+package brute_force
+
+import (
+	"auth-service/domain"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/spf13/viper"
+	"os"
+	"time"
+)
+
+func init_viper(logger *logger.Logger) {
+	if os.Getenv("DOCKER_ENV") != "" {
+		viper.SetConfigFile(`src/configurations/brute_force.json`)
+	} else {
+		viper.SetConfigFile(`configurations/brute_force.json`)
+	}
+	err := viper.ReadInConfig()
+	if err != nil {
+		logger.Logger.Fatalf("error while reading brute force config file, error: %v\n", err)
+	}
+}
+
+func NewBruteForceConfig(logger *logger.Logger) domain.BruteForceConfig {
+	init_viper(logger)
+
+	return domain.BruteForceConfig{
+		MaxAttempts:   viper.GetInt(`brute_force.max_attempts`),
+		MaxIpAttempts: viper.GetInt(`brute_force.max_ip_attempts`),
+		Window:        time.Duration(viper.GetInt(`brute_force.window_minutes`)) * time.Minute,
+		BaseDelay:     time.Duration(viper.GetInt(`brute_force.base_delay_seconds`)) * time.Second,
+		MaxDelay:      time.Duration(viper.GetInt(`brute_force.max_delay_seconds`)) * time.Second,
+		Lockout:       time.Duration(viper.GetInt(`brute_force.lockout_minutes`)) * time.Minute,
+	}
+}
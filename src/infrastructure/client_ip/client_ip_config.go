@@ -0,0 +1,26 @@
+package client_ip
+
+import (
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/spf13/viper"
+	"os"
+)
+
+func init_viper(logger *logger.Logger) {
+	if os.Getenv("DOCKER_ENV") != "" {
+		viper.SetConfigFile(`src/configurations/client_ip.json`)
+	} else {
+		viper.SetConfigFile(`configurations/client_ip.json`)
+	}
+	err := viper.ReadInConfig()
+	if err != nil {
+		logger.Logger.Fatalf("error while reading client ip config file, error: %v\n", err)
+	}
+}
+
+// NewTrustedProxies returns the proxies trusted to set X-Forwarded-For.
+func NewTrustedProxies(logger *logger.Logger) []string {
+	init_viper(logger)
+
+	return viper.GetStringSlice(`client_ip.trusted_proxies`)
+}
@@ -0,0 +1,5 @@
+package dto
+
+type AccountLockDto struct {
+	Token string `json:"token"`
+}
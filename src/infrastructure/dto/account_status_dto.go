@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type AccountStatusRequestDto struct {
+	UserId string    `json:"user_id"`
+	Reason string    `json:"reason"`
+	Until  time.Time `json:"until"`
+}
+
+type AccountStatusDto struct {
+	UserId         string     `json:"user_id"`
+	Status         string     `json:"status"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
+	Reason         string     `json:"reason,omitempty"`
+}
@@ -3,5 +3,6 @@ package dto
 type AuthenticatedUserInfoDto struct {
 	Id string `json:"id"`
 	Role string `json:"role"`
+	Roles []string `json:"roles,omitempty"`
 	Token string `json:"token"`
 }
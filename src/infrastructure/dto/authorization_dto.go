@@ -0,0 +1,38 @@
+package dto
+
+type AuthorizationCheckDto struct {
+	Object string `json:"object"`
+	Action string `json:"action"`
+}
+
+type AuthorizeRequestDto struct {
+	Token     string `json:"token"`
+	Transport string `json:"transport,omitempty"`
+	Object    string `json:"object"`
+	Action    string `json:"action"`
+}
+
+type AuthorizeBatchRequestDto struct {
+	Token     string                  `json:"token"`
+	Transport string                  `json:"transport,omitempty"`
+	Checks    []AuthorizationCheckDto `json:"checks"`
+}
+
+type AuthorizationDecisionDto struct {
+	Object      string   `json:"object"`
+	Action      string   `json:"action"`
+	Allowed     bool     `json:"allowed"`
+	Subject     string   `json:"subject,omitempty"`
+	MatchedRule []string `json:"matched_rule,omitempty"`
+	Reason      string   `json:"reason"`
+}
+
+type AuthorizeResponseDto struct {
+	AuthorizationDecisionDto
+	TtlSeconds int64 `json:"ttl_seconds"`
+}
+
+type AuthorizeBatchResponseDto struct {
+	Decisions  []AuthorizationDecisionDto `json:"decisions"`
+	TtlSeconds int64                      `json:"ttl_seconds"`
+}
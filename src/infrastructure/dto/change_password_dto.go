@@ -0,0 +1,7 @@
+package dto
+
+type ChangePasswordDto struct {
+	OldPassword       string `json:"old_password"`
+	Password          string `json:"password"`
+	ConfirmedPassword string `json:"confirmed_password"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+type EmailChangeDto struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type EmailChangeConfirmDto struct {
+	Code string `json:"code"`
+}
+
+type EmailChangeCancelDto struct {
+	Token string `json:"token"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+type ImpersonationRequestDto struct {
+	UserId string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+type ImpersonationDto struct {
+	TokenUuid string    `json:"token_uuid"`
+	Token     string    `json:"token"`
+	UserId    string    `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+type MagicLinkRequestDto struct {
+	Email string `json:"email"`
+}
+
+type MagicLoginDto struct {
+	Token   string `json:"token"`
+	Refresh bool   `json:"refresh"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+type OAuthClientRequestDto struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type OAuthClientIdDto struct {
+	ClientId string `json:"client_id"`
+}
+
+type OAuthClientDto struct {
+	ClientId     string    `json:"client_id"`
+	ClientSecret string    `json:"client_secret,omitempty"`
+	Name         string    `json:"name"`
+	Scopes       []string  `json:"scopes"`
+	CreatedBy    string    `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// OAuthTokenRequestDto is bound from a form or a JSON body.
+type OAuthTokenRequestDto struct {
+	GrantType    string `form:"grant_type" json:"grant_type"`
+	ClientId     string `form:"client_id" json:"client_id"`
+	ClientSecret string `form:"client_secret" json:"client_secret"`
+	Scope        string `form:"scope" json:"scope"`
+}
+
+type OAuthTokenDto struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type PasswordViolationDto struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+type PolicyDto struct {
+	Transport string `json:"transport,omitempty"`
+	Subject   string `json:"subject"`
+	Object    string `json:"object"`
+	Action    string `json:"action"`
+	Condition string `json:"condition,omitempty"`
+}
+
+type RoleInheritanceDto struct {
+	Transport string `json:"transport,omitempty"`
+	Role      string `json:"role"`
+	Parent    string `json:"parent"`
+}
+
+type PolicyListDto struct {
+	Transport    string               `json:"transport"`
+	Policies     []PolicyDto          `json:"policies"`
+	Inheritances []RoleInheritanceDto `json:"inheritances"`
+}
+
+type PolicyExplanationDto struct {
+	Subject string   `json:"subject"`
+	Roles   []string `json:"inherits"`
+	Object  string   `json:"object"`
+	Action  string   `json:"action"`
+	Routed  bool     `json:"routed"`
+	Allowed bool     `json:"allowed"`
+	Rule    string   `json:"rule,omitempty"`
+}
+
+type PolicyExplanationListDto struct {
+	Transport    string                 `json:"transport"`
+	Explanations []PolicyExplanationDto `json:"explanations"`
+}
+
+type PolicyDiffRequestDto struct {
+	Transport string `json:"transport,omitempty"`
+	Policy    string `json:"policy"`
+}
+
+type PolicyDiffDto struct {
+	Transport string      `json:"transport"`
+	Gained    []PolicyDto `json:"gained"`
+	Lost      []PolicyDto `json:"lost"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+type RoleRequestDto struct {
+	UserId string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+type UserRolesDto struct {
+	UserId string   `json:"user_id"`
+	Roles  []string `json:"roles"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+type ScopeDto struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type RoleScopeRequestDto struct {
+	Role  string `json:"role"`
+	Scope string `json:"scope"`
+}
+
+type RoleScopesDto struct {
+	Role   string   `json:"role"`
+	Scopes []string `json:"scopes"`
+}
+
+type ScopeListDto struct {
+	Scopes []ScopeDto      `json:"scopes"`
+	Roles  []RoleScopesDto `json:"roles"`
+}
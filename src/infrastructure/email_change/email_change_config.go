@@ -0,0 +1,30 @@
+package email_change
+
+import (
+	"auth-service/domain"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/spf13/viper"
+	"os"
+	"time"
+)
+
+func init_viper(logger *logger.Logger) {
+	if os.Getenv("DOCKER_ENV") != "" {
+		viper.SetConfigFile(`src/configurations/email_change.json`)
+	} else {
+		viper.SetConfigFile(`configurations/email_change.json`)
+	}
+	err := viper.ReadInConfig()
+	if err != nil {
+		logger.Logger.Fatalf("error while reading email change config file, error: %v\n", err)
+	}
+}
+
+func NewEmailChangeConfig(logger *logger.Logger) domain.EmailChangeConfig {
+	init_viper(logger)
+
+	return domain.EmailChangeConfig{
+		Ttl:       time.Duration(viper.GetInt(`email_change.ttl_minutes`)) * time.Minute,
+		CancelUrl: viper.GetString(`email_change.cancel_url`),
+	}
+}
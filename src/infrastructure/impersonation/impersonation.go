@@ -0,0 +1,29 @@
+package impersonation
+
+import (
+	"auth-service/domain"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/spf13/viper"
+	"os"
+	"time"
+)
+
+func init_viper(logger *logger.Logger) {
+	if os.Getenv("DOCKER_ENV") != "" {
+		viper.SetConfigFile(`src/configurations/impersonation.json`)
+	} else {
+		viper.SetConfigFile(`configurations/impersonation.json`)
+	}
+	err := viper.ReadInConfig()
+	if err != nil {
+		logger.Logger.Fatalf("error while reading impersonation config file, error: %v\n", err)
+	}
+}
+
+func NewImpersonationConfig(logger *logger.Logger) domain.ImpersonationConfig {
+	init_viper(logger)
+
+	return domain.ImpersonationConfig{
+		Ttl: time.Duration(viper.GetInt(`impersonation.ttl_minutes`)) * time.Minute,
+	}
+}
@@ -0,0 +1,31 @@
+package magic_link
+
+import (
+	"auth-service/domain"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/spf13/viper"
+	"os"
+	"time"
+)
+
+func init_viper(logger *logger.Logger) {
+	if os.Getenv("DOCKER_ENV") != "" {
+		viper.SetConfigFile(`src/configurations/magic_link.json`)
+	} else {
+		viper.SetConfigFile(`configurations/magic_link.json`)
+	}
+	err := viper.ReadInConfig()
+	if err != nil {
+		logger.Logger.Fatalf("error while reading magic link config file, error: %v\n", err)
+	}
+}
+
+func NewMagicLinkConfig(logger *logger.Logger) domain.MagicLinkConfig {
+	init_viper(logger)
+
+	return domain.MagicLinkConfig{
+		Enabled: viper.GetBool(`magic_link.enabled`),
+		Ttl:     time.Duration(viper.GetInt(`magic_link.ttl_minutes`)) * time.Minute,
+		LinkUrl: viper.GetString(`magic_link.link_url`),
+	}
+}
@@ -0,0 +1,50 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// fileMailer appends every message to an mbox file instead of delivering it.
+type fileMailer struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func NewFileMailer(path string) Mailer {
+	return &fileMailer{Path: path}
+}
+
+func (f *fileMailer) Send(context context.Context, message Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From %s %s\n", message.From, time.Now().Format(time.ANSIC))
+	for _, line := range bytes.Split(bytes.ReplaceAll(message.Bytes(), []byte("\r\n"), []byte("\n")), []byte("\n")) {
+		if bytes.HasPrefix(bytes.TrimLeft(line, ">"), []byte("From ")) {
+			b.WriteByte('>')
+		}
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+
+	_, err = file.Write(b.Bytes())
+	return err
+}
@@ -0,0 +1,91 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+const (
+	BackendSmtp   = "smtp"
+	BackendFile   = "file"
+	BackendMemory = "memory"
+)
+
+type Message struct {
+	From     string
+	To       []string
+	Subject  string
+	TextBody string
+	HtmlBody string
+}
+
+type Mailer interface {
+	Send(context context.Context, message Message) error
+}
+
+type senderMailer struct {
+	Mailer
+	From string
+}
+
+// WithSender sends messages without a From address from the given one.
+func WithSender(mailer Mailer, from string) Mailer {
+	return &senderMailer{Mailer: mailer, From: from}
+}
+
+func (s *senderMailer) Send(context context.Context, message Message) error {
+	if message.From == "" {
+		message.From = s.From
+	}
+	return s.Mailer.Send(context, message)
+}
+
+// Bytes renders the message as an RFC 5322 message.
+func (m Message) Bytes() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", m.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(m.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	if m.TextBody == "" {
+		b.WriteString("Content-Type: text/html; charset=\"UTF-8\"\r\n")
+		b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		writeQuotedPrintable(&b, m.HtmlBody)
+		return b.Bytes()
+	}
+
+	parts := multipart.NewWriter(&b)
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain", m.TextBody},
+		{"text/html", m.HtmlBody},
+	} {
+		w, _ := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType + "; charset=\"UTF-8\""},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		writeQuotedPrintable(w, part.body)
+	}
+	parts.Close()
+
+	return b.Bytes()
+}
+
+func writeQuotedPrintable(w io.Writer, body string) {
+	qp := quotedprintable.NewWriter(w)
+	qp.Write([]byte(body))
+	qp.Close()
+}
@@ -0,0 +1,60 @@
+package mailer
+
+import (
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/spf13/viper"
+	"os"
+	"strconv"
+)
+
+func init_viper(logger *logger.Logger) {
+	if os.Getenv("DOCKER_ENV") != "" {
+		viper.SetConfigFile(`src/configurations/mailer.json`)
+	} else {
+		viper.SetConfigFile(`configurations/mailer.json`)
+	}
+	err := viper.ReadInConfig()
+	if err != nil {
+		logger.Logger.Fatalf("error while reading mailer config file, error: %v\n", err)
+	}
+}
+
+// NewMailer builds the backend selected by mailer.backend, overridable from the environment.
+func NewMailer(logger *logger.Logger) Mailer {
+	init_viper(logger)
+
+	backend := envOr("MAIL_BACKEND", viper.GetString(`mailer.backend`))
+	from := envOr("MAIL_FROM", viper.GetString(`mailer.from`))
+
+	switch backend {
+	case BackendFile:
+		path := envOr("MAIL_FILE_PATH", viper.GetString(`mailer.file.path`))
+		logger.Logger.Infof("mail is captured to file %v\n", path)
+		return WithSender(NewFileMailer(path), from)
+	case BackendMemory:
+		logger.Logger.Infof("mail is kept in memory\n")
+		return WithSender(NewMemoryMailer(), from)
+	case BackendSmtp:
+	default:
+		logger.Logger.Fatalf("error while creating mailer, error: unknown backend %v\n", backend)
+	}
+
+	port, err := strconv.Atoi(envOr("MAIL_SMTP_PORT", viper.GetString(`mailer.smtp.port`)))
+	if err != nil {
+		logger.Logger.Fatalf("error while reading smtp port, error: %v\n", err)
+	}
+
+	return WithSender(NewSmtpMailer(SmtpConfig{
+		Host:     envOr("MAIL_SMTP_HOST", viper.GetString(`mailer.smtp.host`)),
+		Port:     port,
+		Username: envOr("MAIL_SMTP_USERNAME", viper.GetString(`mailer.smtp.username`)),
+		Password: envOr("MAIL_SMTP_PASSWORD", viper.GetString(`mailer.smtp.password`)),
+	}), from)
+}
+
+func envOr(key, value string) string {
+	if env := os.Getenv(key); env != "" {
+		return env
+	}
+	return value
+}
@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+)
+
+type SmtpConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+}
+
+type smtpMailer struct {
+	Config SmtpConfig
+}
+
+func NewSmtpMailer(config SmtpConfig) Mailer {
+	return &smtpMailer{Config: config}
+}
+
+func (s *smtpMailer) Send(context context.Context, message Message) error {
+	var auth smtp.Auth
+	if s.Config.Username != "" {
+		auth = smtp.PlainAuth("", s.Config.Username, s.Config.Password, s.Config.Host)
+	}
+
+	address := fmt.Sprintf("%s:%d", s.Config.Host, s.Config.Port)
+	return smtp.SendMail(address, auth, message.From, message.To, message.Bytes())
+}
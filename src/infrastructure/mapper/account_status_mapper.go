@@ -0,0 +1,19 @@
+package mapper
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/dto"
+)
+
+func MapProfileInfoToAccountStatusDto(profileInfo domain.ProfileInfo) dto.AccountStatusDto {
+	status := dto.AccountStatusDto{
+		UserId: profileInfo.ID,
+		Status: profileInfo.Status(),
+		Reason: profileInfo.StatusReason,
+	}
+	if status.Status == domain.AccountSuspended {
+		status.SuspendedUntil = profileInfo.SuspendedUntil
+	}
+
+	return status
+}
@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/dto"
+)
+
+func MapAuthorizationCheckDtos(checkDtos []dto.AuthorizationCheckDto) []domain.AuthorizationCheck {
+	checks := make([]domain.AuthorizationCheck, 0, len(checkDtos))
+	for _, check := range checkDtos {
+		checks = append(checks, domain.AuthorizationCheck{Object: check.Object, Action: check.Action})
+	}
+
+	return checks
+}
+
+func MapAuthorizationDecisionToDto(decision domain.AuthorizationDecision) dto.AuthorizationDecisionDto {
+	return dto.AuthorizationDecisionDto{
+		Object:      decision.Object,
+		Action:      decision.Action,
+		Allowed:     decision.Allowed,
+		Subject:     decision.Subject,
+		MatchedRule: decision.MatchedRule,
+		Reason:      decision.Reason,
+	}
+}
+
+func MapAuthorizationDecisionsToDtos(decisions []domain.AuthorizationDecision) []dto.AuthorizationDecisionDto {
+	decisionDtos := make([]dto.AuthorizationDecisionDto, 0, len(decisions))
+	for _, decision := range decisions {
+		decisionDtos = append(decisionDtos, MapAuthorizationDecisionToDto(decision))
+	}
+
+	return decisionDtos
+}
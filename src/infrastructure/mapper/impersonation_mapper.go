@@ -0,0 +1,15 @@
+package mapper
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/dto"
+)
+
+func MapImpersonationToDto(impersonation domain.Impersonation) dto.ImpersonationDto {
+	return dto.ImpersonationDto{
+		TokenUuid: impersonation.TokenUuid,
+		Token:     impersonation.AccessToken,
+		UserId:    impersonation.UserId,
+		ExpiresAt: impersonation.ExpiresAt,
+	}
+}
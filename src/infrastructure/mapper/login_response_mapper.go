@@ -0,0 +1,15 @@
+package mapper
+
+import (
+	pb "auth-service/grpc/server/authentication_server"
+	"auth-service/infrastructure/dto"
+)
+
+func MapLoginResponseToAuthenticatedUserInfoDto(response *pb.LoginResponse) dto.AuthenticatedUserInfoDto {
+	return dto.AuthenticatedUserInfoDto{
+		Id:    response.Id,
+		Role:  response.Role,
+		Roles: response.Roles,
+		Token: response.AccessToken,
+	}
+}
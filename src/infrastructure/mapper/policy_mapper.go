@@ -0,0 +1,65 @@
+package mapper
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/dto"
+	"strings"
+)
+
+func MapPolicyDtoToPolicy(policyDto dto.PolicyDto) domain.Policy {
+	return domain.Policy{Subject: policyDto.Subject, Object: policyDto.Object, Action: policyDto.Action, Condition: policyDto.Condition}
+}
+
+func MapRoleInheritanceDtoToRoleInheritance(inheritanceDto dto.RoleInheritanceDto) domain.RoleInheritance {
+	return domain.RoleInheritance{Role: inheritanceDto.Role, Parent: inheritanceDto.Parent}
+}
+
+func MapPoliciesToPolicyListDto(transport string, policies []domain.Policy, inheritances []domain.RoleInheritance) dto.PolicyListDto {
+	list := dto.PolicyListDto{
+		Transport:    transport,
+		Policies:     make([]dto.PolicyDto, 0, len(policies)),
+		Inheritances: make([]dto.RoleInheritanceDto, 0, len(inheritances)),
+	}
+	for _, policy := range policies {
+		list.Policies = append(list.Policies, dto.PolicyDto{Subject: policy.Subject, Object: policy.Object, Action: policy.Action, Condition: policy.Condition})
+	}
+	for _, inheritance := range inheritances {
+		list.Inheritances = append(list.Inheritances, dto.RoleInheritanceDto{Role: inheritance.Role, Parent: inheritance.Parent})
+	}
+
+	return list
+}
+
+func MapPolicyExplanationsToPolicyExplanationListDto(transport string, explanations []domain.PolicyExplanation) dto.PolicyExplanationListDto {
+	list := dto.PolicyExplanationListDto{Transport: transport, Explanations: make([]dto.PolicyExplanationDto, 0, len(explanations))}
+	for _, explanation := range explanations {
+		explanationDto := dto.PolicyExplanationDto{
+			Subject: explanation.Subject,
+			Roles:   explanation.Roles,
+			Object:  explanation.Route.Object,
+			Action:  explanation.Route.Action,
+			Routed:  explanation.Routed,
+			Allowed: explanation.Allowed,
+		}
+		if len(explanation.Rule) > 0 {
+			explanationDto.Rule = domain.PolicyTypePermission + ", " + strings.Join(explanation.Rule, ", ")
+		}
+		list.Explanations = append(list.Explanations, explanationDto)
+	}
+
+	return list
+}
+
+func MapAccessChangesToPolicyDiffDto(transport string, changes []domain.AccessChange) dto.PolicyDiffDto {
+	diff := dto.PolicyDiffDto{Transport: transport, Gained: make([]dto.PolicyDto, 0), Lost: make([]dto.PolicyDto, 0)}
+	for _, change := range changes {
+		policyDto := dto.PolicyDto{Subject: change.Subject, Object: change.Route.Object, Action: change.Route.Action}
+		if change.Gained {
+			diff.Gained = append(diff.Gained, policyDto)
+		} else {
+			diff.Lost = append(diff.Lost, policyDto)
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,10 @@
+package mapper
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/dto"
+)
+
+func MapProfileInfoToUserRolesDto(profileInfo domain.ProfileInfo) dto.UserRolesDto {
+	return dto.UserRolesDto{UserId: profileInfo.ID, Roles: profileInfo.RoleNames()}
+}
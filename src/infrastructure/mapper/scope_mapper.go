@@ -0,0 +1,51 @@
+package mapper
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/dto"
+	"time"
+)
+
+func MapScopesToScopeListDto(scopes []domain.Scope, roles []domain.Role) dto.ScopeListDto {
+	list := dto.ScopeListDto{
+		Scopes: make([]dto.ScopeDto, 0, len(scopes)),
+		Roles:  make([]dto.RoleScopesDto, 0, len(roles)),
+	}
+	for _, scope := range scopes {
+		list.Scopes = append(list.Scopes, dto.ScopeDto{Name: scope.Name, Description: scope.Description})
+	}
+	for _, role := range roles {
+		list.Roles = append(list.Roles, MapRoleToRoleScopesDto(role))
+	}
+
+	return list
+}
+
+func MapRoleToRoleScopesDto(role domain.Role) dto.RoleScopesDto {
+	scopes := make([]string, 0, len(role.Scopes))
+	for _, scope := range role.Scopes {
+		scopes = append(scopes, scope.Name)
+	}
+
+	return dto.RoleScopesDto{Role: role.RoleName, Scopes: scopes}
+}
+
+func MapOAuthClientToOAuthClientDto(client domain.OAuthClient, secret string) dto.OAuthClientDto {
+	return dto.OAuthClientDto{
+		ClientId:     client.ID,
+		ClientSecret: secret,
+		Name:         client.Name,
+		Scopes:       client.ScopeNames(),
+		CreatedBy:    client.CreatedBy,
+		CreatedAt:    client.CreatedAt,
+	}
+}
+
+func MapTokenDetailsToOAuthTokenDto(td domain.TokenDetails, scopes []string) dto.OAuthTokenDto {
+	return dto.OAuthTokenDto{
+		AccessToken: td.TokenUuid,
+		TokenType:   "Bearer",
+		ExpiresIn:   td.AtExpires - time.Now().Unix(),
+		Scope:       domain.JoinScopes(scopes),
+	}
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	FailedAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "nishtagram_auth_failed_attempts_total",
+		Help: "The total number of failed credential and code checks",
+	}, []string{"scope"})
+
+	ThrottledAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "nishtagram_auth_throttled_attempts_total",
+		Help: "The total number of attempts rejected because of a delay or lockout",
+	}, []string{"scope"})
+
+	Lockouts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "nishtagram_auth_lockouts_total",
+		Help: "The total number of temporary lockouts, by subject or source IP address",
+	}, []string{"scope", "kind"})
+)
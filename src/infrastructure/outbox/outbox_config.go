@@ -0,0 +1,33 @@
+package outbox
+
+import (
+	"auth-service/domain"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/spf13/viper"
+	"os"
+	"time"
+)
+
+func init_viper(logger *logger.Logger) {
+	if os.Getenv("DOCKER_ENV") != "" {
+		viper.SetConfigFile(`src/configurations/outbox.json`)
+	} else {
+		viper.SetConfigFile(`configurations/outbox.json`)
+	}
+	err := viper.ReadInConfig()
+	if err != nil {
+		logger.Logger.Fatalf("error while reading outbox config file, error: %v\n", err)
+	}
+}
+
+func NewOutboxConfig(logger *logger.Logger) domain.OutboxConfig {
+	init_viper(logger)
+
+	return domain.OutboxConfig{
+		PollInterval: time.Duration(viper.GetInt(`outbox.poll_interval_seconds`)) * time.Second,
+		BatchSize:    viper.GetInt(`outbox.batch_size`),
+		MaxAttempts:  viper.GetInt(`outbox.max_attempts`),
+		BaseBackoff:  time.Duration(viper.GetInt(`outbox.base_backoff_seconds`)) * time.Second,
+		MaxBackoff:   time.Duration(viper.GetInt(`outbox.max_backoff_seconds`)) * time.Second,
+	}
+}
@@ -0,0 +1,66 @@
+package password_policy
+
+import (
+	"auth-service/helper"
+	"bufio"
+	"encoding/hex"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"os"
+	"strings"
+)
+
+const breachedPasswordsFalsePositiveRate = 0.001
+
+// NewBreachedPasswordFilter loads a SHA-1 list in HIBP format, or returns nil when it can't be read.
+func NewBreachedPasswordFilter(path string, logger *logger.Logger) *helper.BloomFilter {
+	if path == "" {
+		logger.Logger.Warnf("breached passwords file is not configured, screening is disabled\n")
+		return nil
+	}
+	if os.Getenv("DOCKER_ENV") != "" {
+		path = "src/" + path
+	}
+
+	// The list is read twice, to size the filter and to fill it.
+	var count uint64
+	err := readDigests(path, func(digest []byte) {
+		count++
+	})
+	if err != nil {
+		logger.Logger.Errorf("error while reading breached passwords file %v, error: %v\n", path, err)
+		return nil
+	}
+
+	filter := helper.NewBloomFilter(count, breachedPasswordsFalsePositiveRate)
+	if err := readDigests(path, filter.AddDigest); err != nil {
+		logger.Logger.Errorf("error while reading breached passwords file %v, error: %v\n", path, err)
+		return nil
+	}
+
+	logger.Logger.Infof("loaded %v breached password hashes\n", count)
+	return filter
+}
+
+func readDigests(path string, add func(digest []byte)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		hash := strings.Split(line, ":")[0]
+		if len(hash) != 40 {
+			continue
+		}
+		digest, err := hex.DecodeString(hash)
+		if err != nil {
+			continue
+		}
+		add(digest)
+	}
+
+	return scanner.Err()
+}
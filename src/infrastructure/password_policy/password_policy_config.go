@@ -0,0 +1,39 @@
+package password_policy
+
+import (
+	"auth-service/domain"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/spf13/viper"
+	"os"
+)
+
+func init_viper(logger *logger.Logger) {
+	if os.Getenv("DOCKER_ENV") != "" {
+		viper.SetConfigFile(`src/configurations/password_policy.json`)
+	} else {
+		viper.SetConfigFile(`configurations/password_policy.json`)
+	}
+	err := viper.ReadInConfig()
+	if err != nil {
+		logger.Logger.Fatalf("error while reading password policy config file, error: %v\n", err)
+	}
+}
+
+func NewPasswordPolicy(logger *logger.Logger) domain.PasswordPolicy {
+	init_viper(logger)
+
+	return domain.PasswordPolicy{
+		MinLength:      viper.GetInt(`password_policy.min_length`),
+		MaxLength:      viper.GetInt(`password_policy.max_length`),
+		RequireUpper:   viper.GetBool(`password_policy.require_upper`),
+		RequireLower:   viper.GetBool(`password_policy.require_lower`),
+		RequireDigit:   viper.GetBool(`password_policy.require_digit`),
+		RequireSpecial: viper.GetBool(`password_policy.require_special`),
+		ForbidUsername: viper.GetBool(`password_policy.forbid_username`),
+		ForbidEmail:    viper.GetBool(`password_policy.forbid_email`),
+		HistoryDepth:   viper.GetInt(`password_policy.history_depth`),
+		MaxAgeDays:     viper.GetInt(`password_policy.max_age_days`),
+
+		BreachedPasswordsFile: viper.GetString(`password_policy.breached_passwords_file`),
+	}
+}
@@ -0,0 +1,30 @@
+package pdp
+
+import (
+	"auth-service/domain"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/spf13/viper"
+	"os"
+	"time"
+)
+
+func init_viper(logger *logger.Logger) {
+	if os.Getenv("DOCKER_ENV") != "" {
+		viper.SetConfigFile(`src/configurations/pdp.json`)
+	} else {
+		viper.SetConfigFile(`configurations/pdp.json`)
+	}
+	err := viper.ReadInConfig()
+	if err != nil {
+		logger.Logger.Fatalf("error while reading pdp config file, error: %v\n", err)
+	}
+}
+
+func NewPdpConfig(logger *logger.Logger) domain.PdpConfig {
+	init_viper(logger)
+
+	return domain.PdpConfig{
+		DecisionTtl:  time.Duration(viper.GetInt(`pdp.decision_ttl_seconds`)) * time.Second,
+		MaxBatchSize: viper.GetInt(`pdp.max_batch_size`),
+	}
+}
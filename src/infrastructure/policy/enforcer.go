@@ -0,0 +1,242 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/fsnotify/fsnotify"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// reloadDelay lets an editor finish writing the model file before it is read.
+const reloadDelay = 200 * time.Millisecond
+
+var errNoRules = errors.New("policy has no rules")
+
+type Enforcer interface {
+	Enforce(sub, obj, act string) (bool, error)
+	EnforceEx(sub, obj, act string) (bool, []string, error)
+	EnforceResource(sub, obj interface{}, act string) (bool, []string, error)
+	Reload() error
+	Watch(context context.Context)
+	Name() string
+	Policies() [][]string
+	Groupings() [][]string
+	AddPolicy(context context.Context, values ...string) (bool, error)
+	RemovePolicy(context context.Context, values ...string) (bool, error)
+	AddGrouping(context context.Context, values ...string) (bool, error)
+	RemoveGrouping(context context.Context, values ...string) (bool, error)
+	LoadSnapshot(rules io.Reader) (*Snapshot, error)
+}
+
+// enforcer is a casbin enforcer that keeps its last good policy when a reload fails.
+type enforcer struct {
+	name      string
+	modelPath string
+	adapter   persist.Adapter
+	watcher   Watcher
+	mu        sync.RWMutex
+	enforcer  *casbin.SyncedEnforcer
+	logger    *logger.Logger
+}
+
+// NewEnforcer loads the transport's policy, seeding or migrating the stored rules first.
+func NewEnforcer(name, modelPath, seedPath string, adapter persist.Adapter, watcher Watcher, logger *logger.Logger) Enforcer {
+	if os.Getenv("DOCKER_ENV") != "" {
+		modelPath = "src/" + modelPath
+		seedPath = "src/" + seedPath
+	}
+
+	e := &enforcer{name: name, modelPath: modelPath, adapter: adapter, watcher: watcher, logger: logger}
+	err := e.Reload()
+	if errors.Is(err, errNoRules) {
+		err = e.seed(seedPath)
+	} else if err == nil {
+		err = e.migrate()
+	}
+	if err != nil {
+		logger.Logger.Fatalf("error while loading %v policy, error: %v\n", name, err)
+	}
+
+	return e
+}
+
+func (e *enforcer) seed(seedPath string) error {
+	seed, err := casbin.NewEnforcer(e.modelPath, seedPath)
+	if err != nil {
+		return err
+	}
+
+	if err := e.adapter.SavePolicy(seed.GetModel()); err != nil {
+		e.logger.Logger.Warnf("error while seeding %v policy from %v, error: %v\n", e.name, seedPath, err)
+	} else {
+		e.logger.Logger.Infof("seeded %v policy from %v\n", e.name, seedPath)
+		if migrator, ok := e.adapter.(Migrator); ok {
+			if err := migrator.Baseline(transportMigrations(e.name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return e.Reload()
+}
+
+// migrate applies the seed file changes an older stored policy misses.
+func (e *enforcer) migrate() error {
+	migrator, ok := e.adapter.(Migrator)
+	if !ok {
+		return nil
+	}
+
+	applied, err := migrator.Migrate(transportMigrations(e.name))
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		return nil
+	}
+
+	e.logger.Logger.Infof("applied %v policy migrations %v\n", e.name, applied)
+	if err := e.watcher.Publish(context.Background(), e.name); err != nil {
+		e.logger.Logger.Errorf("error while publishing %v policy update, replicas reload it on restart, error: %v\n", e.name, err)
+	}
+	return e.Reload()
+}
+
+func (e *enforcer) Name() string {
+	return e.name
+}
+
+func (e *enforcer) current() *casbin.SyncedEnforcer {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.enforcer
+}
+
+func (e *enforcer) Enforce(sub, obj, act string) (bool, error) {
+	return e.current().Enforce(sub, obj, act)
+}
+
+// EnforceEx also returns the rule that allowed the request.
+func (e *enforcer) EnforceEx(sub, obj, act string) (bool, []string, error) {
+	return e.current().EnforceEx(sub, obj, act)
+}
+
+// EnforceResource checks a request of the resource model.
+func (e *enforcer) EnforceResource(sub, obj interface{}, act string) (bool, []string, error) {
+	return e.current().EnforceEx(sub, obj, act)
+}
+
+func (e *enforcer) Reload() error {
+	candidate, err := casbin.NewSyncedEnforcer(e.modelPath, e.adapter)
+	if err != nil {
+		e.logger.Logger.Errorf("keeping last good %v policy, error: %v\n", e.name, err)
+		return fmt.Errorf("failed to load %v policy: %w", e.name, err)
+	}
+
+	rules := len(candidate.GetPolicy())
+	if rules == 0 {
+		e.logger.Logger.Errorf("keeping last good %v policy, it has no rules\n", e.name)
+		return errNoRules
+	}
+
+	e.mu.Lock()
+	e.enforcer = candidate
+	e.mu.Unlock()
+
+	e.logger.Logger.Infof("loaded %v policy with %v rules\n", e.name, rules)
+	return nil
+}
+
+// LoadSnapshot reads a version of the policy with the transport's model without applying it.
+func (e *enforcer) LoadSnapshot(rules io.Reader) (*Snapshot, error) {
+	return LoadSnapshot(e.modelPath, rules)
+}
+
+func (e *enforcer) Policies() [][]string {
+	return e.current().GetPolicy()
+}
+
+func (e *enforcer) Groupings() [][]string {
+	return e.current().GetGroupingPolicy()
+}
+
+// AddPolicy stores the rule and tells the other replicas to reload.
+func (e *enforcer) AddPolicy(context context.Context, values ...string) (bool, error) {
+	return e.changed(context)(e.current().AddPolicy(values))
+}
+
+func (e *enforcer) RemovePolicy(context context.Context, values ...string) (bool, error) {
+	return e.changed(context)(e.current().RemovePolicy(values))
+}
+
+func (e *enforcer) AddGrouping(context context.Context, values ...string) (bool, error) {
+	return e.changed(context)(e.current().AddGroupingPolicy(values))
+}
+
+func (e *enforcer) RemoveGrouping(context context.Context, values ...string) (bool, error) {
+	return e.changed(context)(e.current().RemoveGroupingPolicy(values))
+}
+
+// changed publishes the update when a change was stored.
+func (e *enforcer) changed(context context.Context) func(ok bool, err error) (bool, error) {
+	return func(ok bool, err error) (bool, error) {
+		if err != nil || !ok {
+			return ok, err
+		}
+
+		if err := e.watcher.Publish(context, e.name); err != nil {
+			e.logger.Logger.Errorf("error while publishing %v policy update, replicas reload it on restart, error: %v\n", e.name, err)
+		}
+		return true, nil
+	}
+}
+
+// Watch reloads the policy whenever the model file changes, until the context is done.
+func (e *enforcer) Watch(context context.Context) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		e.logger.Logger.Errorf("error while watching %v policy, error: %v\n", e.name, err)
+		return
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(e.modelPath)); err != nil {
+		e.logger.Logger.Errorf("error while watching %v policy, error: %v\n", e.name, err)
+		return
+	}
+
+	var pending *time.Timer
+	for {
+		select {
+		case <-context.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(e.modelPath) {
+				continue
+			}
+			if pending != nil {
+				pending.Stop()
+			}
+			pending = time.AfterFunc(reloadDelay, func() {
+				_ = e.Reload()
+			})
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			e.logger.Logger.Errorf("error while watching %v policy, error: %v\n", e.name, err)
+		}
+	}
+}
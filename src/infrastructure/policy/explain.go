@@ -0,0 +1,205 @@
+package policy
+
+import (
+	"auth-service/domain"
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/casbin/casbin/v2"
+	"io"
+	"sort"
+	"strings"
+)
+
+// anonymousSubject is the subject of requests without a token.
+const anonymousSubject = "ANONYMOUS"
+
+var ErrNoAction = errors.New("action is required for a route the transport doesn't serve")
+
+// Checker is a live Enforcer or a Snapshot of a policy file.
+type Checker interface {
+	EnforceEx(sub, obj, act string) (bool, []string, error)
+	Policies() [][]string
+	Groupings() [][]string
+}
+
+// Snapshot is a policy loaded from CSV lines instead of the database.
+type Snapshot struct {
+	enforcer *casbin.Enforcer
+}
+
+// LoadSnapshot reads a policy in the seed file format, rejecting lines it doesn't understand.
+func LoadSnapshot(modelPath string, rules io.Reader) (*Snapshot, error) {
+	enforcer, err := casbin.NewEnforcer(modelPath)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(rules)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		reader := csv.NewReader(strings.NewReader(text))
+		reader.TrimLeadingSpace = true
+		values, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+
+		switch {
+		case values[0] == domain.PolicyTypePermission && (len(values) == 4 || len(values) == 5):
+			_, err = enforcer.AddPolicy(values[1:])
+		case values[0] == domain.PolicyTypeInheritance && len(values) == 3:
+			_, err = enforcer.AddGroupingPolicy(values[1:])
+		default:
+			err = errors.New("expected p, subject, object, action[, condition] or g, role, parent")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(enforcer.GetPolicy()) == 0 {
+		return nil, errNoRules
+	}
+
+	return &Snapshot{enforcer: enforcer}, nil
+}
+
+func (s *Snapshot) EnforceEx(sub, obj, act string) (bool, []string, error) {
+	return s.enforcer.EnforceEx(sub, obj, act)
+}
+
+func (s *Snapshot) EnforceResource(sub, obj interface{}, act string) (bool, []string, error) {
+	return s.enforcer.EnforceEx(sub, obj, act)
+}
+
+func (s *Snapshot) Policies() [][]string {
+	return s.enforcer.GetPolicy()
+}
+
+func (s *Snapshot) Groupings() [][]string {
+	return s.enforcer.GetGroupingPolicy()
+}
+
+// Explain checks the subject against the route, or every route served on the object.
+func Explain(checker Checker, subject, object, action string, routes []domain.Route) ([]domain.PolicyExplanation, error) {
+	var matched []domain.Route
+	for _, route := range routes {
+		if route.Object == object && (action == "" || route.Action == action) {
+			matched = append(matched, route)
+		}
+	}
+
+	routed := len(matched) > 0
+	if !routed {
+		if action == "" {
+			return nil, ErrNoAction
+		}
+		matched = []domain.Route{{Object: object, Action: action}}
+	}
+
+	roles := Roles(checker, subject)
+	explanations := make([]domain.PolicyExplanation, 0, len(matched))
+	for _, route := range matched {
+		decision, err := Decide(checker, []string{subject}, route.Object, route.Action)
+		if err != nil {
+			return nil, err
+		}
+		explanations = append(explanations, domain.PolicyExplanation{
+			Subject: subject,
+			Roles:   roles,
+			Route:   route,
+			Routed:  routed,
+			Allowed: decision.Allowed,
+			Rule:    decision.Rule,
+		})
+	}
+
+	return explanations, nil
+}
+
+// Roles returns the roles the subject inherits from, nearest first.
+func Roles(checker Checker, subject string) []string {
+	roles := make([]string, 0)
+	seen := map[string]bool{subject: true}
+	for queue := []string{subject}; len(queue) > 0; queue = queue[1:] {
+		for _, grouping := range checker.Groupings() {
+			if len(grouping) < 2 || grouping[0] != queue[0] || seen[grouping[1]] {
+				continue
+			}
+			seen[grouping[1]] = true
+			roles = append(roles, grouping[1])
+			queue = append(queue, grouping[1])
+		}
+	}
+
+	return roles
+}
+
+// Diff returns the routes each subject gained or lost between two policies.
+func Diff(before, after Checker, routes []domain.Route) ([]domain.AccessChange, error) {
+	changes := make([]domain.AccessChange, 0)
+	for _, subject := range Subjects(before, after) {
+		for _, route := range routes {
+			was, err := Decide(before, []string{subject}, route.Object, route.Action)
+			if err != nil {
+				return nil, err
+			}
+			is, err := Decide(after, []string{subject}, route.Object, route.Action)
+			if err != nil {
+				return nil, err
+			}
+			if was.Allowed != is.Allowed {
+				changes = append(changes, domain.AccessChange{Subject: subject, Route: route, Gained: is.Allowed})
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// Subjects returns the sorted subjects and roles named by the policies.
+func Subjects(checkers ...Checker) []string {
+	seen := map[string]bool{anonymousSubject: true}
+	for _, checker := range checkers {
+		for _, policy := range checker.Policies() {
+			if len(policy) > 0 {
+				seen[policy[0]] = true
+			}
+		}
+		for _, grouping := range checker.Groupings() {
+			for _, role := range grouping {
+				seen[role] = true
+			}
+		}
+	}
+
+	subjects := make([]string, 0, len(seen))
+	for subject := range seen {
+		subjects = append(subjects, subject)
+	}
+	sort.Strings(subjects)
+
+	return subjects
+}
+
+// SortRoutes orders routes by object and then action.
+func SortRoutes(routes []domain.Route) {
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Object != routes[j].Object {
+			return routes[i].Object < routes[j].Object
+		}
+		return routes[i].Action < routes[j].Action
+	})
+}
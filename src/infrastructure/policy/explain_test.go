@@ -0,0 +1,237 @@
+package policy
+
+import (
+	"auth-service/domain"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testModel = "../../http/middleware/rbac_model.conf"
+
+const testPolicy = `
+# comments and blank lines are skipped
+p, ANONYMOUS, /login, *
+p, USER, /changePassword, POST
+p, ADMIN, /agent, GET
+p, scope:profile:write, /changePassword, POST
+g, AGENT, USER
+g, ADMIN, AGENT
+`
+
+var testRoutes = []domain.Route{
+	{Object: "/login", Action: "POST"},
+	{Object: "/changePassword", Action: "POST"},
+	{Object: "/agent", Action: "GET"},
+	{Object: "/agent", Action: "POST"},
+}
+
+func snapshot(t *testing.T, rules string) *Snapshot {
+	t.Helper()
+	loaded, err := LoadSnapshot(testModel, strings.NewReader(rules))
+	if err != nil {
+		t.Fatalf("error while loading policy: %v", err)
+	}
+	return loaded
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr string
+	}{
+		{name: "permissions and inheritance", rules: testPolicy},
+		{name: "permission with a condition", rules: "p, USER, /x, GET, r.sub == r.obj"},
+		{name: "no rules", rules: "# nothing\n", wantErr: errNoRules.Error()},
+		{name: "only inheritance", rules: "g, AGENT, USER", wantErr: errNoRules.Error()},
+		{name: "permission without action", rules: "p, USER, /x", wantErr: "line 1"},
+		{name: "inheritance with three roles", rules: "p, USER, /x, GET\ng, A, B, C", wantErr: "line 2"},
+		{name: "unknown rule type", rules: "p, USER, /x, GET\n\nx, USER, /x, GET", wantErr: "line 3"},
+		{name: "broken quoting", rules: `p, USER, "/x, GET`, wantErr: "line 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadSnapshot(testModel, strings.NewReader(tt.rules))
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	checker := snapshot(t, testPolicy)
+
+	tests := []struct {
+		name    string
+		subject string
+		object  string
+		action  string
+		wantErr error
+		want    []domain.PolicyExplanation
+	}{
+		{
+			name: "allowed through an inherited role", subject: "ADMIN", object: "/changePassword", action: "POST",
+			want: []domain.PolicyExplanation{{Subject: "ADMIN", Roles: []string{"AGENT", "USER"}, Route: testRoutes[1], Routed: true, Allowed: true, Rule: []string{"USER", "/changePassword", "POST"}}},
+		},
+		{
+			name: "allowed through a scope", subject: "scope:profile:write", object: "/changePassword", action: "POST",
+			want: []domain.PolicyExplanation{{Subject: "scope:profile:write", Roles: []string{}, Route: testRoutes[1], Routed: true, Allowed: true, Rule: []string{"scope:profile:write", "/changePassword", "POST"}}},
+		},
+		{
+			name: "denied", subject: "ANONYMOUS", object: "/changePassword", action: "POST",
+			want: []domain.PolicyExplanation{{Subject: "ANONYMOUS", Roles: []string{}, Route: testRoutes[1], Routed: true}},
+		},
+		{
+			name: "every action of the object", subject: "ADMIN", object: "/agent",
+			want: []domain.PolicyExplanation{
+				{Subject: "ADMIN", Roles: []string{"AGENT", "USER"}, Route: testRoutes[2], Routed: true, Allowed: true, Rule: []string{"ADMIN", "/agent", "GET"}},
+				{Subject: "ADMIN", Roles: []string{"AGENT", "USER"}, Route: testRoutes[3], Routed: true},
+			},
+		},
+		{
+			name: "route the transport does not serve", subject: "USER", object: "/changePassword", action: "GET",
+			want: []domain.PolicyExplanation{{Subject: "USER", Roles: []string{}, Route: domain.Route{Object: "/changePassword", Action: "GET"}}},
+		},
+		{
+			name: "unrouted object needs an action", subject: "USER", object: "/unknown", wantErr: ErrNoAction,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Explain(checker, tt.subject, tt.object, tt.action, testRoutes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("explanations = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name  string
+		after string
+		want  []domain.AccessChange
+	}{
+		{name: "same policy", after: testPolicy, want: []domain.AccessChange{}},
+		{
+			name: "reordered and commented rules", after: "g, ADMIN, AGENT\ng, AGENT, USER\n# reordered\n" +
+				"p, scope:profile:write, /changePassword, POST\np, ADMIN, /agent, GET\np, USER, /changePassword, POST\np, ANONYMOUS, /login, *",
+			want: []domain.AccessChange{},
+		},
+		{
+			name: "narrowed anonymous rule", after: strings.Replace(testPolicy, "p, ANONYMOUS, /login, *", "p, ANONYMOUS, /login, GET", 1),
+			want: []domain.AccessChange{{Subject: "ANONYMOUS", Route: testRoutes[0], Gained: false}},
+		},
+		{
+			name: "removed inheritance is lost by every heir", after: strings.Replace(testPolicy, "g, AGENT, USER", "", 1),
+			want: []domain.AccessChange{
+				{Subject: "ADMIN", Route: testRoutes[1], Gained: false},
+				{Subject: "AGENT", Route: testRoutes[1], Gained: false},
+			},
+		},
+		{
+			name: "new subject", after: testPolicy + "p, scope:agent:review, /agent, GET\n",
+			want: []domain.AccessChange{{Subject: "scope:agent:review", Route: testRoutes[2], Gained: true}},
+		},
+	}
+
+	before := snapshot(t, testPolicy)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(before, snapshot(t, tt.after), testRoutes)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("changes = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoles(t *testing.T) {
+	checker := snapshot(t, testPolicy+"g, ADMIN, USER\ng, USER, ADMIN\n")
+
+	tests := []struct {
+		subject string
+		want    []string
+	}{
+		{subject: "ADMIN", want: []string{"AGENT", "USER"}},
+		{subject: "AGENT", want: []string{"USER", "ADMIN"}},
+		{subject: "ANONYMOUS", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			if got := Roles(checker, tt.subject); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("roles = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubjects(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []string
+		want     []string
+	}{
+		{name: "anonymous is always a subject", policies: []string{"p, USER, /x, GET"}, want: []string{"ANONYMOUS", "USER"}},
+		{name: "subjects of every policy", policies: []string{testPolicy, "p, AUDITOR, /x, GET\ng, AUDITOR, GUEST"},
+			want: []string{"ADMIN", "AGENT", "ANONYMOUS", "AUDITOR", "GUEST", "USER", "scope:profile:write"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkers := make([]Checker, 0, len(tt.policies))
+			for _, rules := range tt.policies {
+				checkers = append(checkers, snapshot(t, rules))
+			}
+			if got := Subjects(checkers...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("subjects = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecide(t *testing.T) {
+	checker := snapshot(t, testPolicy)
+
+	tests := []struct {
+		name     string
+		subjects []string
+		object   string
+		action   string
+		want     Decision
+	}{
+		{name: "first allowed subject", subjects: []string{"ANONYMOUS", "AGENT", "USER"}, object: "/changePassword", action: "POST",
+			want: Decision{Allowed: true, Subject: "AGENT", Rule: []string{"USER", "/changePassword", "POST"}}},
+		{name: "scope", subjects: []string{"scope:profile:write"}, object: "/changePassword", action: "POST",
+			want: Decision{Allowed: true, Subject: "scope:profile:write", Rule: []string{"scope:profile:write", "/changePassword", "POST"}}},
+		{name: "no subject allowed", subjects: []string{"ANONYMOUS", "USER"}, object: "/agent", action: "GET"},
+		{name: "no subjects", object: "/login", action: "POST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decide(checker, tt.subjects, tt.object, tt.action)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decision = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package policy
+
+import (
+	"auth-service/domain"
+	"errors"
+	"fmt"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// gormAdapter stores one transport's casbin policy in the policy_rules table.
+type gormAdapter struct {
+	conn      *gorm.DB
+	transport string
+}
+
+func NewGormAdapter(conn *gorm.DB, transport string) persist.Adapter {
+	return &gormAdapter{conn: conn, transport: transport}
+}
+
+func (a *gormAdapter) LoadPolicy(model model.Model) error {
+	var rules []domain.PolicyRule
+	if err := a.conn.Where("transport = ?", a.transport).Order("id").Find(&rules).Error; err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		sec := ""
+		if rule.Ptype != "" {
+			sec = rule.Ptype[:1]
+		}
+		if _, ok := model[sec][rule.Ptype]; !ok {
+			return fmt.Errorf("policy rule %v has type %v which the model does not define", rule.ID, rule.Ptype)
+		}
+		model.AddPolicy(sec, rule.Ptype, rule.Values())
+	}
+
+	return nil
+}
+
+// SavePolicy replaces the stored policy with the one in the model.
+func (a *gormAdapter) SavePolicy(model model.Model) error {
+	return a.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("transport = ?", a.transport).Delete(&domain.PolicyRule{}).Error; err != nil {
+			return err
+		}
+
+		for _, sec := range []string{"p", "g"} {
+			for ptype, assertion := range model[sec] {
+				for _, values := range assertion.Policy {
+					rule, err := a.rule(ptype, values)
+					if err != nil {
+						return err
+					}
+					if err := tx.Create(&rule).Error; err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func (a *gormAdapter) AddPolicy(sec string, ptype string, values []string) error {
+	rule, err := a.rule(ptype, values)
+	if err != nil {
+		return err
+	}
+
+	return a.conn.Create(&rule).Error
+}
+
+func (a *gormAdapter) RemovePolicy(sec string, ptype string, values []string) error {
+	rule, err := a.rule(ptype, values)
+	if err != nil {
+		return err
+	}
+
+	return deleteRule(a.conn, rule)
+}
+
+func deleteRule(conn *gorm.DB, rule domain.PolicyRule) error {
+	return conn.Where(map[string]interface{}{
+		"transport": rule.Transport, "ptype": rule.Ptype,
+		"v0": rule.V0, "v1": rule.V1, "v2": rule.V2, "v3": rule.V3, "v4": rule.V4, "v5": rule.V5,
+	}).Delete(&domain.PolicyRule{}).Error
+}
+
+func (a *gormAdapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	query := a.conn.Where("transport = ? AND ptype = ?", a.transport, ptype)
+	for i, value := range fieldValues {
+		if value == "" {
+			continue
+		}
+		column := fieldIndex + i
+		if column > 5 {
+			return errors.New("policy rules have at most six values")
+		}
+		query = query.Where(columns[column]+" = ?", value)
+	}
+
+	return query.Delete(&domain.PolicyRule{}).Error
+}
+
+var columns = []string{"v0", "v1", "v2", "v3", "v4", "v5"}
+
+func (a *gormAdapter) rule(ptype string, values []string) (domain.PolicyRule, error) {
+	if len(values) > len(columns) {
+		return domain.PolicyRule{}, errors.New("policy rules have at most six values")
+	}
+
+	padded := make([]string, len(columns))
+	copy(padded, values)
+
+	return domain.PolicyRule{
+		Transport: a.transport,
+		Ptype:     ptype,
+		V0:        padded[0],
+		V1:        padded[1],
+		V2:        padded[2],
+		V3:        padded[3],
+		V4:        padded[4],
+		V5:        padded[5],
+	}, nil
+}
+
+// Migrate applies each migration in a transaction with the row recording it.
+func (a *gormAdapter) Migrate(migrations []Migration) ([]string, error) {
+	var applied []string
+	for _, migration := range migrations {
+		ok, err := a.migrate(migration)
+		if err != nil {
+			return applied, fmt.Errorf("failed to apply %v policy migration %v: %w", a.transport, migration.Version, err)
+		}
+		if ok {
+			applied = append(applied, migration.Version)
+		}
+	}
+
+	return applied, nil
+}
+
+func (a *gormAdapter) migrate(migration Migration) (bool, error) {
+	ok := false
+	err := a.conn.Transaction(func(tx *gorm.DB) error {
+		record := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(a.migration(migration))
+		if record.Error != nil || record.RowsAffected == 0 {
+			return record.Error
+		}
+
+		for _, line := range migration.Remove {
+			rule, err := a.parse(line)
+			if err != nil {
+				return err
+			}
+			if err := deleteRule(tx, rule); err != nil {
+				return err
+			}
+		}
+		// a rule may have been added at runtime already
+		for _, line := range migration.Add {
+			rule, err := a.parse(line)
+			if err != nil {
+				return err
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rule).Error; err != nil {
+				return err
+			}
+		}
+
+		ok = true
+		return nil
+	})
+
+	return ok, err
+}
+
+func (a *gormAdapter) Baseline(migrations []Migration) error {
+	for _, migration := range migrations {
+		if err := a.conn.Clauses(clause.OnConflict{DoNothing: true}).Create(a.migration(migration)).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *gormAdapter) migration(migration Migration) *domain.PolicyMigration {
+	return &domain.PolicyMigration{Version: migration.Version, Transport: a.transport, AppliedAt: time.Now()}
+}
+
+func (a *gormAdapter) parse(line string) (domain.PolicyRule, error) {
+	ptype, values, err := parseRule(line)
+	if err != nil {
+		return domain.PolicyRule{}, err
+	}
+
+	return a.rule(ptype, values)
+}
@@ -0,0 +1,229 @@
+package policy
+
+import (
+	"auth-service/domain"
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// Migration changes the stored policy of a transport like a change to its seed file.
+type Migration struct {
+	Version   string
+	Transport string
+	Remove    []string
+	Add       []string
+}
+
+// Migrator is implemented by adapters that store their applied migrations.
+type Migrator interface {
+	Migrate(migrations []Migration) ([]string, error)
+	// Baseline records the migrations as applied.
+	Baseline(migrations []Migration) error
+}
+
+// migrations are applied in order, a version is never changed once released.
+var migrations = []Migration{
+	// AGENT inherits USER, and admins grant roles.
+	{
+		Version:   "0001_agent_inherits_user",
+		Transport: domain.PolicyTransportHttp,
+		Remove: []string{
+			"p, AGENT, /logoutAll, *",
+			"p, AGENT, /verifySecret, *",
+			"p, AGENT, /isTotpEnabled, *",
+			"p, AGENT, /disableTotp, *",
+			"p, AGENT, /resetPasswordMail, *",
+			"p, AGENT, /resetPassword, *",
+			"p, AGENT, /changePassword, *",
+			"p, AGENT, /changeEmail, *",
+			"p, AGENT, /changeEmail/confirm, *",
+			"p, AGENT, /changeEmail/cancel, *",
+			"p, AGENT, /lockAccount, *",
+			"p, AGENT, /activity, *",
+			"p, AGENT, /generateSecret, *",
+		},
+		Add: []string{
+			"p, ADMIN, /admin/roles/grant, *",
+			"p, ADMIN, /admin/roles/revoke, *",
+			"g, AGENT, USER",
+		},
+	},
+	{
+		Version:   "0001_agent_inherits_user",
+		Transport: domain.PolicyTransportGrpc,
+		Remove: []string{
+			"p, AGENT, /Authentication/ChangePassword, *",
+		},
+		Add: []string{
+			"p, ADMIN, /Authentication/GrantRole, *",
+			"p, ADMIN, /Authentication/RevokeRole, *",
+			"g, AGENT, USER",
+		},
+	},
+	// Routes can require scopes, and listing agent requests needs agent:review.
+	{
+		Version:   "0002_scopes",
+		Transport: domain.PolicyTransportHttp,
+		Remove: []string{
+			"p, ANONYMOUS, /agent, *",
+		},
+		Add: []string{
+			"p, ANONYMOUS, /agent, POST",
+			"p, ADMIN, /agent, GET",
+			"p, scope:agent:review, /confirmAgentAccount, *",
+			"p, scope:agent:review, /agent, GET",
+			"p, ANONYMOUS, /oauth/token, *",
+			"p, ADMIN, /admin/scopes, *",
+			"p, ADMIN, /admin/scopes/*, *",
+			"p, ADMIN, /admin/clients, *",
+			"p, ADMIN, /admin/clients/*, *",
+			"p, scope:totp:manage, /generateSecret, *",
+			"p, scope:totp:manage, /verifySecret, *",
+			"p, scope:totp:manage, /isTotpEnabled, *",
+			"p, scope:totp:manage, /disableTotp, *",
+			"p, scope:profile:write, /changePassword, *",
+			"p, scope:profile:write, /changeEmail, *",
+			"p, scope:profile:write, /changeEmail/confirm, *",
+			"p, scope:profile:write, /changeEmail/cancel, *",
+			"p, scope:activity:read, /activity, GET",
+		},
+	},
+	{
+		Version:   "0002_scopes",
+		Transport: domain.PolicyTransportGrpc,
+		Add: []string{
+			"p, scope:profile:write, /Authentication/ChangePassword, *",
+		},
+	},
+	// The policy decision point.
+	{
+		Version:   "0003_authorize",
+		Transport: domain.PolicyTransportHttp,
+		Add: []string{
+			"p, ADMIN, /authorize, *",
+			"p, ADMIN, /authorize/batch, *",
+			"p, scope:policy:decide, /authorize, *",
+			"p, scope:policy:decide, /authorize/batch, *",
+		},
+	},
+	{
+		Version:   "0003_authorize",
+		Transport: domain.PolicyTransportGrpc,
+		Add: []string{
+			"p, ADMIN, /Authentication/Authorize, *",
+			"p, ADMIN, /Authentication/AuthorizeBatch, *",
+			"p, scope:policy:decide, /Authentication/Authorize, *",
+			"p, scope:policy:decide, /Authentication/AuthorizeBatch, *",
+		},
+	},
+	// The resource policy decides whose profile a caller may delete.
+	{
+		Version:   "0004_profile_deletion",
+		Transport: domain.PolicyTransportHttp,
+		Remove: []string{
+			"p, ANONYMOUS, /deleteProfileInfo, *",
+		},
+		Add: []string{
+			"p, USER, /deleteProfileInfo, POST",
+			"p, ADMIN, /deleteProfileInfo, POST",
+		},
+	},
+	// Every HTTP route has a gRPC equivalent, and the Totp service is authorized.
+	{
+		Version:   "0005_grpc_routes",
+		Transport: domain.PolicyTransportGrpc,
+		Add: []string{
+			"p, ANONYMOUS, /Authentication/Register, *",
+			"p, ANONYMOUS, /Authentication/ConfirmAccount, *",
+			"p, ANONYMOUS, /Authentication/ResendRegistrationCode, *",
+			"p, ANONYMOUS, /Authentication/RegisterAgent, *",
+			"p, ANONYMOUS, /Authentication/ValidateAgentAccount, *",
+			"p, ADMIN, /Authentication/ListAgentRequests, *",
+			"p, ADMIN, /Authentication/ConfirmAgentAccount, *",
+			"p, scope:agent:review, /Authentication/ListAgentRequests, *",
+			"p, scope:agent:review, /Authentication/ConfirmAgentAccount, *",
+			"p, USER, /Authentication/LogoutAll, *",
+			"p, ADMIN, /Authentication/LogoutAll, *",
+			"p, ANONYMOUS, /Authentication/RefreshToken, *",
+			"p, ANONYMOUS, /Authentication/IssueClientToken, *",
+			"p, USER, /Authentication/GenerateSecret, *",
+			"p, ANONYMOUS, /Authentication/ValidateTemporaryToken, *",
+			"p, USER, /Authentication/ResendEmail, *",
+			"p, ANONYMOUS, /Authentication/SendMagicLink, *",
+			"p, ANONYMOUS, /Authentication/MagicLogin, *",
+			"p, USER, /Authentication/RequestEmailChange, *",
+			"p, ADMIN, /Authentication/RequestEmailChange, *",
+			"p, USER, /Authentication/ConfirmEmailChange, *",
+			"p, ADMIN, /Authentication/ConfirmEmailChange, *",
+			"p, ANONYMOUS, /Authentication/CancelEmailChange, *",
+			"p, USER, /Authentication/CancelEmailChange, *",
+			"p, ADMIN, /Authentication/CancelEmailChange, *",
+			"p, ANONYMOUS, /Authentication/LockAccount, *",
+			"p, USER, /Authentication/LockAccount, *",
+			"p, ADMIN, /Authentication/LockAccount, *",
+			"p, USER, /Authentication/GetActivity, *",
+			"p, ADMIN, /Authentication/GetActivity, *",
+			"p, ADMIN, /Authentication/SearchActivity, *",
+			"p, ADMIN, /Authentication/ExportAuditLog, *",
+			"p, ADMIN, /Authentication/StartImpersonation, *",
+			"p, ADMIN, /Authentication/EndImpersonation, *",
+			"p, ADMIN, /Authentication/ListScopes, *",
+			"p, ADMIN, /Authentication/AddRoleScope, *",
+			"p, ADMIN, /Authentication/RemoveRoleScope, *",
+			"p, ADMIN, /Authentication/ListOAuthClients, *",
+			"p, ADMIN, /Authentication/CreateOAuthClient, *",
+			"p, ADMIN, /Authentication/DeleteOAuthClient, *",
+			"p, ADMIN, /Authentication/ReloadPolicy, *",
+			"p, ADMIN, /Authentication/ExplainPolicy, *",
+			"p, ADMIN, /Authentication/DiffPolicy, *",
+			"p, ADMIN, /Authentication/ListOutboxMessages, *",
+			"p, ADMIN, /Authentication/RedriveOutboxMessage, *",
+			"p, USER, /Authentication/DeleteProfileInfo, *",
+			"p, ADMIN, /Authentication/DeleteProfileInfo, *",
+			"p, USER, /Totp/Verify, *",
+			"p, ANONYMOUS, /Totp/IsEnabled, *",
+			"p, USER, /Totp/IsEnabled, *",
+			"p, USER, /Totp/Disable, *",
+			"p, IMPERSONATION, /Authentication/GetActivity, *",
+			"p, IMPERSONATION, /Totp/IsEnabled, *",
+			"p, scope:profile:write, /Authentication/RequestEmailChange, *",
+			"p, scope:profile:write, /Authentication/ConfirmEmailChange, *",
+			"p, scope:profile:write, /Authentication/CancelEmailChange, *",
+			"p, scope:totp:manage, /Authentication/GenerateSecret, *",
+			"p, scope:totp:manage, /Totp/Verify, *",
+			"p, scope:totp:manage, /Totp/IsEnabled, *",
+			"p, scope:totp:manage, /Totp/Disable, *",
+			"p, scope:activity:read, /Authentication/GetActivity, *",
+		},
+	},
+}
+
+func transportMigrations(transport string) []Migration {
+	var found []Migration
+	for _, migration := range migrations {
+		if migration.Transport == transport {
+			found = append(found, migration)
+		}
+	}
+
+	return found
+}
+
+// parseRule splits a seed file line into its type and values.
+func parseRule(line string) (string, []string, error) {
+	reader := csv.NewReader(strings.NewReader(line))
+	reader.TrimLeadingSpace = true
+	fields, err := reader.Read()
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid policy rule %q: %w", line, err)
+	}
+	if len(fields) < 2 || fields[0] == "" {
+		return "", nil, fmt.Errorf("invalid policy rule %q", line)
+	}
+
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return fields[0], fields[1:], nil
+}
@@ -0,0 +1,58 @@
+package policy
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+// policyChannel carries the name of a policy that was changed by a replica.
+const policyChannel = "policyUpdates"
+
+type Watcher interface {
+	Publish(context context.Context, name string) error
+	Subscribe(context context.Context, updated func(name string))
+}
+
+type redisWatcher struct {
+	client *redis.Client
+	logger *logger.Logger
+}
+
+func NewRedisWatcher(client *redis.Client, logger *logger.Logger) Watcher {
+	return &redisWatcher{client: client, logger: logger}
+}
+
+func (w *redisWatcher) Publish(context context.Context, name string) error {
+	return w.client.Publish(context, policyChannel, name).Err()
+}
+
+// Subscribe calls updated with the name of every changed policy until the context is done.
+func (w *redisWatcher) Subscribe(context context.Context, updated func(name string)) {
+	subscription := w.client.Subscribe(context, policyChannel)
+	defer subscription.Close()
+
+	messages := subscription.Channel()
+	for {
+		select {
+		case <-context.Done():
+			return
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+			updated(message.Payload)
+		}
+	}
+}
+
+// ReloadOnUpdate reloads a policy whenever a replica publishes a change to it.
+func ReloadOnUpdate(context context.Context, watcher Watcher, enforcers ...Enforcer) {
+	watcher.Subscribe(context, func(name string) {
+		for _, enforcer := range enforcers {
+			if enforcer.Name() == name {
+				_ = enforcer.Reload()
+			}
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package policy
+
+import (
+	"auth-service/domain"
+	"encoding/csv"
+	"strings"
+)
+
+// ResourceChecker is a loaded resource policy.
+type ResourceChecker interface {
+	EnforceResource(sub, obj interface{}, act string) (bool, []string, error)
+}
+
+// resourceSubject is the caller with one of its roles or scopes at a time.
+type resourceSubject struct {
+	Id     string
+	Role   string
+	Status string
+}
+
+// DecideAccess allows the action when a rule for any of the subject's roles holds.
+func DecideAccess(checker ResourceChecker, subject domain.AccessSubject, resource domain.Resource, act string) (Decision, error) {
+	for _, role := range subject.Subjects {
+		ok, rule, err := checker.EnforceResource(resourceSubject{Id: subject.Id, Role: role, Status: subject.Status}, resource, act)
+		if err != nil {
+			return Decision{}, err
+		}
+		if ok {
+			return Decision{Allowed: true, Subject: role, Rule: rule}, nil
+		}
+	}
+	return Decision{}, nil
+}
+
+// CheckResourceRule checks that a resource rule's condition compiles.
+func CheckResourceRule(enforcer Enforcer, rule domain.Policy) error {
+	var line strings.Builder
+	writer := csv.NewWriter(&line)
+	_ = writer.Write(append([]string{domain.PolicyTypePermission}, rule.Values()...))
+	writer.Flush()
+
+	snapshot, err := enforcer.LoadSnapshot(strings.NewReader(line.String()))
+	if err != nil {
+		return err
+	}
+
+	_, err = DecideAccess(snapshot, domain.AccessSubject{Subjects: []string{rule.Subject}}, domain.Resource{Type: rule.Object}, rule.Action)
+	return err
+}
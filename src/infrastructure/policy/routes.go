@@ -0,0 +1,32 @@
+package policy
+
+import (
+	"auth-service/domain"
+	"sync"
+)
+
+// RouteTable holds the routes each transport serves, set once the servers are ready.
+type RouteTable struct {
+	mu     sync.RWMutex
+	routes map[string][]domain.Route
+}
+
+func NewRouteTable() *RouteTable {
+	return &RouteTable{routes: make(map[string][]domain.Route)}
+}
+
+func (t *RouteTable) Set(transport string, routes []domain.Route) {
+	routes = append([]domain.Route(nil), routes...)
+	SortRoutes(routes)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.routes[transport] = routes
+}
+
+func (t *RouteTable) Get(transport string) []domain.Route {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.routes[transport]
+}
@@ -0,0 +1,28 @@
+package policy
+
+import (
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ReloadOnSignal reloads the policies on SIGHUP until the context is done.
+func ReloadOnSignal(context context.Context, logger *logger.Logger, enforcers ...Enforcer) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-context.Done():
+			return
+		case <-signals:
+			logger.Logger.Infof("reloading policies on SIGHUP\n")
+			for _, enforcer := range enforcers {
+				_ = enforcer.Reload()
+			}
+		}
+	}
+}
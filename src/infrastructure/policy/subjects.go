@@ -0,0 +1,28 @@
+package policy
+
+// Decision is the outcome of a request checked for several subjects.
+type Decision struct {
+	Allowed bool
+	Subject string
+	Rule    []string
+}
+
+// EnforceAny allows the request when any of the subjects is allowed.
+func EnforceAny(checker Checker, subjects []string, obj, act string) (bool, error) {
+	decision, err := Decide(checker, subjects, obj, act)
+	return decision.Allowed, err
+}
+
+// Decide is EnforceAny that also tells which subject and rule allowed the request.
+func Decide(checker Checker, subjects []string, obj, act string) (Decision, error) {
+	for _, subject := range subjects {
+		ok, rule, err := checker.EnforceEx(subject, obj, act)
+		if err != nil {
+			return Decision{}, err
+		}
+		if ok {
+			return Decision{Allowed: true, Subject: subject, Rule: rule}, nil
+		}
+	}
+	return Decision{}, nil
+}
@@ -0,0 +1,57 @@
+package rate_limit
+
+import (
+	"auth-service/domain"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/spf13/viper"
+	"os"
+	"time"
+)
+
+type rule struct {
+	Route         string `mapstructure:"route"`
+	Method        string `mapstructure:"method"`
+	Key           string `mapstructure:"key"`
+	Limit         int    `mapstructure:"limit"`
+	WindowSeconds int    `mapstructure:"window_seconds"`
+}
+
+func init_viper(logger *logger.Logger) {
+	if os.Getenv("DOCKER_ENV") != "" {
+		viper.SetConfigFile(`src/configurations/rate_limit.json`)
+	} else {
+		viper.SetConfigFile(`configurations/rate_limit.json`)
+	}
+	err := viper.ReadInConfig()
+	if err != nil {
+		logger.Logger.Fatalf("error while reading rate limit config file, error: %v\n", err)
+	}
+}
+
+func NewRateLimitConfig(logger *logger.Logger) domain.RateLimitConfig {
+	init_viper(logger)
+
+	var rules []rule
+	if err := viper.UnmarshalKey(`rate_limit.rules`, &rules); err != nil {
+		logger.Logger.Fatalf("error while reading rate limit rules, error: %v\n", err)
+	}
+
+	config := domain.RateLimitConfig{Enabled: viper.GetBool(`rate_limit.enabled`)}
+	for _, it := range rules {
+		switch it.Key {
+		case domain.RateLimitByIp, domain.RateLimitByUser, domain.RateLimitByEmail:
+		default:
+			logger.Logger.Fatalf("unknown rate limit key %v for route %v\n", it.Key, it.Route)
+		}
+
+		config.Rules = append(config.Rules, domain.RateLimitRule{
+			Route:  it.Route,
+			Method: it.Method,
+			Key:    it.Key,
+			Limit:  it.Limit,
+			Window: time.Duration(it.WindowSeconds) * time.Second,
+		})
+	}
+
+	return config
+}
@@ -1,6 +1,7 @@
 package saga
 
 import (
+	"auth-service/domain"
 	"auth-service/usecase"
 	"context"
 	"encoding/json"
@@ -11,6 +12,7 @@ import (
 type authSaga struct {
 	profileInfoUsecase usecase.ProfileInfoUsecase
 	registartionUsecase usecase.RegistrationUsecase
+	auditUsecase usecase.AuditUsecase
 	redisClient *redis.Client
 }
 
@@ -19,10 +21,11 @@ type AuthSaga interface {
 	SagaAuth(context context.Context)
 }
 
-func NewAuthSaga(profileInfoUsecase usecase.ProfileInfoUsecase, registrationUsecase usecase.RegistrationUsecase, redisClient *redis.Client) AuthSaga {
+func NewAuthSaga(profileInfoUsecase usecase.ProfileInfoUsecase, registrationUsecase usecase.RegistrationUsecase, auditUsecase usecase.AuditUsecase, redisClient *redis.Client) AuthSaga {
 	return &authSaga{
 		profileInfoUsecase: profileInfoUsecase,
 		registartionUsecase: registrationUsecase,
+		auditUsecase: auditUsecase,
 		redisClient: redisClient,
 	}
 }
@@ -63,7 +66,9 @@ func (a *authSaga) SagaAuth(context context.Context) {
 				}
 				if m.Action == ActionRollback {
 					user := m.Payload
-					if err := a.profileInfoUsecase.DeleteProfileInfo(context, user.Username); err != nil {
+					err := a.profileInfoUsecase.DeleteProfileInfo(context, user.Username)
+					a.auditUsecase.Record(context, domain.AuditActorSystem, user.Username, domain.AuditProfileDeleted, err)
+					if err != nil {
 						continue
 					}
 
@@ -0,0 +1,31 @@
+package security_notification
+
+import (
+	"auth-service/domain"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/spf13/viper"
+	"os"
+	"time"
+)
+
+func init_viper(logger *logger.Logger) {
+	if os.Getenv("DOCKER_ENV") != "" {
+		viper.SetConfigFile(`src/configurations/security_notification.json`)
+	} else {
+		viper.SetConfigFile(`configurations/security_notification.json`)
+	}
+	err := viper.ReadInConfig()
+	if err != nil {
+		logger.Logger.Fatalf("error while reading security notification config file, error: %v\n", err)
+	}
+}
+
+func NewSecurityNotificationConfig(logger *logger.Logger) domain.SecurityNotificationConfig {
+	init_viper(logger)
+
+	return domain.SecurityNotificationConfig{
+		Enabled: viper.GetBool(`security_notification.enabled`),
+		LockUrl: viper.GetString(`security_notification.lock_url`),
+		LockTtl: time.Duration(viper.GetInt(`security_notification.lock_ttl_hours`)) * time.Hour,
+	}
+}
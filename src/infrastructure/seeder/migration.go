@@ -0,0 +1,39 @@
+package seeder
+
+import (
+	"auth-service/domain"
+	"gorm.io/gorm"
+)
+
+const (
+	profileRolesTable = "profile_info_roles"
+	roleScopesTable   = "role_scopes"
+)
+
+// MigrateProfileRoles moves profile role_id columns into profile_info_roles once.
+func MigrateProfileRoles(conn *gorm.DB) error {
+	if !conn.Migrator().HasTable(&domain.ProfileInfo{}) || !conn.Migrator().HasColumn(&domain.ProfileInfo{}, "role_id") {
+		return nil
+	}
+
+	return conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.AutoMigrate(&domain.ProfileInfo{}); err != nil {
+			return err
+		}
+
+		err := tx.Exec(`INSERT INTO ` + profileRolesTable + ` (profile_info_id, role_id)
+			SELECT id, role_id FROM profile_infos WHERE role_id IS NOT NULL AND role_id <> 0
+			ON CONFLICT DO NOTHING`).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Migrator().DropColumn(&domain.ProfileInfo{}, "role_id")
+	})
+}
+
+// BackfillPasswordChangedAt dates untracked passwords from the account's creation.
+func BackfillPasswordChangedAt(conn *gorm.DB) error {
+	return conn.Exec(`UPDATE profile_infos SET password_changed_at = COALESCE(created_at, now())
+		WHERE password_changed_at IS NULL OR password_changed_at = '0001-01-01'`).Error
+}
@@ -6,23 +6,64 @@ import (
 )
 
 func SeedData(gorm *gorm.DB) {
+	gorm.Migrator().DropTable(profileRolesTable)
+	gorm.Migrator().DropTable(roleScopesTable)
 	gorm.Migrator().DropTable(&domain.Role{})
 	gorm.Migrator().DropTable(&domain.ProfileInfo{})
 	gorm.Migrator().DropTable(&domain.TotpSecret{})
+	gorm.Migrator().DropTable(&domain.PasswordHistory{})
+	gorm.Migrator().DropTable(&domain.KnownDevice{})
+	gorm.Migrator().DropTable(&domain.LoginEvent{})
 
+	gorm.AutoMigrate(&domain.Scope{})
+	gorm.AutoMigrate(&domain.OAuthClient{})
 	gorm.AutoMigrate(&domain.Role{})
 	gorm.AutoMigrate(&domain.ProfileInfo{})
 	gorm.AutoMigrate(&domain.TotpSecret{})
+	gorm.AutoMigrate(&domain.PasswordHistory{})
+	gorm.AutoMigrate(&domain.KnownDevice{})
+	gorm.AutoMigrate(&domain.LoginEvent{})
+	// The outbox is not dropped so that undelivered mail survives a restart.
+	gorm.AutoMigrate(&domain.OutboxMessage{})
+	// The audit log is append-only and never dropped.
+	gorm.AutoMigrate(&domain.AuditEntry{})
+	gorm.AutoMigrate(&domain.PolicyRule{})
+	gorm.AutoMigrate(&domain.PolicyMigration{})
 
+	seedScopes(gorm)
 	seedRoles(gorm)
 	seedProfiles(gorm)
 
 }
 
+func seedScopes(gorm *gorm.DB) {
+	scopes := []domain.Scope{
+		{Name: domain.ScopeProfileWrite, Description: "Change the account's email and password"},
+		{Name: domain.ScopeTotpManage, Description: "Enable and disable two-factor authentication"},
+		{Name: domain.ScopeActivityRead, Description: "Read the account's sign-in activity"},
+		{Name: domain.ScopeAgentReview, Description: "Review agent registration requests"},
+		{Name: domain.ScopePolicyDecide, Description: "Ask the policy decision point whether a token is authorized"},
+	}
+
+	for _, scope := range scopes {
+		gorm.Where(domain.Scope{Name: scope.Name}).FirstOrCreate(&scope)
+	}
+	// OAuth clients granted a retired scope simply lose it.
+	gorm.Where("name IN ?", domain.RetiredScopes).Delete(&domain.Scope{})
+}
+
 func seedRoles(gorm *gorm.DB){
-	admin := domain.Role{RoleName: "admin"}
-	agent := domain.Role{RoleName: "agent"}
-	user := domain.Role{RoleName: "user"}
+	var scopes []domain.Scope
+	gorm.Find(&scopes)
+	byName := make(map[string]domain.Scope)
+	for _, scope := range scopes {
+		byName[scope.Name] = scope
+	}
+	userScopes := []domain.Scope{byName[domain.ScopeProfileWrite], byName[domain.ScopeTotpManage], byName[domain.ScopeActivityRead]}
+
+	admin := domain.Role{RoleName: domain.RoleAdmin, Scopes: []domain.Scope{byName[domain.ScopeActivityRead], byName[domain.ScopeAgentReview]}}
+	agent := domain.Role{RoleName: domain.RoleAgent, Scopes: userScopes}
+	user := domain.Role{RoleName: domain.RoleUser, Scopes: userScopes}
 
 	gorm.Create(&admin)
 	gorm.Create(&agent)
@@ -33,16 +74,16 @@ func seedProfiles(gorm *gorm.DB) {
 	var roleUser domain.Role
 	var roleAdmin domain.Role
 	var roleAgent domain.Role
-	gorm.Where("role_name=?", "user").First(&roleUser)
-	gorm.Where("role_name=?", "admin").First(&roleAdmin)
-	gorm.Where("role_name=?", "agent").First(&roleAgent)
+	gorm.Where("role_name=?", domain.RoleUser).First(&roleUser)
+	gorm.Where("role_name=?", domain.RoleAdmin).First(&roleAdmin)
+	gorm.Where("role_name=?", domain.RoleAgent).First(&roleAgent)
 
 	profile1 := domain.ProfileInfo{
 		ID: "e2b5f92e-c31b-11eb-8529-0242ac130003",
 		Email: "alexignjat1998@gmail.com",
 		Username: "user1",
 		Password: "$2y$10$jwbLvrZYHgZN3HFJIV1vFu.lxi6SiiKFzx2B3RItMxruVD8wNPqdS", //user1
-		Role: roleUser,
+		Roles: []domain.Role{roleUser},
 	}
 
 	profile2 := domain.ProfileInfo{
@@ -50,7 +91,7 @@ func seedProfiles(gorm *gorm.DB) {
 		Email: "user2@gmail.com",
 		Username: "user2",
 		Password: "$2y$10$D0LiWoNj3Ej7bnhq4qwX9OfQwI/zW8dJ86M0vMO0uWXw2zpmIs/r.", //user2
-		Role: roleUser,
+		Roles: []domain.Role{roleUser},
 	}
 
 	profile3 := domain.ProfileInfo{
@@ -58,35 +99,35 @@ func seedProfiles(gorm *gorm.DB) {
 		Email: "user3@gmail.com",
 		Username: "user3",
 		Password: "$2y$10$OYT/DOvOVd4ofL2uWvPlbuTGU65SdyhW4vei9dqm5NxIEvrQHCf4C", //user3
-		Role: roleUser,
+		Roles: []domain.Role{roleUser},
 	}
 	profile4 := domain.ProfileInfo{
 		ID : "43420055-3174-4c2a-9823-a8f060d644c3",
 		Email: "user4@gmail.com",
 		Username: "user4",
 		Password: "$2y$10$OYT/DOvOVd4ofL2uWvPlbuTGU65SdyhW4vei9dqm5NxIEvrQHCf4C", //user3
-		Role: roleUser,
+		Roles: []domain.Role{roleUser},
 	}
 	profile5 := domain.ProfileInfo{
 		ID : "ead67925-e71c-43f4-8739-c3b823fe21bb",
 		Email: "user5@gmail.com",
 		Username: "user5",
 		Password: "$2y$10$OYT/DOvOVd4ofL2uWvPlbuTGU65SdyhW4vei9dqm5NxIEvrQHCf4C", //user3
-		Role: roleUser,
+		Roles: []domain.Role{roleUser},
 	}
 	profile6 := domain.ProfileInfo{
 		ID : "23ddb1dd-4303-428b-b506-ff313071d5d7",
 		Email: "user6@gmail.com",
 		Username: "user6",
 		Password: "$2y$10$OYT/DOvOVd4ofL2uWvPlbuTGU65SdyhW4vei9dqm5NxIEvrQHCf4C", //user3
-		Role: roleUser,
+		Roles: []domain.Role{roleUser},
 	}
 	admin := domain.ProfileInfo{
 		Email: "admin1@gmail.com",
 		ID : "bdb7d7c5-2c9a-4b4c-ab64-4e4828d93926",
 		Username: "admin1",
 		Password: "$2y$10$6KqgPNO9RrBRKCx8ZKyzKu/oorCnraEEovjMIa9FHlxRhb5tNhQOe", //admin1
-		Role: roleAdmin,
+		Roles: []domain.Role{roleAdmin},
 	}
 
 	agent := domain.ProfileInfo{
@@ -94,7 +135,7 @@ func seedProfiles(gorm *gorm.DB) {
 		ID : "1d09bb0a-d9fc-11eb-b8bc-0242ac130003",
 		Username: "agent1",
 		Password: "$2y$12$fbhWKmsyK8UKF28N6AKtEeyK12ziEcMI69pWSTCXcunl5fM/x31GK", //agent1
-		Role: roleAgent,
+		Roles: []domain.Role{roleAgent},
 	}
 
 	gorm.Create(&profile1)
@@ -1,9 +1,14 @@
 package interactor
 
 import (
+	"auth-service/assets/mail_template"
+	"auth-service/domain"
 	"auth-service/gateway"
 	"auth-service/grpc/server/authentication_server/implementation"
+	"auth-service/helper"
 	"auth-service/http/handler"
+	"auth-service/infrastructure/mailer"
+	"auth-service/infrastructure/policy"
 	"auth-service/infrastructure/saga"
 	"auth-service/infrastructure/tracer"
 	"auth-service/repository"
@@ -28,24 +33,81 @@ type interactor struct {
 	RedisClient *redis.Client
 	SagaRedisClient *redis.Client
 	Orchestrator saga.Orchestrator
+	PasswordPolicy domain.PasswordPolicy
+	BreachedPasswords *helper.BloomFilter
+	MagicLink domain.MagicLinkConfig
+	EmailChange domain.EmailChangeConfig
+	Mailer mailer.Mailer
+	Outbox domain.OutboxConfig
+	MailTemplates *mail_template.Registry
+	SecurityNotification domain.SecurityNotificationConfig
+	SecurityEvents usecase.SecurityEventUsecase
+	BruteForce domain.BruteForceConfig
+	RateLimit domain.RateLimitConfig
+	Impersonation domain.ImpersonationConfig
+	Pdp domain.PdpConfig
+	HttpEnforcer policy.Enforcer
+	GrpcEnforcer policy.Enforcer
+	ResourceEnforcer policy.Enforcer
+	Routes *policy.RouteTable
 }
 
 type Interactor interface {
 	NewProfileInfoRepository() repository.ProfileInfoRepository
 	NewRoleRepository() repository.RoleRepository
+	NewScopeRepository() repository.ScopeRepository
+	NewOAuthClientRepository() repository.OAuthClientRepository
 	NewTotpRepository() repository.TotpRepository
+	NewPasswordHistoryRepository() repository.PasswordHistoryRepository
+	NewOutboxRepository() repository.OutboxRepository
+	NewKnownDeviceRepository() repository.KnownDeviceRepository
+	NewLoginEventRepository() repository.LoginEventRepository
+	NewAuditLogRepository() repository.AuditLogRepository
 
 	NewRedisUsecase() usecase.RedisUsecase
 	NewAuthenticationUsecase() usecase.AuthenticationUsecase
 	NewJwtUsecase() usecase.JwtUsecase
 	NewProfileInfoUsecase() usecase.ProfileInfoUsecase
+	NewSessionUsecase() usecase.SessionUsecase
 	NewRegistrationUsecase() usecase.RegistrationUsecase
 	NewTotpUsecase() usecase.TotpUsecase
+	NewPasswordPolicyUsecase() usecase.PasswordPolicyUsecase
+	NewMagicLinkUsecase() usecase.MagicLinkUsecase
+	NewEmailChangeUsecase() usecase.EmailChangeUsecase
+	NewMailUsecase() usecase.MailUsecase
+	NewOutboxUsecase() usecase.OutboxUsecase
+	NewSecurityEventUsecase() usecase.SecurityEventUsecase
+	NewKnownDeviceUsecase() usecase.KnownDeviceUsecase
+	NewSecurityNotificationUsecase() usecase.SecurityNotificationUsecase
+	NewBruteForceUsecase() usecase.BruteForceUsecase
+	NewRateLimitUsecase() usecase.RateLimitUsecase
+	NewLoginEventUsecase() usecase.LoginEventUsecase
+	NewAuditUsecase() usecase.AuditUsecase
+	NewAccountStatusUsecase() usecase.AccountStatusUsecase
+	NewImpersonationUsecase() usecase.ImpersonationUsecase
+	NewPolicyUsecase() usecase.PolicyUsecase
+	NewAccessUsecase() usecase.AccessUsecase
+	NewRoleUsecase() usecase.RoleUsecase
+	NewAuthorizationUsecase() usecase.AuthorizationUsecase
+	NewScopeUsecase() usecase.ScopeUsecase
+	NewOAuthClientUsecase() usecase.OAuthClientUsecase
 
 	NewAppHandler() AppHandler
 	NewAuthenticationHandler() handler.AuthenticationHandler
 	NewRegistrationHandler() handler.RegistrationHandler
 	NewTotpHandler() handler.TotpHandler
+	NewEmailChangeHandler() handler.EmailChangeHandler
+	NewOutboxHandler() handler.OutboxHandler
+	NewAccountLockHandler() handler.AccountLockHandler
+	NewLoginEventHandler() handler.LoginEventHandler
+	NewAuditHandler() handler.AuditHandler
+	NewAccountStatusHandler() handler.AccountStatusHandler
+	NewImpersonationHandler() handler.ImpersonationHandler
+	NewPolicyHandler() handler.PolicyHandler
+	NewRoleHandler() handler.RoleHandler
+	NewAuthorizationHandler() handler.AuthorizationHandler
+	NewScopeHandler() handler.ScopeHandler
+	NewOAuthHandler() handler.OAuthHandler
 
 	NewUserGateway() gateway.UserGateway
 
@@ -57,15 +119,39 @@ type appHandler struct {
 	handler.AuthenticationHandler
 	handler.RegistrationHandler
 	handler.TotpHandler
+	handler.EmailChangeHandler
+	handler.OutboxHandler
+	handler.AccountLockHandler
+	handler.LoginEventHandler
+	handler.AuditHandler
+	handler.AccountStatusHandler
+	handler.ImpersonationHandler
+	handler.PolicyHandler
+	handler.RoleHandler
+	handler.AuthorizationHandler
+	handler.ScopeHandler
+	handler.OAuthHandler
 }
 
 type AppHandler interface {
 	handler.AuthenticationHandler
 	handler.RegistrationHandler
 	handler.TotpHandler
+	handler.EmailChangeHandler
+	handler.OutboxHandler
+	handler.AccountLockHandler
+	handler.LoginEventHandler
+	handler.AuditHandler
+	handler.AccountStatusHandler
+	handler.ImpersonationHandler
+	handler.PolicyHandler
+	handler.RoleHandler
+	handler.AuthorizationHandler
+	handler.ScopeHandler
+	handler.OAuthHandler
 }
 
-func NewInteractor(conn *gorm.DB, logger *logger.Logger, redisClient *redis.Client, sagaRedisClient *redis.Client, orchestrator saga.Orchestrator) Interactor {
+func NewInteractor(conn *gorm.DB, logger *logger.Logger, redisClient *redis.Client, sagaRedisClient *redis.Client, orchestrator saga.Orchestrator, passwordPolicy domain.PasswordPolicy, breachedPasswords *helper.BloomFilter, magicLink domain.MagicLinkConfig, emailChange domain.EmailChangeConfig, mailer mailer.Mailer, outbox domain.OutboxConfig, mailTemplates *mail_template.Registry, securityNotification domain.SecurityNotificationConfig, bruteForce domain.BruteForceConfig, rateLimit domain.RateLimitConfig, impersonation domain.ImpersonationConfig, pdp domain.PdpConfig, httpEnforcer policy.Enforcer, grpcEnforcer policy.Enforcer, resourceEnforcer policy.Enforcer, routes *policy.RouteTable) Interactor {
 	tracer, closer := tracer.Init(tracing_name)
 	opentracing.SetGlobalTracer(tracer)
 	return &interactor{
@@ -76,6 +162,23 @@ func NewInteractor(conn *gorm.DB, logger *logger.Logger, redisClient *redis.Clie
 		RedisClient: redisClient,
 		SagaRedisClient: sagaRedisClient,
 		Orchestrator: orchestrator,
+		PasswordPolicy: passwordPolicy,
+		BreachedPasswords: breachedPasswords,
+		MagicLink: magicLink,
+		EmailChange: emailChange,
+		Mailer: mailer,
+		Outbox: outbox,
+		MailTemplates: mailTemplates,
+		SecurityNotification: securityNotification,
+		SecurityEvents: usecase.NewSecurityEventUsecase(logger),
+		BruteForce: bruteForce,
+		RateLimit: rateLimit,
+		Impersonation: impersonation,
+		Pdp: pdp,
+		HttpEnforcer: httpEnforcer,
+		GrpcEnforcer: grpcEnforcer,
+		ResourceEnforcer: resourceEnforcer,
+		Routes: routes,
 	}
 }
 
@@ -84,16 +187,56 @@ func (i *interactor) NewAppHandler() AppHandler {
 	appHandler.AuthenticationHandler = i.NewAuthenticationHandler()
 	appHandler.RegistrationHandler = i.NewRegistrationHandler()
 	appHandler.TotpHandler = i.NewTotpHandler()
+	appHandler.EmailChangeHandler = i.NewEmailChangeHandler()
+	appHandler.OutboxHandler = i.NewOutboxHandler()
+	appHandler.AccountLockHandler = i.NewAccountLockHandler()
+	appHandler.LoginEventHandler = i.NewLoginEventHandler()
+	appHandler.AuditHandler = i.NewAuditHandler()
+	appHandler.AccountStatusHandler = i.NewAccountStatusHandler()
+	appHandler.ImpersonationHandler = i.NewImpersonationHandler()
+	appHandler.PolicyHandler = i.NewPolicyHandler()
+	appHandler.RoleHandler = i.NewRoleHandler()
+	appHandler.AuthorizationHandler = i.NewAuthorizationHandler()
+	appHandler.ScopeHandler = i.NewScopeHandler()
+	appHandler.OAuthHandler = i.NewOAuthHandler()
 	return appHandler
 }
 func (i *interactor) NewProfileInfoRepository() repository.ProfileInfoRepository {
 	return repository.NewProfileInfoRepository(i.Conn, i.logger)
 }
 
+func (i *interactor) NewPasswordHistoryRepository() repository.PasswordHistoryRepository {
+	return repository.NewPasswordHistoryRepository(i.Conn, i.logger)
+}
+
+func (i *interactor) NewOutboxRepository() repository.OutboxRepository {
+	return repository.NewOutboxRepository(i.Conn, i.logger)
+}
+
+func (i *interactor) NewKnownDeviceRepository() repository.KnownDeviceRepository {
+	return repository.NewKnownDeviceRepository(i.Conn, i.logger)
+}
+
+func (i *interactor) NewAuditLogRepository() repository.AuditLogRepository {
+	return repository.NewAuditLogRepository(i.Conn, i.logger)
+}
+
+func (i *interactor) NewLoginEventRepository() repository.LoginEventRepository {
+	return repository.NewLoginEventRepository(i.Conn, i.logger)
+}
+
 func (i *interactor) NewRoleRepository() repository.RoleRepository {
 	return repository.NewRoleRepository(i.Conn, i.logger)
 }
 
+func (i *interactor) NewScopeRepository() repository.ScopeRepository {
+	return repository.NewScopeRepository(i.Conn, i.logger)
+}
+
+func (i *interactor) NewOAuthClientRepository() repository.OAuthClientRepository {
+	return repository.NewOAuthClientRepository(i.Conn, i.logger)
+}
+
 func (i *interactor) NewRedisUsecase() usecase.RedisUsecase {
 	return usecase.NewRedisUsecase(i.RedisClient, i.logger)
 }
@@ -103,24 +246,24 @@ func (i *interactor) NewAuthenticationUsecase() usecase.AuthenticationUsecase {
 }
 
 func (i *interactor) NewJwtUsecase() usecase.JwtUsecase {
-	return usecase.NewJwtUsecase(i.NewRedisUsecase(), i.logger, i.NewAuthenticationUsecase())
+	return usecase.NewJwtUsecase(i.NewRedisUsecase(), i.logger, i.NewAuthenticationUsecase(), i.NewRoleRepository())
 }
 
 func (i *interactor) NewAuthenticationHandler() handler.AuthenticationHandler {
 
-	return handler.NewAuthenticationHandler(i.NewAuthenticationUsecase(), i.NewJwtUsecase(), i.NewProfileInfoUsecase(), i.Tracer, i.NewRedisUsecase(), i.NewTotpUsecase(), i.logger)
+	return handler.NewAuthenticationHandler(i.NewAuthenticationUsecase(), i.NewProfileInfoUsecase(), i.Tracer, i.NewMagicLinkUsecase(), i.NewSecurityEventUsecase(), i.NewAuditUsecase(), i.NewAccessUsecase(), i.NewAuthenticationServiceImpl(), i.logger)
 }
 
 func (i *interactor) NewProfileInfoUsecase() usecase.ProfileInfoUsecase {
-	return usecase.NewProfileInfoUsecase(i.NewProfileInfoRepository(), i.NewRedisUsecase(), i.logger)
+	return usecase.NewProfileInfoUsecase(i.NewProfileInfoRepository(), i.NewPasswordHistoryRepository(), i.NewRedisUsecase(), i.NewPasswordPolicyUsecase(), i.NewSecurityEventUsecase(), i.NewBruteForceUsecase(), i.NewMailUsecase(), i.logger)
 }
 
 func (i *interactor) NewRegistrationUsecase() usecase.RegistrationUsecase {
-	return usecase.NewRegistrationUsecase(i.NewRedisUsecase(), i.NewProfileInfoUsecase(), i.NewUserGateway(), i.logger)
+	return usecase.NewRegistrationUsecase(i.NewRedisUsecase(), i.NewProfileInfoUsecase(), i.NewUserGateway(), i.NewPasswordPolicyUsecase(), i.NewMailUsecase(), i.NewBruteForceUsecase(), i.NewRoleRepository(), i.logger)
 }
 
 func (i *interactor) NewRegistrationHandler() handler.RegistrationHandler {
-	return handler.NewRegistrationHandler(i.NewRegistrationUsecase(), i.logger, i.Orchestrator)
+	return handler.NewRegistrationHandler(i.NewRegistrationUsecase(), i.NewAuditUsecase(), i.logger, i.Orchestrator)
 }
 
 func (i *interactor) NewUserGateway() gateway.UserGateway {
@@ -136,14 +279,147 @@ func (i *interactor) NewTotpUsecase() usecase.TotpUsecase {
 	return usecase.NewTotpUsecase(i.NewTotpRepository(), i.NewRedisUsecase(), i.NewProfileInfoUsecase(), i.logger)
 }
 
+func (i *interactor) NewPasswordPolicyUsecase() usecase.PasswordPolicyUsecase {
+	return usecase.NewPasswordPolicyUsecase(i.PasswordPolicy, i.BreachedPasswords, i.logger)
+}
+
+func (i *interactor) NewMagicLinkUsecase() usecase.MagicLinkUsecase {
+	return usecase.NewMagicLinkUsecase(i.MagicLink, i.NewRedisUsecase(), i.NewProfileInfoUsecase(), i.NewMailUsecase(), i.logger)
+}
+
+func (i *interactor) NewEmailChangeUsecase() usecase.EmailChangeUsecase {
+	return usecase.NewEmailChangeUsecase(i.EmailChange, i.NewProfileInfoRepository(), i.NewProfileInfoUsecase(), i.NewRedisUsecase(), i.NewAuthenticationUsecase(), i.NewUserGateway(), i.NewMailUsecase(), i.NewSecurityEventUsecase(), i.NewBruteForceUsecase(), i.NewAuditUsecase(), i.logger)
+}
+
+func (i *interactor) NewMailUsecase() usecase.MailUsecase {
+	return usecase.NewMailUsecase(i.NewOutboxUsecase(), i.MailTemplates, i.NewProfileInfoRepository(), i.logger)
+}
+
+func (i *interactor) NewOutboxUsecase() usecase.OutboxUsecase {
+	return usecase.NewOutboxUsecase(i.Outbox, i.NewOutboxRepository(), i.Mailer, i.logger)
+}
+
+// NewSecurityEventUsecase returns the event bus shared by publishers and subscribers.
+func (i *interactor) NewSecurityEventUsecase() usecase.SecurityEventUsecase {
+	return i.SecurityEvents
+}
+
+func (i *interactor) NewAuditUsecase() usecase.AuditUsecase {
+	return usecase.NewAuditUsecase(i.NewAuditLogRepository(), i.logger)
+}
+
+func (i *interactor) NewAccountStatusUsecase() usecase.AccountStatusUsecase {
+	return usecase.NewAccountStatusUsecase(i.NewProfileInfoRepository(), i.NewAuthenticationUsecase(), i.NewAuditUsecase(), i.logger)
+}
+
+func (i *interactor) NewImpersonationUsecase() usecase.ImpersonationUsecase {
+	return usecase.NewImpersonationUsecase(i.Impersonation, i.NewProfileInfoRepository(), i.NewJwtUsecase(), i.NewAuthenticationUsecase(), i.NewAuditUsecase(), i.logger)
+}
+
+func (i *interactor) NewSessionUsecase() usecase.SessionUsecase {
+	return usecase.NewSessionUsecase(i.NewJwtUsecase(), i.NewAuthenticationUsecase(), i.NewProfileInfoUsecase(), i.logger)
+}
+
+func (i *interactor) NewLoginEventUsecase() usecase.LoginEventUsecase {
+	return usecase.NewLoginEventUsecase(i.NewLoginEventRepository(), i.logger)
+}
+
+func (i *interactor) NewKnownDeviceUsecase() usecase.KnownDeviceUsecase {
+	return usecase.NewKnownDeviceUsecase(i.NewKnownDeviceRepository(), i.NewSecurityEventUsecase(), i.logger)
+}
+
+func (i *interactor) NewSecurityNotificationUsecase() usecase.SecurityNotificationUsecase {
+	return usecase.NewSecurityNotificationUsecase(i.SecurityNotification, i.NewProfileInfoRepository(), i.NewRedisUsecase(), i.NewAuthenticationUsecase(), i.NewMailUsecase(), i.NewAuditUsecase(), i.logger)
+}
+
+func (i *interactor) NewBruteForceUsecase() usecase.BruteForceUsecase {
+	return usecase.NewBruteForceUsecase(i.BruteForce, i.NewRedisUsecase(), i.logger)
+}
+
+func (i *interactor) NewRateLimitUsecase() usecase.RateLimitUsecase {
+	return usecase.NewRateLimitUsecase(i.RateLimit, i.NewRedisUsecase(), i.logger)
+}
+
+func (i *interactor) NewAccountLockHandler() handler.AccountLockHandler {
+	return handler.NewAccountLockHandler(i.NewSecurityNotificationUsecase(), i.Tracer, i.logger)
+}
+
+func (i *interactor) NewAccountStatusHandler() handler.AccountStatusHandler {
+	return handler.NewAccountStatusHandler(i.NewAccountStatusUsecase(), i.Tracer, i.logger)
+}
+
+func (i *interactor) NewPolicyUsecase() usecase.PolicyUsecase {
+	return usecase.NewPolicyUsecase([]policy.Enforcer{i.HttpEnforcer, i.GrpcEnforcer, i.ResourceEnforcer}, i.Routes, i.NewAuditUsecase(), i.logger)
+}
+
+func (i *interactor) NewAccessUsecase() usecase.AccessUsecase {
+	return usecase.NewAccessUsecase(i.ResourceEnforcer, i.NewProfileInfoRepository(), i.logger)
+}
+
+func (i *interactor) NewPolicyHandler() handler.PolicyHandler {
+	return handler.NewPolicyHandler(i.NewPolicyUsecase(), i.Tracer, i.logger)
+}
+
+func (i *interactor) NewRoleUsecase() usecase.RoleUsecase {
+	return usecase.NewRoleUsecase(i.NewProfileInfoRepository(), i.NewRoleRepository(), i.NewAuthenticationUsecase(), i.NewAuditUsecase(), i.logger)
+}
+
+func (i *interactor) NewRoleHandler() handler.RoleHandler {
+	return handler.NewRoleHandler(i.NewRoleUsecase(), i.Tracer, i.logger)
+}
+
+func (i *interactor) NewAuthorizationUsecase() usecase.AuthorizationUsecase {
+	return usecase.NewAuthorizationUsecase(i.Pdp, []policy.Enforcer{i.HttpEnforcer, i.GrpcEnforcer}, i.NewAuthenticationUsecase(), i.logger)
+}
+
+func (i *interactor) NewAuthorizationHandler() handler.AuthorizationHandler {
+	return handler.NewAuthorizationHandler(i.NewAuthorizationUsecase(), i.Tracer, i.logger)
+}
+
+func (i *interactor) NewScopeUsecase() usecase.ScopeUsecase {
+	return usecase.NewScopeUsecase(i.NewScopeRepository(), i.NewRoleRepository(), i.NewAuditUsecase(), i.logger)
+}
+
+func (i *interactor) NewScopeHandler() handler.ScopeHandler {
+	return handler.NewScopeHandler(i.NewScopeUsecase(), i.Tracer, i.logger)
+}
+
+func (i *interactor) NewOAuthClientUsecase() usecase.OAuthClientUsecase {
+	return usecase.NewOAuthClientUsecase(i.NewOAuthClientRepository(), i.NewScopeRepository(), i.NewJwtUsecase(), i.NewAuthenticationUsecase(), i.NewBruteForceUsecase(), i.NewAuditUsecase(), i.logger)
+}
+
+func (i *interactor) NewOAuthHandler() handler.OAuthHandler {
+	return handler.NewOAuthHandler(i.NewOAuthClientUsecase(), i.Tracer, i.logger)
+}
+
+func (i *interactor) NewImpersonationHandler() handler.ImpersonationHandler {
+	return handler.NewImpersonationHandler(i.NewImpersonationUsecase(), i.Tracer, i.logger)
+}
+
+func (i *interactor) NewAuditHandler() handler.AuditHandler {
+	return handler.NewAuditHandler(i.NewAuditUsecase(), i.Tracer, i.logger)
+}
+
+func (i *interactor) NewLoginEventHandler() handler.LoginEventHandler {
+	return handler.NewLoginEventHandler(i.NewLoginEventUsecase(), i.Tracer, i.logger)
+}
+
+func (i *interactor) NewOutboxHandler() handler.OutboxHandler {
+	return handler.NewOutboxHandler(i.NewOutboxUsecase(), i.NewAuditUsecase(), i.logger)
+}
+
+func (i *interactor) NewEmailChangeHandler() handler.EmailChangeHandler {
+	return handler.NewEmailChangeHandler(i.NewEmailChangeUsecase(), i.Tracer, i.logger)
+}
+
 func (i *interactor) NewTotpHandler() handler.TotpHandler {
-	return handler.NewTotpHandler(i.NewTotpUsecase(), i.Tracer, i.NewProfileInfoUsecase(), i.logger)
+	return handler.NewTotpHandler(i.NewTotpUsecase(), i.Tracer, i.NewProfileInfoUsecase(), i.NewSecurityEventUsecase(), i.NewBruteForceUsecase(), i.NewAuditUsecase(), i.logger)
 }
 
 func (i *interactor) NewAuthenticationServiceImpl() *implementation.AuthenticationServer {
-	return implementation.NewAuthenticationServiceImpl(i.NewProfileInfoUsecase(), i.NewTotpUsecase(), i.NewJwtUsecase(), i.NewAuthenticationUsecase(), i.NewRedisUsecase())
+	return implementation.NewAuthenticationServiceImpl(i.NewProfileInfoUsecase(), i.NewTotpUsecase(), i.NewJwtUsecase(), i.NewAuthenticationUsecase(), i.NewRedisUsecase(), i.NewMailUsecase(), i.NewKnownDeviceUsecase(), i.NewBruteForceUsecase(), i.NewLoginEventUsecase(), i.NewAuditUsecase(), i.NewAccountStatusUsecase(), i.NewPolicyUsecase(), i.NewRoleUsecase(), i.NewAuthorizationUsecase(), i.NewRegistrationUsecase(), i.Orchestrator, i.NewMagicLinkUsecase(), i.NewEmailChangeUsecase(), i.NewSecurityNotificationUsecase(), i.NewSecurityEventUsecase(), i.NewAccessUsecase(), i.NewImpersonationUsecase(), i.NewScopeUsecase(), i.NewOAuthClientUsecase(), i.NewOutboxUsecase(), i.NewSessionUsecase())
 }
 
 func (i *interactor) NewTotpServiceImpl() *totp_implementation.TotpServer {
-	return totp_implementation.NewTotpServer(i.NewTotpUsecase(), i.NewProfileInfoUsecase())
+	return totp_implementation.NewTotpServer(i.NewTotpUsecase(), i.NewProfileInfoUsecase(), i.NewSecurityEventUsecase(), i.NewBruteForceUsecase(), i.NewAuthenticationUsecase(), i.NewAuditUsecase())
 }
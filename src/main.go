@@ -1,15 +1,34 @@
 package main
 
 import (
+	"auth-service/assets/mail_template"
+	"auth-service/domain"
+	"auth-service/grpc/gateway"
+	helper2 "auth-service/grpc/helper"
 	"auth-service/grpc/interceptor/auth_interceptor"
+	"auth-service/grpc/interceptor/error_interceptor"
+	"auth-service/grpc/interceptor/rate_limit_interceptor"
 	"auth-service/grpc/server/authentication_server"
 	"auth-service/http/middleware"
 	router2 "auth-service/http/router"
+	"auth-service/infrastructure/brute_force"
+	"auth-service/infrastructure/client_ip"
+	"auth-service/infrastructure/email_change"
+	"auth-service/infrastructure/impersonation"
+	"auth-service/infrastructure/pdp"
+	"auth-service/infrastructure/magic_link"
+	"auth-service/infrastructure/mailer"
+	"auth-service/infrastructure/outbox"
+	"auth-service/infrastructure/password_policy"
+	"auth-service/infrastructure/policy"
 	"auth-service/infrastructure/postgresqldb"
+	"auth-service/infrastructure/rate_limit"
 	"auth-service/infrastructure/redisdb"
 	"auth-service/infrastructure/saga"
 	"auth-service/infrastructure/saga_redisdb"
+	"auth-service/infrastructure/security_notification"
 	"auth-service/infrastructure/seeder"
+	"auth-service/helper"
 	interactor2 "auth-service/interactor"
 	"context"
 	"github.com/gin-gonic/gin"
@@ -17,6 +36,7 @@ import (
 	"google.golang.org/grpc"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"strconv"
 )
@@ -43,7 +63,13 @@ func main() {
 	logger := logger.InitializeLogger("auth-service", context.Background())
 	postgreConn := postgresqldb.NewDBConnection(logger)
 	redisClient := redisdb.NewReddisConn(logger)
+	if err := seeder.MigrateProfileRoles(postgreConn); err != nil {
+		logger.Logger.Fatalf("error while migrating profile roles, error: %v\n", err)
+	}
 	seeder.SeedData(postgreConn)
+	if err := seeder.BackfillPasswordChangedAt(postgreConn); err != nil {
+		logger.Logger.Fatalf("error while backfilling password ages, error: %v\n", err)
+	}
 	redisClient.FlushAll(context.Background())
 	sagaRedisClient := saga_redisdb.NewSagaRedis(logger)
 	sagaRedisClient.FlushAll(context.Background())
@@ -51,47 +77,98 @@ func main() {
 	orchestrator := saga.NewOrchestrator(context.Background(), sagaRedisClient)
 	go orchestrator.Start(context.Background())
 
-	interactor := interactor2.NewInteractor(postgreConn, logger, redisClient, sagaRedisClient, orchestrator)
+	passwordPolicy := password_policy.NewPasswordPolicy(logger)
+	breachedPasswords := password_policy.NewBreachedPasswordFilter(passwordPolicy.BreachedPasswordsFile, logger)
+
+	magicLink := magic_link.NewMagicLinkConfig(logger)
+	emailChange := email_change.NewEmailChangeConfig(logger)
+	mailer := mailer.NewMailer(logger)
+	outboxConfig := outbox.NewOutboxConfig(logger)
+	securityNotification := security_notification.NewSecurityNotificationConfig(logger)
+	bruteForce := brute_force.NewBruteForceConfig(logger)
+	rateLimit := rate_limit.NewRateLimitConfig(logger)
+	impersonationConfig := impersonation.NewImpersonationConfig(logger)
+	pdpConfig := pdp.NewPdpConfig(logger)
+	policyWatcher := policy.NewRedisWatcher(redisClient, logger)
+	httpEnforcer := policy.NewEnforcer(domain.PolicyTransportHttp, "http/middleware/rbac_model.conf", "http/middleware/rbac_policy.csv", policy.NewGormAdapter(postgreConn, domain.PolicyTransportHttp), policyWatcher, logger)
+	grpcEnforcer := policy.NewEnforcer(domain.PolicyTransportGrpc, "grpc/interceptor/auth_interceptor/rbac_model.conf", "grpc/interceptor/auth_interceptor/rbac_policy.csv", policy.NewGormAdapter(postgreConn, domain.PolicyTransportGrpc), policyWatcher, logger)
+	resourceEnforcer := policy.NewEnforcer(domain.PolicyTransportResource, "infrastructure/policy/resource_model.conf", "infrastructure/policy/resource_policy.csv", policy.NewGormAdapter(postgreConn, domain.PolicyTransportResource), policyWatcher, logger)
+	go httpEnforcer.Watch(context.Background())
+	go grpcEnforcer.Watch(context.Background())
+	go resourceEnforcer.Watch(context.Background())
+	go policy.ReloadOnSignal(context.Background(), logger, httpEnforcer, grpcEnforcer, resourceEnforcer)
+	go policy.ReloadOnUpdate(context.Background(), policyWatcher, httpEnforcer, grpcEnforcer, resourceEnforcer)
+	routes := policy.NewRouteTable()
+	trustedProxies := client_ip.NewTrustedProxies(logger)
+	port := uint(8079)
+	lis := getNetListener(port)
+	// the REST gateway dials the gRPC server from its own address
+	gatewayHost, _, _ := net.SplitHostPort(lis.Addr().String())
+	if err := helper.SetTrustedProxies(append([]string{gatewayHost}, trustedProxies...)); err != nil {
+		logger.Logger.Fatalf("error while parsing trusted proxies, error: %v\n", err)
+	}
+	mailTemplates, err := mail_template.NewRegistry()
+	if err != nil {
+		logger.Logger.Fatalf("error while loading mail templates, error: %v\n", err)
+	}
+
+	interactor := interactor2.NewInteractor(postgreConn, logger, redisClient, sagaRedisClient, orchestrator, passwordPolicy, breachedPasswords, magicLink, emailChange, mailer, outboxConfig, mailTemplates, securityNotification, bruteForce, rateLimit, impersonationConfig, pdpConfig, httpEnforcer, grpcEnforcer, resourceEnforcer, routes)
 	appHandler := interactor.NewAppHandler()
 
+	go interactor.NewOutboxUsecase().Run(context.Background())
+	interactor.NewSecurityEventUsecase().Subscribe(interactor.NewSecurityNotificationUsecase().Notify)
 
-	router := router2.NewRouter(appHandler, logger)
+
+	router := router2.NewRouter(appHandler, interactor.NewAuthenticationUsecase(), httpEnforcer, interactor.NewRateLimitUsecase(), logger)
 	router.Use(gin.Logger())
 	router.Use(middleware.CORSMiddleware())
+	routes.Set(domain.PolicyTransportHttp, router2.Routes(router))
 
 
-	authSaga := saga.NewAuthSaga(interactor.NewProfileInfoUsecase(), interactor.NewRegistrationUsecase(), sagaRedisClient)
+	authSaga := saga.NewAuthSaga(interactor.NewProfileInfoUsecase(), interactor.NewRegistrationUsecase(), interactor.NewAuditUsecase(), sagaRedisClient)
 	go authSaga.SagaAuth(context.Background())
 
 
-	port := uint(8079)
-	lis := getNetListener(port)
 	/*creds, err := credentials.NewServerTLSFromFile("src/certificate/cert.pem", "src/certificate/key.pem")
 	if err != nil {
 		panic(err)
 	}*/
 
-	a := auth_interceptor.NewAuthUnaryInterceptor(interactor.NewAuthenticationUsecase())
+	a := auth_interceptor.NewAuthUnaryInterceptor(interactor.NewAuthenticationUsecase(), grpcEnforcer, logger)
+
+	r := rate_limit_interceptor.NewRateLimitUnaryInterceptor(interactor.NewRateLimitUsecase(), interactor.NewAuthenticationUsecase())
 
-	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(a.UnaryAuthorizationInterceptor))
+	e := error_interceptor.NewErrorUnaryInterceptor(logger)
+
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(e.UnaryErrorInterceptor, a.UnaryAuthorizationInterceptor, r.UnaryRateLimitInterceptor),
+		grpc.ChainStreamInterceptor(e.StreamErrorInterceptor, a.StreamAuthorizationInterceptor))
 	loginServiceImpl := interactor.NewAuthenticationServiceImpl()
 	totpServiceImpl := interactor.NewTotpServiceImpl()
 
 	authentication_server.RegisterAuthenticationServer(grpcServer, loginServiceImpl)
 	authentication_server.RegisterTotpServer(grpcServer, totpServiceImpl)
+	routes.Set(domain.PolicyTransportGrpc, helper2.Routes(grpcServer))
 	go func() {
 		log.Fatalln(grpcServer.Serve(lis))
 	}()
 
+	gatewayHandler, err := gateway.NewHandler(context.Background(), lis.Addr().String())
+	if err != nil {
+		logger.Logger.Fatalf("error while registering the REST gateway, error: %v\n", err)
+	}
+	httpHandler := http.NewServeMux()
+	httpHandler.Handle(gateway.Prefix, gatewayHandler)
+	httpHandler.Handle("/", router)
+
 	logger.Logger.Info("server auth-service listening on port ", port)
 	//logger.Logger.Info("server auth-service listening on port 8091")
 	if os.Getenv("DOCKER_ENV") == "" {
-		err := router.RunTLS(":8091", "certificate/cert.pem", "certificate/key.pem")
+		err := http.ListenAndServeTLS(":8091", "certificate/cert.pem", "certificate/key.pem", httpHandler)
 		if err != nil {
 			return
 		}
 	} else {
-		err := router.Run(":8091")
+		err := http.ListenAndServe(":8091", httpHandler)
 		if err != nil {
 			return
 		}
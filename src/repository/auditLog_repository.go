@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/tracer"
+	"context"
+	"errors"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"gorm.io/gorm"
+	"time"
+)
+
+// auditLockKey is the advisory lock that serializes appends to the chain.
+const auditLockKey = 4242038
+
+type auditLogRepository struct {
+	Conn *gorm.DB
+	logger *logger.Logger
+}
+
+type AuditLogRepository interface {
+	Append(context context.Context, entry *domain.AuditEntry) error
+	Iterate(context context.Context, afterId uint, batchSize int, process func(entry domain.AuditEntry) error) error
+}
+
+func NewAuditLogRepository(conn *gorm.DB, logger *logger.Logger) AuditLogRepository {
+	return &auditLogRepository{Conn: conn, logger: logger}
+}
+
+// Append links the entry to the last one in the chain and stores it.
+func (a *auditLogRepository) Append(context context.Context, entry *domain.AuditEntry) error {
+	span := tracer.StartSpanFromContext(context, "repository/AppendAuditEntry")
+	defer span.Finish()
+
+	err := a.Conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditLockKey).Error; err != nil {
+			return err
+		}
+
+		var last domain.AuditEntry
+		err := tx.Order("id desc").Take(&last).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			entry.ID = 1
+			entry.PrevHash = domain.AuditGenesisHash
+		case err != nil:
+			return err
+		default:
+			entry.ID = last.ID + 1
+			entry.PrevHash = last.Hash
+		}
+
+		// Postgres keeps microseconds, the hash has to survive a round trip.
+		entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+		entry.Hash = entry.ComputeHash()
+		return tx.Create(entry).Error
+	})
+
+	if err != nil {
+		a.logger.Logger.Errorf("error while appending audit entry %v for %v, error: %v\n", entry.Action, entry.Target, err)
+		tracer.LogError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// Iterate hands the entries after afterId to process in chain order.
+func (a *auditLogRepository) Iterate(context context.Context, afterId uint, batchSize int, process func(entry domain.AuditEntry) error) error {
+	span := tracer.StartSpanFromContext(context, "repository/IterateAuditEntries")
+	defer span.Finish()
+
+	for {
+		var entries []domain.AuditEntry
+		if err := a.Conn.Where("id > ?", afterId).Order("id").Limit(batchSize).Find(&entries).Error; err != nil {
+			a.logger.Logger.Errorf("error while reading audit entries after %v, error: %v\n", afterId, err)
+			tracer.LogError(span, err)
+			return err
+		}
+
+		for _, entry := range entries {
+			if err := process(entry); err != nil {
+				return err
+			}
+			afterId = entry.ID
+		}
+
+		if len(entries) < batchSize {
+			return nil
+		}
+	}
+}
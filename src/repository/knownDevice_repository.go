@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/tracer"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"gorm.io/gorm"
+)
+
+type knownDeviceRepository struct {
+	Conn *gorm.DB
+	logger *logger.Logger
+}
+
+type KnownDeviceRepository interface {
+	Get(context context.Context, profileInfoId, fingerprint, ipAddress string) (*domain.KnownDevice, error)
+	CountByProfileInfoId(context context.Context, profileInfoId string) (int64, error)
+	Save(context context.Context, device *domain.KnownDevice) error
+}
+
+func NewKnownDeviceRepository(conn *gorm.DB, logger *logger.Logger) KnownDeviceRepository {
+	return &knownDeviceRepository{Conn: conn, logger: logger}
+}
+
+func (k *knownDeviceRepository) Get(context context.Context, profileInfoId, fingerprint, ipAddress string) (*domain.KnownDevice, error) {
+	span := tracer.StartSpanFromContext(context, "repository/GetKnownDevice")
+	defer span.Finish()
+
+	var device domain.KnownDevice
+	if err := k.Conn.Where("profile_info_id = ? and fingerprint = ? and ip_address = ?", profileInfoId, fingerprint, ipAddress).First(&device).Error; err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	return &device, nil
+}
+
+func (k *knownDeviceRepository) CountByProfileInfoId(context context.Context, profileInfoId string) (int64, error) {
+	span := tracer.StartSpanFromContext(context, "repository/CountKnownDevices")
+	defer span.Finish()
+
+	var count int64
+	if err := k.Conn.Model(&domain.KnownDevice{}).Where("profile_info_id = ?", profileInfoId).Count(&count).Error; err != nil {
+		k.logger.Logger.Errorf("error while counting known devices for profile info id %v, error: %v\n", profileInfoId, err)
+		tracer.LogError(span, err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (k *knownDeviceRepository) Save(context context.Context, device *domain.KnownDevice) error {
+	span := tracer.StartSpanFromContext(context, "repository/SaveKnownDevice")
+	defer span.Finish()
+
+	if err := k.Conn.Save(device).Error; err != nil {
+		k.logger.Logger.Errorf("error while saving known device for profile info id %v, error: %v\n", device.ProfileInfoId, err)
+		tracer.LogError(span, err)
+		return err
+	}
+
+	return nil
+}
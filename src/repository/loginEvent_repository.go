@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/tracer"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"gorm.io/gorm"
+)
+
+type loginEventRepository struct {
+	Conn *gorm.DB
+	logger *logger.Logger
+}
+
+type LoginEventRepository interface {
+	Create(context context.Context, event *domain.LoginEvent) error
+	Find(context context.Context, filter domain.LoginEventFilter) ([]domain.LoginEvent, error)
+}
+
+func NewLoginEventRepository(conn *gorm.DB, logger *logger.Logger) LoginEventRepository {
+	return &loginEventRepository{Conn: conn, logger: logger}
+}
+
+func (l *loginEventRepository) Create(context context.Context, event *domain.LoginEvent) error {
+	span := tracer.StartSpanFromContext(context, "repository/CreateLoginEvent")
+	defer span.Finish()
+
+	if err := l.Conn.Create(event).Error; err != nil {
+		l.logger.Logger.Errorf("error while creating login event for %v, error: %v\n", event.Username, err)
+		tracer.LogError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// Find returns the newest events matching the filter first.
+func (l *loginEventRepository) Find(context context.Context, filter domain.LoginEventFilter) ([]domain.LoginEvent, error) {
+	span := tracer.StartSpanFromContext(context, "repository/FindLoginEvents")
+	defer span.Finish()
+
+	query := l.Conn.Model(&domain.LoginEvent{})
+	if filter.ProfileInfoId != "" {
+		query = query.Where("profile_info_id = ?", filter.ProfileInfoId)
+	}
+	if filter.Username != "" {
+		query = query.Where("username = ?", filter.Username)
+	}
+	if filter.IpAddress != "" {
+		query = query.Where("ip_address = ?", filter.IpAddress)
+	}
+	if filter.Success != nil {
+		query = query.Where("success = ?", *filter.Success)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("created_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("created_at < ?", filter.Until)
+	}
+
+	var events []domain.LoginEvent
+	if err := query.Order("created_at desc").Limit(filter.Limit).Find(&events).Error; err != nil {
+		l.logger.Logger.Errorf("error while finding login events, error: %v\n", err)
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/tracer"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"gorm.io/gorm"
+)
+
+type oauthClientRepository struct {
+	Conn   *gorm.DB
+	logger *logger.Logger
+}
+
+type OAuthClientRepository interface {
+	Create(context context.Context, client *domain.OAuthClient) error
+	GetById(context context.Context, id string) (*domain.OAuthClient, error)
+	GetAll(context context.Context) ([]domain.OAuthClient, error)
+	Delete(context context.Context, client *domain.OAuthClient) error
+}
+
+func NewOAuthClientRepository(conn *gorm.DB, logger *logger.Logger) OAuthClientRepository {
+	return &oauthClientRepository{Conn: conn, logger: logger}
+}
+
+func (o *oauthClientRepository) Create(context context.Context, client *domain.OAuthClient) error {
+	span := tracer.StartSpanFromContext(context, "repository/CreateOAuthClient")
+	defer span.Finish()
+
+	err := o.Conn.Create(client).Error
+	if err != nil {
+		o.logger.Logger.Errorf("error while creating oauth client %v, error: %v\n", client.Name, err)
+		tracer.LogError(span, err)
+	}
+	return err
+}
+
+func (o *oauthClientRepository) GetById(context context.Context, id string) (*domain.OAuthClient, error) {
+	span := tracer.StartSpanFromContext(context, "repository/GetOAuthClientById")
+	defer span.Finish()
+
+	var client domain.OAuthClient
+	err := o.Conn.Preload("Scopes").Take(&client, "id = ?", id).Error
+	if err != nil {
+		o.logger.Logger.Errorf("error while getting oauth client %v, error: %v\n", id, err)
+		tracer.LogError(span, err)
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (o *oauthClientRepository) GetAll(context context.Context) ([]domain.OAuthClient, error) {
+	span := tracer.StartSpanFromContext(context, "repository/GetOAuthClients")
+	defer span.Finish()
+
+	var clients []domain.OAuthClient
+	err := o.Conn.Preload("Scopes").Order("created_at").Find(&clients).Error
+	if err != nil {
+		o.logger.Logger.Errorf("error while getting oauth clients, error: %v\n", err)
+		tracer.LogError(span, err)
+	}
+	return clients, err
+}
+
+// Delete removes the client together with its scope grants.
+func (o *oauthClientRepository) Delete(context context.Context, client *domain.OAuthClient) error {
+	span := tracer.StartSpanFromContext(context, "repository/DeleteOAuthClient")
+	defer span.Finish()
+
+	err := o.Conn.Select("Scopes").Delete(client).Error
+	if err != nil {
+		o.logger.Logger.Errorf("error while deleting oauth client %v, error: %v\n", client.ID, err)
+		tracer.LogError(span, err)
+	}
+	return err
+}
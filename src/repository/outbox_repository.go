@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/tracer"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type outboxRepository struct {
+	Conn *gorm.DB
+	logger *logger.Logger
+}
+
+type OutboxRepository interface {
+	Create(context context.Context, message *domain.OutboxMessage) error
+	Update(context context.Context, message *domain.OutboxMessage) error
+	GetById(context context.Context, id uint) (*domain.OutboxMessage, error)
+	GetByStatus(context context.Context, status string, limit int) ([]domain.OutboxMessage, error)
+	ClaimDue(context context.Context, now time.Time, limit int, process func(message *domain.OutboxMessage)) error
+	Supersede(context context.Context, category, recipient string) error
+}
+
+func NewOutboxRepository(conn *gorm.DB, logger *logger.Logger) OutboxRepository {
+	return &outboxRepository{Conn: conn, logger: logger}
+}
+
+func (o *outboxRepository) Create(context context.Context, message *domain.OutboxMessage) error {
+	span := tracer.StartSpanFromContext(context, "repository/CreateOutboxMessage")
+	defer span.Finish()
+
+	if err := o.Conn.Create(message).Error; err != nil {
+		o.logger.Logger.Errorf("error while creating outbox message for %v, error: %v\n", message.Recipient, err)
+		tracer.LogError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (o *outboxRepository) Update(context context.Context, message *domain.OutboxMessage) error {
+	if err := o.Conn.Save(message).Error; err != nil {
+		o.logger.Logger.Errorf("error while updating outbox message %v, error: %v\n", message.ID, err)
+		return err
+	}
+
+	return nil
+}
+
+func (o *outboxRepository) GetById(context context.Context, id uint) (*domain.OutboxMessage, error) {
+	var message domain.OutboxMessage
+	if err := o.Conn.Take(&message, "id = ?", id).Error; err != nil {
+		o.logger.Logger.Errorf("error while getting outbox message %v, error: %v\n", id, err)
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+func (o *outboxRepository) GetByStatus(context context.Context, status string, limit int) ([]domain.OutboxMessage, error) {
+	var messages []domain.OutboxMessage
+	query := o.Conn.Order("updated_at desc").Limit(limit)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Find(&messages).Error; err != nil {
+		o.logger.Logger.Errorf("error while getting outbox messages with status %v, error: %v\n", status, err)
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// ClaimDue locks due messages, skipping rows locked by another instance, and processes them.
+func (o *outboxRepository) ClaimDue(context context.Context, now time.Time, limit int, process func(message *domain.OutboxMessage)) error {
+	return o.Conn.Transaction(func(tx *gorm.DB) error {
+		var messages []domain.OutboxMessage
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? and next_attempt_at <= ?", domain.OutboxPending, now).
+			Order("next_attempt_at").Limit(limit).Find(&messages).Error
+		if err != nil {
+			o.logger.Logger.Errorf("error while claiming outbox messages, error: %v\n", err)
+			return err
+		}
+
+		for i := range messages {
+			process(&messages[i])
+			if err := tx.Save(&messages[i]).Error; err != nil {
+				o.logger.Logger.Errorf("error while updating outbox message %v, error: %v\n", messages[i].ID, err)
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (o *outboxRepository) Supersede(context context.Context, category, recipient string) error {
+	err := o.Conn.Model(&domain.OutboxMessage{}).
+		Where("category = ? and recipient = ? and status in ?", category, recipient, []string{domain.OutboxPending, domain.OutboxDead}).
+		Updates(map[string]interface{}{"status": domain.OutboxSuperseded, "text_body": "", "html_body": ""}).Error
+	if err != nil {
+		o.logger.Logger.Errorf("error while superseding %v outbox messages for %v, error: %v\n", category, recipient, err)
+	}
+
+	return err
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/tracer"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"gorm.io/gorm"
+)
+
+type passwordHistoryRepository struct {
+	Conn *gorm.DB
+	logger *logger.Logger
+}
+
+type PasswordHistoryRepository interface {
+	Create(context context.Context, history *domain.PasswordHistory) error
+	GetLastByProfileInfoId(context context.Context, profileInfoId string, limit int) ([]domain.PasswordHistory, error)
+	DeleteAllExceptLast(context context.Context, profileInfoId string, keep int) error
+}
+
+func NewPasswordHistoryRepository(conn *gorm.DB, logger *logger.Logger) PasswordHistoryRepository {
+	return &passwordHistoryRepository{Conn: conn, logger: logger}
+}
+
+func (p *passwordHistoryRepository) Create(context context.Context, history *domain.PasswordHistory) error {
+	span := tracer.StartSpanFromContext(context, "repository/CreatePasswordHistory")
+	defer span.Finish()
+
+	if err := p.Conn.Create(history).Error; err != nil {
+		p.logger.Logger.Errorf("error while creating password history for profile info id %v, error: %v\n", history.ProfileInfoId, err)
+		tracer.LogError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (p *passwordHistoryRepository) GetLastByProfileInfoId(context context.Context, profileInfoId string, limit int) ([]domain.PasswordHistory, error) {
+	span := tracer.StartSpanFromContext(context, "repository/GetLastByProfileInfoId")
+	defer span.Finish()
+
+	var history []domain.PasswordHistory
+	if err := p.Conn.Where("profile_info_id = ?", profileInfoId).Order("created_at desc").Limit(limit).Find(&history).Error; err != nil {
+		p.logger.Logger.Errorf("error while getting password history for profile info id %v, error: %v\n", profileInfoId, err)
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func (p *passwordHistoryRepository) DeleteAllExceptLast(context context.Context, profileInfoId string, keep int) error {
+	span := tracer.StartSpanFromContext(context, "repository/DeleteAllExceptLast")
+	defer span.Finish()
+
+	kept := p.Conn.Model(&domain.PasswordHistory{}).Select("id").Where("profile_info_id = ?", profileInfoId).Order("created_at desc").Limit(keep)
+	if err := p.Conn.Unscoped().Where("profile_info_id = ? and id not in (?)", profileInfoId, kept).Delete(&domain.PasswordHistory{}).Error; err != nil {
+		p.logger.Logger.Errorf("error while deleting password history for profile info id %v, error: %v\n", profileInfoId, err)
+		tracer.LogError(span, err)
+		return err
+	}
+
+	return nil
+}
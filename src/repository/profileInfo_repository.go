@@ -23,6 +23,9 @@ type ProfileInfoRepository interface {
 	GetProfileInfoById(context context.Context, id string) (*domain.ProfileInfo, error)
 	Update(context context.Context, profileInfo *domain.ProfileInfo) error
 	DeleteProfileInfo(context context.Context, username string) error
+	UpdateEmail(context context.Context, id, from, to string) error
+	AddRole(context context.Context, profileInfo *domain.ProfileInfo, role *domain.Role) error
+	RemoveRole(context context.Context, profileInfo *domain.ProfileInfo, role *domain.Role) error
 }
 
 func NewProfileInfoRepository(conn *gorm.DB, logger *logger.Logger) ProfileInfoRepository {
@@ -41,9 +44,41 @@ func (p *profileInfoRepository) DeleteProfileInfo(context context.Context, usern
 	return nil
 }
 
+// UpdateEmail changes a profile's email only while it still has the expected one.
+func (p *profileInfoRepository) UpdateEmail(context context.Context, id, from, to string) error {
+	span := tracer.StartSpanFromContext(context, "UpdateEmail")
+	defer span.Finish()
+
+	err := p.Conn.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&domain.ProfileInfo{}).Where("email = ? and id <> ?", to, id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return gorm.ErrInvalidData
+		}
+
+		result := tx.Model(&domain.ProfileInfo{}).Where("id = ? and email = ?", id, from).Update("email", to)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		p.logger.Logger.Errorf("error while updating email for profile info %v, error: %v\n", id, err)
+		tracer.LogError(span, err)
+	}
+	return err
+}
+
 func (p *profileInfoRepository) GetProfileInfoByEmail(context context.Context, email string) (domain.ProfileInfo, error) {
 	profileInfo := domain.ProfileInfo{}
-	err := p.Conn.Joins("Role").Take(&profileInfo, "email = ?", email).Error
+	err := p.Conn.Preload("Roles").Take(&profileInfo, "email = ?", email).Error
 	if err != nil {
 		p.logger.Logger.Errorf("error while getting profile info by email %v, error: %v\n", email, err)
 	}
@@ -55,7 +90,7 @@ func (p *profileInfoRepository) GetProfileInfoByUsername(context context.Context
 	defer span.Finish()
 
 	profileInfo := domain.ProfileInfo{}
-	err := p.Conn.Joins("Role").Take(&profileInfo, "username = ?", username).Error
+	err := p.Conn.Preload("Roles").Take(&profileInfo, "username = ?", username).Error
 
 	if err != nil {
 		p.logger.Logger.Errorf("error while getting profile info by username %v, error: %v\n", username, err)
@@ -103,10 +138,34 @@ func (p *profileInfoRepository) GetProfileInfoByUsernameOrEmail(context context.
 
 func (p *profileInfoRepository) GetProfileInfoById(context context.Context, id string) (*domain.ProfileInfo, error) {
 	var value *domain.ProfileInfo
-	err := p.Conn.Preload("Role").Take(&value, "id = ?", id).Error
+	err := p.Conn.Preload("Roles").Take(&value, "id = ?", id).Error
 
 	if err != nil {
 		p.logger.Logger.Errorf("error while getting profile info by id %v, error: %v\n", id, err)
 	}
 	return value, err
 }
+
+func (p *profileInfoRepository) AddRole(context context.Context, profileInfo *domain.ProfileInfo, role *domain.Role) error {
+	span := tracer.StartSpanFromContext(context, "repository/AddRole")
+	defer span.Finish()
+
+	err := p.Conn.Model(profileInfo).Association("Roles").Append(role)
+	if err != nil {
+		p.logger.Logger.Errorf("error while adding role %v to profile info %v, error: %v\n", role.RoleName, profileInfo.ID, err)
+		tracer.LogError(span, err)
+	}
+	return err
+}
+
+func (p *profileInfoRepository) RemoveRole(context context.Context, profileInfo *domain.ProfileInfo, role *domain.Role) error {
+	span := tracer.StartSpanFromContext(context, "repository/RemoveRole")
+	defer span.Finish()
+
+	err := p.Conn.Model(profileInfo).Association("Roles").Delete(role)
+	if err != nil {
+		p.logger.Logger.Errorf("error while removing role %v from profile info %v, error: %v\n", role.RoleName, profileInfo.ID, err)
+		tracer.LogError(span, err)
+	}
+	return err
+}
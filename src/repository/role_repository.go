@@ -15,6 +15,10 @@ type roleRepository struct {
 type RoleRepository interface {
 	Create(context context.Context, role *domain.Role) error
 	GetByName(context context.Context, roleName string) (*domain.Role, error)
+	GetAll(context context.Context) ([]domain.Role, error)
+	GetScopeNames(context context.Context, roleNames []string) ([]string, error)
+	AddScope(context context.Context, role *domain.Role, scope *domain.Scope) error
+	RemoveScope(context context.Context, role *domain.Role, scope *domain.Scope) error
 }
 
 func NewRoleRepository(conn *gorm.DB, logger *logger.Logger) RoleRepository {
@@ -31,10 +35,57 @@ func (r *roleRepository) Create(context context.Context, role *domain.Role) erro
 
 func (r *roleRepository) GetByName(context context.Context, roleName string) (*domain.Role, error) {
 	var role *domain.Role
-	err := r.Conn.Where("RoleName=?", roleName).First(&role).Error
+	err := r.Conn.Preload("Scopes").Where("role_name = ?", roleName).First(&role).Error
 
 	if err != nil {
 		r.logger.Logger.Errorf("error while getting role by name, error: %v\n", err)
 	}
 	return role, err
 }
+
+func (r *roleRepository) GetAll(context context.Context) ([]domain.Role, error) {
+	var roles []domain.Role
+	err := r.Conn.Preload("Scopes").Order("id").Find(&roles).Error
+
+	if err != nil {
+		r.logger.Logger.Errorf("error while getting roles, error: %v\n", err)
+	}
+	return roles, err
+}
+
+// GetScopeNames returns the scopes granted to any of the roles, sorted by name.
+func (r *roleRepository) GetScopeNames(context context.Context, roleNames []string) ([]string, error) {
+	scopes := make([]string, 0)
+	if len(roleNames) == 0 {
+		return scopes, nil
+	}
+
+	err := r.Conn.Model(&domain.Scope{}).
+		Distinct("scopes.name").
+		Joins("JOIN role_scopes ON role_scopes.scope_id = scopes.id").
+		Joins("JOIN roles ON roles.id = role_scopes.role_id AND roles.deleted_at IS NULL").
+		Where("roles.role_name IN ?", roleNames).
+		Order("scopes.name").
+		Pluck("scopes.name", &scopes).Error
+
+	if err != nil {
+		r.logger.Logger.Errorf("error while getting scopes of roles %v, error: %v\n", roleNames, err)
+	}
+	return scopes, err
+}
+
+func (r *roleRepository) AddScope(context context.Context, role *domain.Role, scope *domain.Scope) error {
+	err := r.Conn.Model(role).Association("Scopes").Append(scope)
+	if err != nil {
+		r.logger.Logger.Errorf("error while adding scope %v to role %v, error: %v\n", scope.Name, role.RoleName, err)
+	}
+	return err
+}
+
+func (r *roleRepository) RemoveScope(context context.Context, role *domain.Role, scope *domain.Scope) error {
+	err := r.Conn.Model(role).Association("Scopes").Delete(scope)
+	if err != nil {
+		r.logger.Logger.Errorf("error while removing scope %v from role %v, error: %v\n", scope.Name, role.RoleName, err)
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"auth-service/domain"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"gorm.io/gorm"
+)
+
+type scopeRepository struct {
+	Conn   *gorm.DB
+	logger *logger.Logger
+}
+
+type ScopeRepository interface {
+	GetAll(context context.Context) ([]domain.Scope, error)
+	GetByNames(context context.Context, names []string) ([]domain.Scope, error)
+}
+
+func NewScopeRepository(conn *gorm.DB, logger *logger.Logger) ScopeRepository {
+	return &scopeRepository{Conn: conn, logger: logger}
+}
+
+func (s *scopeRepository) GetAll(context context.Context) ([]domain.Scope, error) {
+	var scopes []domain.Scope
+	err := s.Conn.Order("name").Find(&scopes).Error
+
+	if err != nil {
+		s.logger.Logger.Errorf("error while getting scopes, error: %v\n", err)
+	}
+	return scopes, err
+}
+
+func (s *scopeRepository) GetByNames(context context.Context, names []string) ([]domain.Scope, error) {
+	var scopes []domain.Scope
+	err := s.Conn.Where("name IN ?", names).Order("name").Find(&scopes).Error
+
+	if err != nil {
+		s.logger.Logger.Errorf("error while getting scopes %v, error: %v\n", names, err)
+	}
+	return scopes, err
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/policy"
+	"auth-service/infrastructure/tracer"
+	"auth-service/repository"
+	"context"
+	"fmt"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"strings"
+)
+
+// AccessDeniedError is returned when no resource rule allows the caller.
+type AccessDeniedError struct {
+	Resource string
+	Action   string
+}
+
+func (e *AccessDeniedError) Error() string {
+	return fmt.Sprintf("not allowed to %v this %v", e.Action, e.Resource)
+}
+
+func (e *AccessDeniedError) DescribeError() *domain.Error {
+	err := domain.PermissionDenied(domain.ReasonAccessDenied, e.Error())
+	err.Metadata = map[string]string{"resource": e.Resource, "action": e.Action}
+	return err
+}
+
+type accessUsecase struct {
+	Enforcer              policy.Enforcer
+	ProfileInfoRepository repository.ProfileInfoRepository
+	logger                *logger.Logger
+}
+
+// AccessUsecase checks requests against the resource policy.
+type AccessUsecase interface {
+	Subject(context context.Context, userId string, subjects []string) domain.AccessSubject
+	ProfileResource(context context.Context, username string) domain.Resource
+	Authorize(context context.Context, subject domain.AccessSubject, resource domain.Resource, action string) error
+}
+
+func NewAccessUsecase(enforcer policy.Enforcer, profileInfoRepository repository.ProfileInfoRepository, logger *logger.Logger) AccessUsecase {
+	return &accessUsecase{Enforcer: enforcer, ProfileInfoRepository: profileInfoRepository, logger: logger}
+}
+
+// Subject adds the account status to the caller's token subjects.
+func (a *accessUsecase) Subject(context context.Context, userId string, subjects []string) domain.AccessSubject {
+	span := tracer.StartSpanFromContext(context, "usecase/AccessSubject")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+	subject := domain.AccessSubject{Id: userId, Subjects: subjects}
+	if userId == "" || strings.HasPrefix(userId, domain.OAuthClientSessionPrefix) {
+		return subject
+	}
+
+	if account, err := a.ProfileInfoRepository.GetProfileInfoById(ctx1, userId); err == nil {
+		subject.Status = account.Status()
+	}
+
+	return subject
+}
+
+// ProfileResource describes the profile with the username.
+func (a *accessUsecase) ProfileResource(context context.Context, username string) domain.Resource {
+	span := tracer.StartSpanFromContext(context, "usecase/ProfileResource")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+	resource := domain.Resource{Type: domain.ResourceProfile}
+	if account, err := a.ProfileInfoRepository.GetProfileInfoByUsername(ctx1, username); err == nil {
+		resource.Id = account.ID
+		resource.Owner = account.ID
+	}
+
+	return resource
+}
+
+func (a *accessUsecase) Authorize(context context.Context, subject domain.AccessSubject, resource domain.Resource, action string) error {
+	span := tracer.StartSpanFromContext(context, "usecase/AuthorizeAccess")
+	defer span.Finish()
+
+	decision, err := policy.DecideAccess(a.Enforcer, subject, resource, action)
+	if err != nil {
+		a.logger.Logger.Errorf("error while enforcing resource policy, error: %v\n", err)
+		tracer.LogError(span, err)
+		return err
+	}
+
+	if !decision.Allowed {
+		a.logger.Logger.Warnf("%v denied to %v %v %v\n", subject.Id, action, resource.Type, resource.Id)
+		return &AccessDeniedError{Resource: resource.Type, Action: action}
+	}
+
+	return nil
+}
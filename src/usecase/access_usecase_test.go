@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/policy"
+	"auth-service/repository"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+type resourcePolicy struct {
+	policy.Enforcer
+	snapshot *policy.Snapshot
+}
+
+func (r resourcePolicy) EnforceResource(sub, obj interface{}, act string) (bool, []string, error) {
+	return r.snapshot.EnforceResource(sub, obj, act)
+}
+
+func loadResourcePolicy(t *testing.T) resourcePolicy {
+	rules, err := os.Open("../infrastructure/policy/resource_policy.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rules.Close()
+
+	snapshot, err := policy.LoadSnapshot("../infrastructure/policy/resource_model.conf", rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resourcePolicy{snapshot: snapshot}
+}
+
+type profileDirectory struct {
+	repository.ProfileInfoRepository
+	profiles []domain.ProfileInfo
+}
+
+func (p profileDirectory) GetProfileInfoById(context context.Context, id string) (*domain.ProfileInfo, error) {
+	for _, profile := range p.profiles {
+		if profile.ID == id {
+			return &profile, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (p profileDirectory) GetProfileInfoByUsername(context context.Context, username string) (domain.ProfileInfo, error) {
+	for _, profile := range p.profiles {
+		if profile.Username == username {
+			return profile, nil
+		}
+	}
+	return domain.ProfileInfo{}, errors.New("record not found")
+}
+
+func TestAuthorizeProfileDeletion(t *testing.T) {
+	suspendedUntil := time.Now().Add(time.Hour)
+	profiles := profileDirectory{profiles: []domain.ProfileInfo{
+		{ID: "1", Username: "jelena"},
+		{ID: "2", Username: "marko"},
+		{ID: "3", Username: "suspended", SuspendedUntil: &suspendedUntil},
+	}}
+	access := NewAccessUsecase(loadResourcePolicy(t), profiles, logger.InitializeLogger("auth-service", context.Background()))
+
+	tests := []struct {
+		name      string
+		userId    string
+		subjects  []string
+		username  string
+		wantAllow bool
+	}{
+		{name: "own profile", userId: "1", subjects: []string{"USER"}, username: "jelena", wantAllow: true},
+		{name: "someone else's profile", userId: "1", subjects: []string{"USER"}, username: "marko"},
+		{name: "agent deleting their own profile", userId: "2", subjects: []string{"AGENT"}, username: "marko", wantAllow: true},
+		{name: "suspended owner", userId: "3", subjects: []string{"USER"}, username: "suspended"},
+		{name: "admin", userId: "admin", subjects: []string{"ADMIN"}, username: "marko", wantAllow: true},
+		{name: "unknown profile", userId: "1", subjects: []string{"USER"}, username: "nobody"},
+		{name: "anonymous", subjects: []string{"ANONYMOUS"}, username: "jelena"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			subject := access.Subject(ctx, tt.userId, tt.subjects)
+			err := access.Authorize(ctx, subject, access.ProfileResource(ctx, tt.username), domain.ResourceActionDelete)
+
+			if tt.wantAllow {
+				if err != nil {
+					t.Errorf("err = %v, want allowed", err)
+				}
+				return
+			}
+			var denied *AccessDeniedError
+			if !errors.As(err, &denied) || denied.Resource != domain.ResourceProfile {
+				t.Errorf("err = %v, want access to the profile denied", err)
+			}
+		})
+	}
+}
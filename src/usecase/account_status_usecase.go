@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/tracer"
+	"auth-service/repository"
+	"context"
+	"errors"
+	"fmt"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"time"
+)
+
+const (
+	statusReasonRequired = "a reason is required"
+	statusOwnAccount     = "admins can't change the status of their own account"
+	suspensionInPast     = "suspension must end in the future"
+)
+
+// AccountStatusError is returned when an inactive account logs in or uses a token.
+type AccountStatusError struct {
+	Status string
+	Until  *time.Time
+}
+
+func (e *AccountStatusError) Error() string {
+	switch e.Status {
+	case domain.AccountSuspended:
+		return fmt.Sprintf("account is suspended until %v", e.Until.UTC().Format(time.RFC3339))
+	case domain.AccountBanned:
+		return "account is banned"
+	default:
+		return "account is locked, reset your password to unlock it"
+	}
+}
+
+func (e *AccountStatusError) DescribeError() *domain.Error {
+	reason := domain.ReasonAccountLocked
+	switch e.Status {
+	case domain.AccountSuspended:
+		reason = domain.ReasonAccountSuspended
+	case domain.AccountBanned:
+		reason = domain.ReasonAccountBanned
+	}
+
+	err := domain.PermissionDenied(reason, e.Error())
+	err.Metadata = map[string]string{"status": e.Status}
+	if e.Until != nil {
+		err.Metadata["suspended_until"] = e.Until.UTC().Format(time.RFC3339)
+	}
+	return err
+}
+
+// CheckAccountStatus returns an *AccountStatusError unless the account is active.
+func CheckAccountStatus(profileInfo domain.ProfileInfo) error {
+	status := profileInfo.Status()
+	if status == domain.AccountActive {
+		return nil
+	}
+
+	return &AccountStatusError{Status: status, Until: profileInfo.SuspendedUntil}
+}
+
+type accountStatusUsecase struct {
+	ProfileInfoRepository repository.ProfileInfoRepository
+	AuthenticationUsecase AuthenticationUsecase
+	AuditUsecase          AuditUsecase
+	logger                *logger.Logger
+}
+
+type AccountStatusUsecase interface {
+	Suspend(context context.Context, actor, userId string, until time.Time, reason string) (*domain.ProfileInfo, error)
+	Ban(context context.Context, actor, userId, reason string) (*domain.ProfileInfo, error)
+	Unlock(context context.Context, actor, userId, reason string) (*domain.ProfileInfo, error)
+	Check(context context.Context, userId string) error
+}
+
+func NewAccountStatusUsecase(profileInfoRepository repository.ProfileInfoRepository, authenticationUsecase AuthenticationUsecase, auditUsecase AuditUsecase, logger *logger.Logger) AccountStatusUsecase {
+	return &accountStatusUsecase{ProfileInfoRepository: profileInfoRepository, AuthenticationUsecase: authenticationUsecase, AuditUsecase: auditUsecase, logger: logger}
+}
+
+// Suspend blocks the account until the given time and revokes its sessions.
+func (a *accountStatusUsecase) Suspend(context context.Context, actor, userId string, until time.Time, reason string) (*domain.ProfileInfo, error) {
+	if !until.After(time.Now()) {
+		return nil, domain.InvalidArgument(suspensionInPast)
+	}
+
+	return a.change(context, actor, userId, reason, domain.AuditAccountSuspended, func(account *domain.ProfileInfo) {
+		account.SuspendedUntil = &until
+	})
+}
+
+// Ban blocks the account until an admin unlocks it and revokes its sessions.
+func (a *accountStatusUsecase) Ban(context context.Context, actor, userId, reason string) (*domain.ProfileInfo, error) {
+	return a.change(context, actor, userId, reason, domain.AuditAccountBanned, func(account *domain.ProfileInfo) {
+		now := time.Now()
+		account.BannedAt = &now
+	})
+}
+
+// Unlock lifts a ban, a suspension and an owner's lock at once.
+func (a *accountStatusUsecase) Unlock(context context.Context, actor, userId, reason string) (*domain.ProfileInfo, error) {
+	return a.change(context, actor, userId, reason, domain.AuditAccountUnlocked, func(account *domain.ProfileInfo) {
+		account.BannedAt = nil
+		account.SuspendedUntil = nil
+		account.LockedAt = nil
+	})
+}
+
+func (a *accountStatusUsecase) change(context context.Context, actor, userId, reason, action string, apply func(account *domain.ProfileInfo)) (*domain.ProfileInfo, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/ChangeAccountStatus")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	account, err := a.validateChange(ctx1, actor, userId, reason)
+	if err != nil {
+		tracer.LogError(span, err)
+		a.AuditUsecase.RecordWithReason(ctx1, actor, userId, action, reason, err)
+		return nil, err
+	}
+
+	apply(account)
+	account.StatusReason = reason
+	if err := a.ProfileInfoRepository.Update(ctx1, account); err != nil {
+		tracer.LogError(span, err)
+		a.AuditUsecase.RecordWithReason(ctx1, actor, userId, action, reason, err)
+		return nil, errors.New(updateError)
+	}
+
+	a.logger.Logger.Warnf("account %v is now %v on request of %v, reason: %v\n", account.ID, account.Status(), actor, reason)
+
+	if account.Status() != domain.AccountActive {
+		err = a.AuthenticationUsecase.RevokeUserSessions(ctx1, account.ID)
+	}
+	a.AuditUsecase.RecordWithReason(ctx1, actor, userId, action, reason, err)
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (a *accountStatusUsecase) validateChange(context context.Context, actor, userId, reason string) (*domain.ProfileInfo, error) {
+	if reason == "" {
+		return nil, domain.InvalidArgument(statusReasonRequired)
+	}
+	if actor == userId {
+		return nil, domain.PermissionDenied(domain.ReasonForbidden, statusOwnAccount)
+	}
+
+	account, err := a.ProfileInfoRepository.GetProfileInfoById(context, userId)
+	if err != nil {
+		return nil, domain.NotFound(userNotFound)
+	}
+
+	return account, nil
+}
+
+// Check returns an *AccountStatusError unless the user's account is active.
+func (a *accountStatusUsecase) Check(context context.Context, userId string) error {
+	span := tracer.StartSpanFromContext(context, "usecase/CheckAccountStatus")
+	defer span.Finish()
+
+	account, err := a.ProfileInfoRepository.GetProfileInfoById(tracer.ContextWithSpan(context, span), userId)
+	if err != nil {
+		tracer.LogError(span, err)
+		return domain.NotFound(userNotFound)
+	}
+
+	return CheckAccountStatus(*account)
+}
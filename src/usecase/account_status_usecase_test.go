@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"context"
+	"testing"
+	"time"
+
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+func TestCheckAccountStatus(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		account    domain.ProfileInfo
+		wantReason string
+	}{
+		{name: "active", account: domain.ProfileInfo{}},
+		{name: "suspended", account: domain.ProfileInfo{SuspendedUntil: &future}, wantReason: domain.ReasonAccountSuspended},
+		{name: "suspension ended", account: domain.ProfileInfo{SuspendedUntil: &past}},
+		{name: "banned", account: domain.ProfileInfo{BannedAt: &past}, wantReason: domain.ReasonAccountBanned},
+		{name: "locked by the owner", account: domain.ProfileInfo{LockedAt: &past}, wantReason: domain.ReasonAccountLocked},
+		{name: "ban outranks suspension", account: domain.ProfileInfo{BannedAt: &past, SuspendedUntil: &future}, wantReason: domain.ReasonAccountBanned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckAccountStatus(tt.account)
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Errorf("err = %v", err)
+				}
+				return
+			}
+			if got := domain.AsError(err); got.Kind != domain.ErrorPermissionDenied || got.Reason != tt.wantReason {
+				t.Errorf("err = %v %v, want permission denied %v", got.Kind, got.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestChangeAccountStatus(t *testing.T) {
+	tomorrow := time.Now().Add(24 * time.Hour)
+	yesterday := time.Now().Add(-24 * time.Hour)
+
+	suspend := func(until time.Time) func(AccountStatusUsecase, string, string) error {
+		return func(usecase AccountStatusUsecase, actor, reason string) error {
+			_, err := usecase.Suspend(context.Background(), actor, "1", until, reason)
+			return err
+		}
+	}
+	ban := func(usecase AccountStatusUsecase, actor, reason string) error {
+		_, err := usecase.Ban(context.Background(), actor, "1", reason)
+		return err
+	}
+	unlock := func(usecase AccountStatusUsecase, actor, reason string) error {
+		_, err := usecase.Unlock(context.Background(), actor, "1", reason)
+		return err
+	}
+
+	tests := []struct {
+		name        string
+		change      func(AccountStatusUsecase, string, string) error
+		actor       string
+		reason      string
+		banned      bool
+		wantKind    string
+		wantStatus  string
+		wantRevoked bool
+		wantAudit   string
+	}{
+		{name: "suspend", change: suspend(tomorrow), actor: "admin", reason: "spam", wantStatus: domain.AccountSuspended, wantRevoked: true, wantAudit: domain.AuditAccountSuspended},
+		{name: "suspend into the past", change: suspend(yesterday), actor: "admin", reason: "spam", wantKind: domain.ErrorInvalidArgument, wantStatus: domain.AccountActive},
+		{name: "ban", change: ban, actor: "admin", reason: "fraud", wantStatus: domain.AccountBanned, wantRevoked: true, wantAudit: domain.AuditAccountBanned},
+		{name: "ban without a reason", change: ban, actor: "admin", wantKind: domain.ErrorInvalidArgument, wantStatus: domain.AccountActive, wantAudit: domain.AuditAccountBanned},
+		{name: "ban own account", change: ban, actor: "1", reason: "fraud", wantKind: domain.ErrorPermissionDenied, wantStatus: domain.AccountActive, wantAudit: domain.AuditAccountBanned},
+		{name: "unlock", change: unlock, actor: "admin", reason: "appeal", banned: true, wantStatus: domain.AccountActive, wantAudit: domain.AuditAccountUnlocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := &domain.ProfileInfo{ID: "1"}
+			if tt.banned {
+				account.BannedAt = &yesterday
+			}
+			accounts := &accountRepository{account: account}
+			sessions := &revokedSessions{}
+			audit := &auditTrail{}
+			usecase := NewAccountStatusUsecase(accounts, sessions, audit, logger.InitializeLogger("auth-service", context.Background()))
+
+			err := tt.change(usecase, tt.actor, tt.reason)
+			if tt.wantKind == "" && err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if tt.wantKind != "" && domain.AsError(err).Kind != tt.wantKind {
+				t.Fatalf("err = %v, want %v", err, tt.wantKind)
+			}
+			if status := accounts.account.Status(); status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", status, tt.wantStatus)
+			}
+			if revoked := len(sessions.users) > 0; revoked != tt.wantRevoked {
+				t.Errorf("sessions revoked: %v, want %v", revoked, tt.wantRevoked)
+			}
+			if tt.wantAudit != "" && (len(audit.actions) != 1 || audit.actions[0] != tt.wantAudit) {
+				t.Errorf("audited %v, want %v", audit.actions, tt.wantAudit)
+			}
+		})
+	}
+}
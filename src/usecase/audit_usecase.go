@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"auth-service/infrastructure/tracer"
+	"auth-service/repository"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"strings"
+)
+
+const auditExportBatchSize = 500
+
+type auditUsecase struct {
+	AuditLogRepository repository.AuditLogRepository
+	logger             *logger.Logger
+}
+
+type AuditUsecase interface {
+	Record(context context.Context, actor, target, action string, err error)
+	RecordWithReason(context context.Context, actor, target, action, reason string, err error)
+	Export(context context.Context, afterId uint, write func(entry domain.AuditEntry) error) error
+}
+
+func NewAuditUsecase(auditLogRepository repository.AuditLogRepository, logger *logger.Logger) AuditUsecase {
+	return &auditUsecase{AuditLogRepository: auditLogRepository, logger: logger}
+}
+
+// Record appends an entry for the action, only logging errors.
+func (a *auditUsecase) Record(context context.Context, actor, target, action string, err error) {
+	a.RecordWithReason(context, actor, target, action, "", err)
+}
+
+// RecordWithReason is Record for actions that an admin has to justify.
+func (a *auditUsecase) RecordWithReason(context context.Context, actor, target, action, reason string, err error) {
+	span := tracer.StartSpanFromContext(context, "usecase/RecordAudit")
+	defer span.Finish()
+
+	if actor == "" {
+		actor = domain.AuditActorAnonymous
+	}
+
+	entry := domain.AuditEntry{
+		Actor:     actor,
+		Target:    target,
+		Action:    action,
+		Outcome:   domain.AuditSuccess,
+		Details:   reason,
+		RequestId: helper.RequestIdFromContext(context),
+	}
+	entry.IpAddress, _ = helper.ClientInfoFromContext(context)
+	if err != nil {
+		entry.Outcome = domain.AuditFailure
+		entry.Details = strings.TrimPrefix(reason+": "+err.Error(), ": ")
+	}
+
+	if err := a.AuditLogRepository.Append(tracer.ContextWithSpan(context, span), &entry); err != nil {
+		a.logger.Logger.Errorf("audit entry lost: %v %v by %v (%v)\n", action, target, actor, entry.Outcome)
+		tracer.LogError(span, err)
+	}
+}
+
+func (a *auditUsecase) Export(context context.Context, afterId uint, write func(entry domain.AuditEntry) error) error {
+	span := tracer.StartSpanFromContext(context, "usecase/ExportAudit")
+	defer span.Finish()
+
+	return a.AuditLogRepository.Iterate(tracer.ContextWithSpan(context, span), afterId, auditExportBatchSize, write)
+}
@@ -4,6 +4,7 @@ import (
 	"auth-service/domain"
 	"auth-service/infrastructure/tracer"
 	"context"
+	"github.com/go-redis/redis/v8"
 	logger "github.com/jelena-vlajkov/logger/logger"
 	"time"
 )
@@ -11,7 +12,12 @@ const (
 	authToken = "authToken"
 	refreshToken = "refreshToken"
 	totp_token = "totpToken"
+	userSessions = "userSessions"
 )
+
+// ErrInvalidToken is returned for a token uuid that is unknown or expired.
+var ErrInvalidToken = domain.Unauthenticated(domain.ReasonInvalidToken, "invalid or expired token")
+
 type authenticationUsecase struct {
 	RedisUsecase RedisUsecase
 	logger *logger.Logger
@@ -28,6 +34,7 @@ type AuthenticationUsecase interface {
 	FetchTemporaryToken(ctx context.Context, tokenUuid string) ([]byte, error)
 	DeleteTemporaryToken(ctx context.Context, tokenUuid string) error
 	DeleteRefreshToken(ctx context.Context, refreshTokenUuid string) error
+	RevokeUserSessions(ctx context.Context, userId string) error
 }
 
 func NewAuthenticationUsecase(redisUsecase RedisUsecase, logger *logger.Logger) AuthenticationUsecase{
@@ -50,6 +57,11 @@ func (a *authenticationUsecase) SaveAuthToken(ctx context.Context, userId uint,
 		return err
 	}
 
+	if err := a.indexSession(ctx, td.UserId, key, at.Sub(now)); err != nil {
+		tracer.LogError(span, err)
+		return err
+	}
+
 	return nil
 }
 
@@ -59,7 +71,7 @@ func (a *authenticationUsecase) FetchAuthToken(ctx context.Context, tokenUuid st
 	value, err := a.RedisUsecase.GetValueByKey(ctx, key)
 
 	if err != nil {
-		return nil, err
+		return nil, tokenError(err)
 	}
 
 	return value, err
@@ -75,6 +87,36 @@ func (a *authenticationUsecase) SaveRefreshToken(ctx context.Context, userId uin
 		return err
 	}
 
+	return a.indexSession(ctx, td.UserId, key, rt.Sub(now))
+}
+
+// indexSession records a token key under its owner so all sessions can be revoked.
+func (a *authenticationUsecase) indexSession(ctx context.Context, userId, key string, expiration time.Duration) error {
+	if userId == "" {
+		return nil
+	}
+
+	return a.RedisUsecase.AddToSet(ctx, userSessions+userId, key, expiration)
+}
+
+func (a *authenticationUsecase) RevokeUserSessions(ctx context.Context, userId string) error {
+	a.logger.Logger.Infof("revoking sessions for user %v\n", userId)
+	span := tracer.StartSpanFromContext(ctx, "usecase/RevokeUserSessions")
+	defer span.Finish()
+
+	keys, err := a.RedisUsecase.GetSetMembers(ctx, userSessions+userId)
+	if err != nil {
+		tracer.LogError(span, err)
+		return err
+	}
+
+	for _, key := range append(keys, userSessions+userId) {
+		if err := a.RedisUsecase.DeleteValueByKey(ctx, key); err != nil {
+			tracer.LogError(span, err)
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -84,7 +126,7 @@ func (a *authenticationUsecase) FetchRefreshToken(ctx context.Context, refreshTo
 	value, err := a.RedisUsecase.GetValueByKey(ctx, key)
 
 	if err != nil {
-		return nil, err
+		return nil, tokenError(err)
 	}
 
 	return value, err
@@ -126,7 +168,7 @@ func (a *authenticationUsecase) FetchTemporaryToken(ctx context.Context, tokenUu
 
 	if err != nil {
 		tracer.LogError(span, err)
-		return nil, err
+		return nil, tokenError(err)
 	}
 
 	return value, err
@@ -163,5 +205,10 @@ func (a *authenticationUsecase) DeleteRefreshToken(ctx context.Context, refreshT
 	return nil
 }
 
-
-
+// tokenError tells a missing token apart from Redis being unavailable.
+func tokenError(err error) error {
+	if err == redis.Nil {
+		return ErrInvalidToken
+	}
+	return err
+}
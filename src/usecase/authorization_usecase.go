@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"auth-service/infrastructure/policy"
+	"auth-service/infrastructure/tracer"
+	"context"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"strings"
+	"time"
+)
+
+const (
+	authorizationNoChecks      = "at least one check is required"
+	authorizationObjectMissing = "every check needs an object"
+	anonymousSubject           = "ANONYMOUS"
+)
+
+type authorizationUsecase struct {
+	Config                domain.PdpConfig
+	Enforcers             []policy.Enforcer
+	AuthenticationUsecase AuthenticationUsecase
+	logger                *logger.Logger
+}
+
+// AuthorizationUsecase is the policy decision point other services ask.
+type AuthorizationUsecase interface {
+	Authorize(context context.Context, transport, token string, checks []domain.AuthorizationCheck) ([]domain.AuthorizationDecision, time.Duration, error)
+}
+
+func NewAuthorizationUsecase(config domain.PdpConfig, enforcers []policy.Enforcer, authenticationUsecase AuthenticationUsecase, logger *logger.Logger) AuthorizationUsecase {
+	return &authorizationUsecase{Config: config, Enforcers: enforcers, AuthenticationUsecase: authenticationUsecase, logger: logger}
+}
+
+// tokenSubjects is who a token acts as and for how long.
+type tokenSubjects struct {
+	subjects  []string
+	actorId   string
+	expiresAt *time.Time
+	valid     bool
+}
+
+// Authorize evaluates every check for the token and returns how long they may be cached.
+func (a *authorizationUsecase) Authorize(context context.Context, transport, token string, checks []domain.AuthorizationCheck) ([]domain.AuthorizationDecision, time.Duration, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/Authorize")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	enforcer, err := a.enforcer(transport)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := a.validateChecks(checks); err != nil {
+		return nil, 0, err
+	}
+
+	subjects := a.subjects(ctx1, token)
+
+	decisions := make([]domain.AuthorizationDecision, 0, len(checks))
+	for _, check := range checks {
+		decision, err := a.decide(enforcer, subjects, check)
+		if err != nil {
+			tracer.LogError(span, err)
+			return nil, 0, err
+		}
+		decisions = append(decisions, decision)
+	}
+
+	return decisions, a.ttl(subjects), nil
+}
+
+func (a *authorizationUsecase) validateChecks(checks []domain.AuthorizationCheck) error {
+	if len(checks) == 0 {
+		return domain.InvalidArgument(authorizationNoChecks)
+	}
+	if a.Config.MaxBatchSize > 0 && len(checks) > a.Config.MaxBatchSize {
+		return domain.InvalidArgument(fmt.Sprintf("at most %v checks can be made at once", a.Config.MaxBatchSize))
+	}
+	for _, check := range checks {
+		if check.Object == "" {
+			return domain.InvalidArgument(authorizationObjectMissing)
+		}
+	}
+	return nil
+}
+
+func (a *authorizationUsecase) decide(enforcer policy.Enforcer, subjects tokenSubjects, check domain.AuthorizationCheck) (domain.AuthorizationDecision, error) {
+	if check.Action == "" {
+		check.Action = "*"
+	}
+	decision := domain.AuthorizationDecision{Object: check.Object, Action: check.Action, Reason: domain.AuthorizationNoRule}
+	if !subjects.valid {
+		decision.Reason = domain.AuthorizationInvalidToken
+		return decision, nil
+	}
+
+	matched, err := policy.Decide(enforcer, subjects.subjects, check.Object, check.Action)
+	if err != nil || !matched.Allowed {
+		return decision, err
+	}
+
+	if subjects.actorId != "" {
+		ok, err := enforcer.Enforce(domain.ImpersonationSubject, check.Object, check.Action)
+		if err != nil {
+			return decision, err
+		}
+		if !ok {
+			decision.Reason = domain.AuthorizationImpersonation
+			return decision, nil
+		}
+	}
+
+	decision.Allowed = true
+	decision.Subject = matched.Subject
+	decision.MatchedRule = matched.Rule
+	decision.Reason = domain.AuthorizationAllowed
+	return decision, nil
+}
+
+// subjects accepts a signed token or a token uuid, which must still be stored.
+func (a *authorizationUsecase) subjects(context context.Context, token string) tokenSubjects {
+	if token == "" {
+		return tokenSubjects{subjects: []string{anonymousSubject}, valid: true}
+	}
+
+	if strings.Count(token, ".") != 2 {
+		stored, err := a.AuthenticationUsecase.FetchAuthToken(context, token)
+		if err != nil {
+			return tokenSubjects{}
+		}
+		token = string(stored)
+	}
+
+	parsed, err := verifyToken(token)
+	if err != nil {
+		return tokenSubjects{}
+	}
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || !parsed.Valid {
+		return tokenSubjects{}
+	}
+
+	accessUuid, _ := claims["access_uuid"].(string)
+	if _, err := a.AuthenticationUsecase.FetchAuthToken(context, accessUuid); accessUuid == "" || err != nil {
+		return tokenSubjects{}
+	}
+
+	subjects := tokenSubjects{subjects: helper.SubjectsFromClaims(claims), valid: true}
+	subjects.actorId, _ = claims[domain.ImpersonationClaim].(string)
+	if exp, ok := claims["exp"].(float64); ok {
+		expiresAt := time.Unix(int64(exp), 0)
+		subjects.expiresAt = &expiresAt
+	}
+	if len(subjects.subjects) == 0 {
+		subjects.subjects = []string{anonymousSubject}
+	}
+
+	return subjects
+}
+
+// ttl keeps cached decisions from outliving the token they were made for.
+func (a *authorizationUsecase) ttl(subjects tokenSubjects) time.Duration {
+	ttl := a.Config.DecisionTtl
+	if subjects.expiresAt != nil {
+		if remaining := time.Until(*subjects.expiresAt); remaining < ttl {
+			ttl = remaining
+		}
+	}
+	if ttl < 0 {
+		return 0
+	}
+
+	return ttl.Truncate(time.Second)
+}
+
+func (a *authorizationUsecase) enforcer(transport string) (policy.Enforcer, error) {
+	if transport == "" {
+		transport = domain.PolicyTransportHttp
+	}
+	for _, enforcer := range a.Enforcers {
+		if enforcer.Name() == transport {
+			return enforcer, nil
+		}
+	}
+
+	return nil, domain.InvalidArgument(unknownTransport)
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/policy"
+	"context"
+	"testing"
+	"time"
+
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+func TestAuthorize(t *testing.T) {
+	log := logger.InitializeLogger("auth-service", context.Background())
+	authentication := NewAuthenticationUsecase(newMemoryRedis(), log)
+	jwt := NewJwtUsecase(newMemoryRedis(), log, authentication, noScopes{})
+	enforcer := newMemoryEnforcer(t, domain.PolicyTransportHttp,
+		[][]string{
+			{"ANONYMOUS", "/login", "*"},
+			{"USER", "/changePassword", "POST"},
+			{"USER", "/activity", "GET"},
+			{"IMPERSONATION", "/activity", "GET"},
+		}, nil)
+	pdp := NewAuthorizationUsecase(domain.PdpConfig{DecisionTtl: time.Hour, MaxBatchSize: 3}, []policy.Enforcer{enforcer}, authentication, log)
+	ctx := context.Background()
+
+	user, err := jwt.CreateToken(ctx, []string{domain.RoleUser}, "1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	impersonation, err := jwt.CreateImpersonationToken(ctx, []string{domain.RoleUser}, "1", "admin", 5*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revoked, err := jwt.CreateToken(ctx, []string{domain.RoleUser}, "2", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := authentication.DeleteAuthToken(ctx, revoked.TokenUuid); err != nil {
+		t.Fatal(err)
+	}
+
+	checks := []domain.AuthorizationCheck{
+		{Object: "/changePassword", Action: "POST"},
+		{Object: "/activity", Action: "GET"},
+		{Object: "/login"},
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		wantReason []string
+		maxTtl     time.Duration
+	}{
+		{
+			name:       "anonymous",
+			wantReason: []string{domain.AuthorizationNoRule, domain.AuthorizationNoRule, domain.AuthorizationAllowed},
+			maxTtl:     time.Hour,
+		},
+		{
+			name:       "signed user token",
+			token:      user.AccessToken,
+			wantReason: []string{domain.AuthorizationAllowed, domain.AuthorizationAllowed, domain.AuthorizationNoRule},
+			maxTtl:     time.Until(time.Unix(user.AtExpires, 0)),
+		},
+		{
+			name:       "token uuid",
+			token:      user.TokenUuid,
+			wantReason: []string{domain.AuthorizationAllowed, domain.AuthorizationAllowed, domain.AuthorizationNoRule},
+			maxTtl:     time.Until(time.Unix(user.AtExpires, 0)),
+		},
+		{
+			name:       "impersonation",
+			token:      impersonation.AccessToken,
+			wantReason: []string{domain.AuthorizationImpersonation, domain.AuthorizationAllowed, domain.AuthorizationNoRule},
+			maxTtl:     5 * time.Minute,
+		},
+		{
+			name:       "revoked token",
+			token:      revoked.AccessToken,
+			wantReason: []string{domain.AuthorizationInvalidToken, domain.AuthorizationInvalidToken, domain.AuthorizationInvalidToken},
+			maxTtl:     time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decisions, ttl, err := pdp.Authorize(ctx, "", tt.token, checks)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, decision := range decisions {
+				if decision.Reason != tt.wantReason[i] || decision.Allowed != (tt.wantReason[i] == domain.AuthorizationAllowed) {
+					t.Errorf("%v: %v %v, want %v", checks[i].Object, decision.Allowed, decision.Reason, tt.wantReason[i])
+				}
+			}
+			if ttl > tt.maxTtl || ttl%time.Second != 0 {
+				t.Errorf("ttl = %v, want at most %v in whole seconds", ttl, tt.maxTtl)
+			}
+		})
+	}
+}
+
+func TestAuthorizeRejectsBadRequests(t *testing.T) {
+	enforcer := newMemoryEnforcer(t, domain.PolicyTransportHttp, [][]string{{"ANONYMOUS", "/login", "*"}}, nil)
+	pdp := NewAuthorizationUsecase(domain.PdpConfig{DecisionTtl: time.Hour, MaxBatchSize: 2}, []policy.Enforcer{enforcer}, nil,
+		logger.InitializeLogger("auth-service", context.Background()))
+
+	for name, authorize := range map[string]func() error{
+		"no checks": func() error {
+			_, _, err := pdp.Authorize(context.Background(), "", "", nil)
+			return err
+		},
+		"too many checks": func() error {
+			_, _, err := pdp.Authorize(context.Background(), "", "", []domain.AuthorizationCheck{{Object: "/a"}, {Object: "/b"}, {Object: "/c"}})
+			return err
+		},
+		"check without object": func() error {
+			_, _, err := pdp.Authorize(context.Background(), "", "", []domain.AuthorizationCheck{{Action: "GET"}})
+			return err
+		},
+		"unknown transport": func() error {
+			_, _, err := pdp.Authorize(context.Background(), "grpc", "", []domain.AuthorizationCheck{{Object: "/login"}})
+			return err
+		},
+	} {
+		if err := authorize(); domain.AsError(err).Kind != domain.ErrorInvalidArgument {
+			t.Errorf("%v: err = %v, want invalid argument", name, err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"auth-service/infrastructure/metrics"
+	"auth-service/infrastructure/tracer"
+	"context"
+	"fmt"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"time"
+)
+
+// Scopes of the checks protected against brute force.
+const (
+	BruteForceLogin        = "login"
+	BruteForceTotp         = "totp"
+	BruteForceConfirmation = "confirmation"
+	BruteForceReset        = "reset"
+	BruteForceClient       = "client"
+)
+
+const (
+	bruteForceKey = "bruteForce/"
+
+	bruteForceSubject = "subject"
+	bruteForceIp      = "ip"
+)
+
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("too many failed attempts, try again in %v", e.RetryAfter.Round(time.Second))
+}
+
+func (e *TooManyAttemptsError) DescribeError() *domain.Error {
+	return domain.ResourceExhausted(domain.ReasonTooManyAttempts, e.Error(), e.RetryAfter)
+}
+
+type bruteForceUsecase struct {
+	Config       domain.BruteForceConfig
+	RedisUsecase RedisUsecase
+	logger       *logger.Logger
+}
+
+// BruteForceUsecase delays and locks out subjects and IP addresses after failed attempts.
+type BruteForceUsecase interface {
+	Check(context context.Context, scope, subject string) error
+	Fail(context context.Context, scope, subject string)
+	Succeed(context context.Context, scope, subject string)
+}
+
+func NewBruteForceUsecase(config domain.BruteForceConfig, redisUsecase RedisUsecase, logger *logger.Logger) BruteForceUsecase {
+	return &bruteForceUsecase{Config: config, RedisUsecase: redisUsecase, logger: logger}
+}
+
+type bruteForceCounter struct {
+	kind      string
+	key       string
+	threshold int
+}
+
+func (b *bruteForceUsecase) counters(context context.Context, scope, subject string) []bruteForceCounter {
+	var counters []bruteForceCounter
+	if subject != "" {
+		counters = append(counters, bruteForceCounter{bruteForceSubject, bruteForceKey + scope + "/" + bruteForceSubject + "/" + subject, b.Config.MaxAttempts})
+	}
+	if ip, _ := helper.ClientInfoFromContext(context); ip != "" {
+		counters = append(counters, bruteForceCounter{bruteForceIp, bruteForceKey + scope + "/" + bruteForceIp + "/" + ip, b.Config.MaxIpAttempts})
+	}
+
+	return counters
+}
+
+// Check returns a TooManyAttemptsError while the subject or client is delayed or locked out.
+func (b *bruteForceUsecase) Check(context context.Context, scope, subject string) error {
+	span := tracer.StartSpanFromContext(context, "usecase/CheckBruteForce")
+	defer span.Finish()
+
+	var retryAfter time.Duration
+	for _, counter := range b.counters(context, scope, subject) {
+		for _, key := range []string{counter.key + "/lock", counter.key + "/delay"} {
+			if ttl := b.RedisUsecase.TimeToLive(context, key); ttl > retryAfter {
+				retryAfter = ttl
+			}
+		}
+	}
+
+	if retryAfter == 0 {
+		return nil
+	}
+
+	metrics.ThrottledAttempts.WithLabelValues(scope).Inc()
+	err := &TooManyAttemptsError{RetryAfter: retryAfter}
+	tracer.LogError(span, err)
+	return err
+}
+
+func (b *bruteForceUsecase) Fail(context context.Context, scope, subject string) {
+	metrics.FailedAttempts.WithLabelValues(scope).Inc()
+
+	for _, counter := range b.counters(context, scope, subject) {
+		attempts, err := b.RedisUsecase.Increment(context, counter.key, b.Config.Window)
+		if err != nil {
+			continue
+		}
+
+		if int(attempts) >= counter.threshold {
+			b.logger.Logger.Warnf("locking out %v %v for %v after %v failed %v attempts\n", counter.kind, counter.key, b.Config.Lockout, attempts, scope)
+			metrics.Lockouts.WithLabelValues(scope, counter.kind).Inc()
+			_ = b.RedisUsecase.AddKeyValueSet(context, counter.key+"/lock", attempts, b.Config.Lockout)
+			_ = b.RedisUsecase.DeleteValueByKey(context, counter.key)
+			continue
+		}
+
+		_ = b.RedisUsecase.AddKeyValueSet(context, counter.key+"/delay", attempts, b.delay(attempts))
+	}
+}
+
+// Succeed clears the subject's failures but keeps the client's.
+func (b *bruteForceUsecase) Succeed(context context.Context, scope, subject string) {
+	for _, counter := range b.counters(context, scope, subject) {
+		if counter.kind != bruteForceSubject {
+			continue
+		}
+		_ = b.RedisUsecase.DeleteValueByKey(context, counter.key)
+		_ = b.RedisUsecase.DeleteValueByKey(context, counter.key+"/delay")
+	}
+}
+
+func (b *bruteForceUsecase) delay(attempts int64) time.Duration {
+	delay := b.Config.BaseDelay
+	for i := int64(1); i < attempts && delay < b.Config.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > b.Config.MaxDelay {
+		delay = b.Config.MaxDelay
+	}
+
+	return delay
+}
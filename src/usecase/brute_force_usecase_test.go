@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+var bruteForceConfig = domain.BruteForceConfig{
+	MaxAttempts:   3,
+	MaxIpAttempts: 5,
+	Window:        15 * time.Minute,
+	BaseDelay:     time.Second,
+	MaxDelay:      4 * time.Second,
+	Lockout:       30 * time.Minute,
+}
+
+func retryAfter(t *testing.T, err error) time.Duration {
+	if err == nil {
+		return 0
+	}
+	var tooMany *TooManyAttemptsError
+	if !errors.As(err, &tooMany) {
+		t.Fatalf("err = %v, want a TooManyAttemptsError", err)
+	}
+	return tooMany.RetryAfter
+}
+
+func TestBruteForceSubject(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 0, want: 0},
+		{failures: 1, want: time.Second},
+		{failures: 2, want: 2 * time.Second},
+		{failures: 3, want: 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		usecase := NewBruteForceUsecase(bruteForceConfig, newMemoryRedis(), logger.InitializeLogger("auth-service", context.Background()))
+		for i := 0; i < tt.failures; i++ {
+			usecase.Fail(context.Background(), BruteForceLogin, "jelena")
+		}
+
+		if got := retryAfter(t, usecase.Check(context.Background(), BruteForceLogin, "jelena")); got != tt.want {
+			t.Errorf("after %v failures retry after %v, want %v", tt.failures, got, tt.want)
+		}
+		if got := retryAfter(t, usecase.Check(context.Background(), BruteForceTotp, "jelena")); got != 0 {
+			t.Errorf("after %v failures other scope retries after %v", tt.failures, got)
+		}
+	}
+}
+
+func TestBruteForceDelayIsCapped(t *testing.T) {
+	config := bruteForceConfig
+	config.MaxAttempts = 10
+	usecase := NewBruteForceUsecase(config, newMemoryRedis(), logger.InitializeLogger("auth-service", context.Background()))
+
+	for i := 0; i < 6; i++ {
+		usecase.Fail(context.Background(), BruteForceLogin, "jelena")
+	}
+
+	if got := retryAfter(t, usecase.Check(context.Background(), BruteForceLogin, "jelena")); got != config.MaxDelay {
+		t.Errorf("retry after %v, want %v", got, config.MaxDelay)
+	}
+}
+
+func TestBruteForceSucceed(t *testing.T) {
+	redis := newMemoryRedis()
+	usecase := NewBruteForceUsecase(bruteForceConfig, redis, logger.InitializeLogger("auth-service", context.Background()))
+
+	usecase.Fail(context.Background(), BruteForceLogin, "jelena")
+	usecase.Fail(context.Background(), BruteForceLogin, "jelena")
+	usecase.Succeed(context.Background(), BruteForceLogin, "jelena")
+
+	if err := usecase.Check(context.Background(), BruteForceLogin, "jelena"); err != nil {
+		t.Fatalf("still delayed after a success: %v", err)
+	}
+
+	// The count starts over, two more failures don't lock the account.
+	usecase.Fail(context.Background(), BruteForceLogin, "jelena")
+	usecase.Fail(context.Background(), BruteForceLogin, "jelena")
+	if got := retryAfter(t, usecase.Check(context.Background(), BruteForceLogin, "jelena")); got != 2*time.Second {
+		t.Errorf("retry after %v, want 2s", got)
+	}
+}
+
+func TestBruteForceIp(t *testing.T) {
+	redis := newMemoryRedis()
+	usecase := NewBruteForceUsecase(bruteForceConfig, redis, logger.InitializeLogger("auth-service", context.Background()))
+	attacker := context.WithValue(context.Background(), helper.ClientIpKey, "203.0.113.7")
+
+	// Guessing a different account every time never locks one of them.
+	for _, username := range []string{"a", "b", "c", "d", "e"} {
+		usecase.Fail(attacker, BruteForceLogin, username)
+		usecase.Succeed(attacker, BruteForceLogin, username)
+	}
+
+	if got := retryAfter(t, usecase.Check(attacker, BruteForceLogin, "f")); got != bruteForceConfig.Lockout {
+		t.Errorf("address retries after %v, want %v", got, bruteForceConfig.Lockout)
+	}
+
+	other := context.WithValue(context.Background(), helper.ClientIpKey, "198.51.100.1")
+	if err := usecase.Check(other, BruteForceLogin, "f"); err != nil {
+		t.Errorf("other address is locked out: %v", err)
+	}
+}
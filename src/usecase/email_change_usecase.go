@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/gateway"
+	"auth-service/helper"
+	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/tracer"
+	"auth-service/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"net/mail"
+	"net/url"
+)
+
+const (
+	emailChangeKey    = "emailChangeRequest/"
+	emailChangeCancel = "emailChangeCancel/"
+
+	emailTaken           = "email is already in use"
+	emailInvalid         = "email is not a valid address"
+	emailUnchanged       = "new email is the same as the current one"
+	emailChangeNotFound  = "no pending email change"
+	emailChangeCancelErr = "email change link is invalid or expired"
+)
+
+type emailChangeUsecase struct {
+	Config                domain.EmailChangeConfig
+	ProfileInfoRepository repository.ProfileInfoRepository
+	ProfileInfoUsecase    ProfileInfoUsecase
+	RedisUsecase          RedisUsecase
+	AuthenticationUsecase AuthenticationUsecase
+	UserGateway           gateway.UserGateway
+	MailUsecase           MailUsecase
+	SecurityEventUsecase  SecurityEventUsecase
+	BruteForceUsecase     BruteForceUsecase
+	AuditUsecase          AuditUsecase
+	logger                *logger.Logger
+}
+
+type EmailChangeUsecase interface {
+	RequestChange(context context.Context, userId string, dto dto.EmailChangeDto) error
+	ConfirmChange(context context.Context, userId, code string) error
+	CancelChange(context context.Context, token string) error
+}
+
+func NewEmailChangeUsecase(config domain.EmailChangeConfig, profileInfoRepository repository.ProfileInfoRepository, profileInfoUsecase ProfileInfoUsecase,
+	redisUsecase RedisUsecase, authenticationUsecase AuthenticationUsecase, userGateway gateway.UserGateway, mailUsecase MailUsecase, securityEventUsecase SecurityEventUsecase, bruteForceUsecase BruteForceUsecase, auditUsecase AuditUsecase, logger *logger.Logger) EmailChangeUsecase {
+	return &emailChangeUsecase{
+		Config:                config,
+		ProfileInfoRepository: profileInfoRepository,
+		ProfileInfoUsecase:    profileInfoUsecase,
+		RedisUsecase:          redisUsecase,
+		AuthenticationUsecase: authenticationUsecase,
+		UserGateway:           userGateway,
+		MailUsecase:           mailUsecase,
+		SecurityEventUsecase:  securityEventUsecase,
+		BruteForceUsecase:     bruteForceUsecase,
+		AuditUsecase:          auditUsecase,
+		logger:                logger,
+	}
+}
+
+// RequestChange stores a pending change and mails a code to the new address.
+func (e *emailChangeUsecase) RequestChange(context context.Context, userId string, dto dto.EmailChangeDto) error {
+	e.logger.Logger.Infof("requesting email change for user %v\n", userId)
+	span := tracer.StartSpanFromContext(context, "usecase/RequestEmailChange")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	account, err := e.ProfileInfoRepository.GetProfileInfoById(ctx1, userId)
+	if err != nil {
+		tracer.LogError(span, err)
+		return domain.NotFound(userNotFound)
+	}
+
+	if err := VerifyPassword(ctx1, dto.Password, account.Password); err != nil {
+		return domain.InvalidArgument(invalidOldPass)
+	}
+
+	if address, err := mail.ParseAddress(dto.Email); err != nil || address.Address != dto.Email {
+		return domain.InvalidArgument(emailInvalid)
+	}
+
+	if dto.Email == account.Email {
+		return domain.InvalidArgument(emailUnchanged)
+	}
+
+	if e.ProfileInfoUsecase.ExistsByUsernameOrEmail(ctx1, "", dto.Email) {
+		return domain.InvalidArgument(emailTaken)
+	}
+
+	e.discardPending(ctx1, userId)
+
+	code := helper.RandomStringGenerator(8)
+	codeHash, err := helper.Hash(code)
+	if err != nil {
+		tracer.LogError(span, err)
+		return errors.New(hashError)
+	}
+
+	cancelToken, err := helper.RandomToken(32)
+	if err != nil {
+		tracer.LogError(span, err)
+		return err
+	}
+
+	request, err := json.Marshal(domain.EmailChangeRequest{
+		NewEmail:        dto.Email,
+		CodeHash:        string(codeHash),
+		CancelTokenHash: hashNonce(cancelToken),
+	})
+	if err != nil {
+		tracer.LogError(span, err)
+		return err
+	}
+
+	if err := e.RedisUsecase.AddKeyValueSet(ctx1, emailChangeKey+userId, request, e.Config.Ttl); err != nil {
+		tracer.LogError(span, err)
+		return err
+	}
+	if err := e.RedisUsecase.AddKeyValueSet(ctx1, emailChangeCancel+hashNonce(cancelToken), userId, e.Config.Ttl); err != nil {
+		tracer.LogError(span, err)
+		return err
+	}
+
+	cancelLink := fmt.Sprintf("%s?token=%s", e.Config.CancelUrl, url.QueryEscape(cancelToken))
+	if err := e.MailUsecase.SendEmailChangeCodeMail(ctx1, dto.Email, account.Username, code); err != nil {
+		tracer.LogError(span, err)
+		e.discardPending(ctx1, userId)
+		return errors.New(emailNotSent)
+	}
+	if err := e.MailUsecase.SendEmailChangeNoticeMail(ctx1, account.Email, account.Username, dto.Email, cancelLink); err != nil {
+		tracer.LogError(span, err)
+		e.discardPending(ctx1, userId)
+		return errors.New(emailNotSent)
+	}
+
+	return nil
+}
+
+// ConfirmChange applies a pending change and reverts it when the user service rejects it.
+func (e *emailChangeUsecase) ConfirmChange(context context.Context, userId, code string) error {
+	e.logger.Logger.Infof("confirming email change for user %v\n", userId)
+	span := tracer.StartSpanFromContext(context, "usecase/ConfirmEmailChange")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	if err := e.BruteForceUsecase.Check(ctx1, BruteForceConfirmation, userId); err != nil {
+		tracer.LogError(span, err)
+		return err
+	}
+
+	request, err := e.pending(ctx1, userId)
+	if err != nil {
+		tracer.LogError(span, err)
+		return err
+	}
+
+	if err := VerifyPassword(ctx1, code, request.CodeHash); err != nil {
+		e.logger.Logger.Errorf("error while confirming email change for user %v, error: %v\n", userId, invalidCode)
+		e.BruteForceUsecase.Fail(ctx1, BruteForceConfirmation, userId)
+		return domain.InvalidArgument(invalidCode)
+	}
+	e.BruteForceUsecase.Succeed(ctx1, BruteForceConfirmation, userId)
+
+	account, err := e.ProfileInfoRepository.GetProfileInfoById(ctx1, userId)
+	if err != nil {
+		tracer.LogError(span, err)
+		return domain.NotFound(userNotFound)
+	}
+
+	err = e.ProfileInfoRepository.UpdateEmail(ctx1, userId, account.Email, request.NewEmail)
+	if err != nil {
+		tracer.LogError(span, err)
+		if e.ProfileInfoUsecase.ExistsByUsernameOrEmail(ctx1, "", request.NewEmail) {
+			return domain.InvalidArgument(emailTaken)
+		}
+		return errors.New(updateError)
+	}
+
+	if err := e.UserGateway.UpdateUserEmail(ctx1, userId, request.NewEmail); err != nil {
+		e.logger.Logger.Errorf("error while propagating email change for user %v, error: %v\n", userId, err)
+		tracer.LogError(span, err)
+		if err := e.ProfileInfoRepository.UpdateEmail(ctx1, userId, request.NewEmail, account.Email); err != nil {
+			e.logger.Logger.Errorf("error while reverting email change for user %v, error: %v\n", userId, err)
+			reason := fmt.Sprintf("email is %v, user service has %v", request.NewEmail, account.Email)
+			e.AuditUsecase.RecordWithReason(ctx1, domain.AuditActorSystem, userId, domain.AuditEmailChangeNotReverted, reason, err)
+		}
+		return errors.New(updateError)
+	}
+
+	e.discardPending(ctx1, userId)
+	e.SecurityEventUsecase.Publish(ctx1, domain.SecurityEvent{Type: domain.SecurityEventEmailChanged, ProfileInfoId: userId, PreviousEmail: account.Email})
+
+	if err := e.AuthenticationUsecase.RevokeUserSessions(ctx1, userId); err != nil {
+		e.logger.Logger.Errorf("error while revoking sessions for user %v, error: %v\n", userId, err)
+		tracer.LogError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (e *emailChangeUsecase) CancelChange(context context.Context, token string) error {
+	span := tracer.StartSpanFromContext(context, "usecase/CancelEmailChange")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	userId, err := e.RedisUsecase.GetValueByKey(ctx1, emailChangeCancel+hashNonce(token))
+	if err != nil {
+		tracer.LogError(span, err)
+		return domain.InvalidArgument(emailChangeCancelErr)
+	}
+
+	e.logger.Logger.Infof("cancelling email change for user %v\n", string(userId))
+	e.discardPending(ctx1, string(userId))
+
+	return nil
+}
+
+func (e *emailChangeUsecase) pending(context context.Context, userId string) (*domain.EmailChangeRequest, error) {
+	value, err := e.RedisUsecase.GetValueByKey(context, emailChangeKey+userId)
+	if err != nil {
+		return nil, domain.NotFound(emailChangeNotFound)
+	}
+
+	var request domain.EmailChangeRequest
+	if err := json.Unmarshal(value, &request); err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+func (e *emailChangeUsecase) discardPending(context context.Context, userId string) {
+	request, err := e.pending(context, userId)
+	if err != nil {
+		return
+	}
+
+	_ = e.RedisUsecase.DeleteValueByKey(context, emailChangeCancel+request.CancelTokenHash)
+	_ = e.RedisUsecase.DeleteValueByKey(context, emailChangeKey+userId)
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/dto"
+	"auth-service/repository"
+	"context"
+	"errors"
+	"testing"
+
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+type emailRepository struct {
+	repository.ProfileInfoRepository
+	account   domain.ProfileInfo
+	revertErr error
+}
+
+func (e *emailRepository) GetProfileInfoById(context context.Context, id string) (*domain.ProfileInfo, error) {
+	account := e.account
+	return &account, nil
+}
+
+func (e *emailRepository) UpdateEmail(context context.Context, id, from, to string) error {
+	if e.account.Email != from {
+		return errors.New("email changed meanwhile")
+	}
+	if e.revertErr != nil && from != "jelena@example.com" {
+		return e.revertErr
+	}
+	e.account.Email = to
+	return nil
+}
+
+type changeMails struct {
+	MailUsecase
+	code string
+}
+
+func (c *changeMails) SendEmailChangeCodeMail(context context.Context, subjectMail, subjectName, verCode string) error {
+	c.code = verCode
+	return nil
+}
+
+func (c *changeMails) SendEmailChangeNoticeMail(context context.Context, subjectMail, subjectName, newEmail, cancelLink string) error {
+	return nil
+}
+
+type userService struct {
+	email string
+	err   error
+}
+
+func (u *userService) SaveRegisteredUser(context context.Context, user *domain.User) error {
+	return nil
+}
+
+func (u *userService) UpdateUserEmail(context context.Context, userId, email string) error {
+	if u.err != nil {
+		return u.err
+	}
+	u.email = email
+	return nil
+}
+
+type revokedSessions struct {
+	AuthenticationUsecase
+	users []string
+}
+
+func (r *revokedSessions) RevokeUserSessions(ctx context.Context, userId string) error {
+	r.users = append(r.users, userId)
+	return nil
+}
+
+type noBruteForce struct{}
+
+func (noBruteForce) Check(context context.Context, scope, subject string) error { return nil }
+func (noBruteForce) Fail(context context.Context, scope, subject string)        {}
+func (noBruteForce) Succeed(context context.Context, scope, subject string)     {}
+
+type auditTrail struct {
+	AuditUsecase
+	actions []string
+}
+
+func (a *auditTrail) Record(context context.Context, actor, target, action string, err error) {
+	a.actions = append(a.actions, action)
+}
+
+func (a *auditTrail) RecordWithReason(context context.Context, actor, target, action, reason string, err error) {
+	a.actions = append(a.actions, action)
+}
+
+func TestConfirmEmailChange(t *testing.T) {
+	tests := []struct {
+		name        string
+		wrongCode   bool
+		userErr     error
+		revertErr   error
+		wantErr     bool
+		wantEmail   string
+		wantRevoked bool
+		wantAudit   []string
+	}{
+		{name: "confirmed", wantEmail: "new@example.com", wantRevoked: true},
+		{name: "wrong code", wrongCode: true, wantErr: true, wantEmail: "jelena@example.com"},
+		{name: "user service rejects the change", userErr: errors.New("bad gateway"), wantErr: true, wantEmail: "jelena@example.com"},
+		{name: "change can't be reverted", userErr: errors.New("bad gateway"), revertErr: errors.New("connection refused"),
+			wantErr: true, wantEmail: "new@example.com", wantAudit: []string{domain.AuditEmailChangeNotReverted}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts := &emailRepository{account: domain.ProfileInfo{ID: "1", Username: "jelena", Email: "jelena@example.com", Password: hashed(t, "password")}, revertErr: tt.revertErr}
+			redis := newMemoryRedis()
+			mails := &changeMails{}
+			users := &userService{err: tt.userErr}
+			sessions := &revokedSessions{}
+			audit := &auditTrail{}
+			usecase := NewEmailChangeUsecase(domain.EmailChangeConfig{}, accounts, knownProfiles{}, redis, sessions, users, mails, ignoredEvents{}, noBruteForce{}, audit,
+				logger.InitializeLogger("auth-service", context.Background()))
+
+			if err := usecase.RequestChange(context.Background(), "1", dto.EmailChangeDto{Email: "new@example.com", Password: "password"}); err != nil {
+				t.Fatal(err)
+			}
+			code := mails.code
+			if tt.wrongCode {
+				code = "wrong"
+			}
+
+			err := usecase.ConfirmChange(context.Background(), "1", code)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if accounts.account.Email != tt.wantEmail {
+				t.Errorf("email = %v, want %v", accounts.account.Email, tt.wantEmail)
+			}
+			if tt.wantRevoked != (len(sessions.users) == 1) {
+				t.Errorf("revoked sessions of %v", sessions.users)
+			}
+			if tt.wantRevoked && users.email != "new@example.com" {
+				t.Errorf("user service has %q", users.email)
+			}
+			if len(audit.actions) != len(tt.wantAudit) || (len(tt.wantAudit) > 0 && audit.actions[0] != tt.wantAudit[0]) {
+				t.Errorf("audited %v, want %v", audit.actions, tt.wantAudit)
+			}
+			if pending := redis.ExistsByKey(context.Background(), emailChangeKey+"1"); pending == (err == nil) {
+				t.Errorf("change still pending: %v", pending)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/tracer"
+	"auth-service/repository"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"time"
+)
+
+const (
+	impersonationOwnAccount = "admins can't impersonate themselves"
+	impersonationOfAdmin    = "admin accounts can't be impersonated"
+	impersonationNotFound   = "impersonation not found or already ended"
+)
+
+type impersonationUsecase struct {
+	Config                domain.ImpersonationConfig
+	ProfileInfoRepository repository.ProfileInfoRepository
+	JwtUsecase            JwtUsecase
+	AuthenticationUsecase AuthenticationUsecase
+	AuditUsecase          AuditUsecase
+	logger                *logger.Logger
+}
+
+type ImpersonationUsecase interface {
+	Start(context context.Context, actor, userId, reason string) (*domain.Impersonation, error)
+	End(context context.Context, actor, tokenUuid string) error
+}
+
+func NewImpersonationUsecase(config domain.ImpersonationConfig, profileInfoRepository repository.ProfileInfoRepository, jwtUsecase JwtUsecase, authenticationUsecase AuthenticationUsecase, auditUsecase AuditUsecase, logger *logger.Logger) ImpersonationUsecase {
+	return &impersonationUsecase{Config: config, ProfileInfoRepository: profileInfoRepository, JwtUsecase: jwtUsecase, AuthenticationUsecase: authenticationUsecase, AuditUsecase: auditUsecase, logger: logger}
+}
+
+// Start issues a short-lived access token for the user naming the admin in its act claim.
+func (i *impersonationUsecase) Start(context context.Context, actor, userId, reason string) (*domain.Impersonation, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/StartImpersonation")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	account, err := i.validateStart(ctx1, actor, userId, reason)
+	if err != nil {
+		tracer.LogError(span, err)
+		i.AuditUsecase.RecordWithReason(ctx1, actor, userId, domain.AuditImpersonationStarted, reason, err)
+		return nil, err
+	}
+
+	td, err := i.JwtUsecase.CreateImpersonationToken(ctx1, account.RoleNames(), account.ID, actor, i.Config.Ttl)
+	i.AuditUsecase.RecordWithReason(ctx1, actor, userId, domain.AuditImpersonationStarted, reason, err)
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	i.logger.Logger.Warnf("admin %v is impersonating user %v, reason: %v\n", actor, userId, reason)
+
+	return &domain.Impersonation{
+		TokenUuid:   td.TokenUuid,
+		AccessToken: td.AccessToken,
+		UserId:      account.ID,
+		ActorId:     actor,
+		ExpiresAt:   time.Unix(td.AtExpires, 0),
+	}, nil
+}
+
+func (i *impersonationUsecase) validateStart(context context.Context, actor, userId, reason string) (*domain.ProfileInfo, error) {
+	if reason == "" {
+		return nil, domain.InvalidArgument(statusReasonRequired)
+	}
+	if actor == userId {
+		return nil, domain.PermissionDenied(domain.ReasonForbidden, impersonationOwnAccount)
+	}
+
+	account, err := i.ProfileInfoRepository.GetProfileInfoById(context, userId)
+	if err != nil {
+		return nil, domain.NotFound(userNotFound)
+	}
+	if account.HasRole(domain.RoleAdmin) {
+		return nil, domain.PermissionDenied(domain.ReasonForbidden, impersonationOfAdmin)
+	}
+	if err := CheckAccountStatus(*account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// End revokes an impersonation token before it expires.
+func (i *impersonationUsecase) End(context context.Context, actor, tokenUuid string) error {
+	span := tracer.StartSpanFromContext(context, "usecase/EndImpersonation")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	token, err := i.AuthenticationUsecase.FetchAuthToken(ctx1, tokenUuid)
+	if err != nil {
+		tracer.LogError(span, err)
+		return domain.NotFound(impersonationNotFound)
+	}
+
+	impersonator, err := i.JwtUsecase.ExtractActorId(ctx1, string(token))
+	if err != nil || *impersonator == "" {
+		return domain.NotFound(impersonationNotFound)
+	}
+
+	userId, err := i.JwtUsecase.ExtractUserId(ctx1, string(token))
+	if err != nil || userId == nil {
+		return domain.NotFound(impersonationNotFound)
+	}
+
+	err = i.AuthenticationUsecase.DeleteAuthToken(ctx1, tokenUuid)
+	i.AuditUsecase.RecordWithReason(ctx1, actor, *userId, domain.AuditImpersonationEnded, "started by "+*impersonator, err)
+	if err != nil {
+		tracer.LogError(span, err)
+		return err
+	}
+
+	i.logger.Logger.Warnf("impersonation of user %v by %v ended by %v\n", *userId, *impersonator, actor)
+
+	return nil
+}
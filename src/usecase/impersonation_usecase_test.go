@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/repository"
+	"context"
+	"testing"
+	"time"
+
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+type noScopes struct {
+	repository.RoleRepository
+}
+
+func (noScopes) GetScopeNames(context context.Context, roleNames []string) ([]string, error) {
+	return nil, nil
+}
+
+type impersonationFixture struct {
+	usecase        ImpersonationUsecase
+	authentication AuthenticationUsecase
+	jwt            JwtUsecase
+	accounts       *accountRepository
+	audit          *auditTrail
+}
+
+func newImpersonationFixture(account domain.ProfileInfo) *impersonationFixture {
+	log := logger.InitializeLogger("auth-service", context.Background())
+	authentication := NewAuthenticationUsecase(newMemoryRedis(), log)
+	jwt := NewJwtUsecase(newMemoryRedis(), log, authentication, noScopes{})
+	f := &impersonationFixture{
+		authentication: authentication,
+		jwt:            jwt,
+		accounts:       &accountRepository{account: &account},
+		audit:          &auditTrail{},
+	}
+	f.usecase = NewImpersonationUsecase(domain.ImpersonationConfig{Ttl: 15 * time.Minute}, f.accounts, jwt, authentication, f.audit, log)
+	return f
+}
+
+func TestStartImpersonationIsRefused(t *testing.T) {
+	suspendedUntil := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		account  domain.ProfileInfo
+		actor    string
+		reason   string
+		wantKind string
+	}{
+		{name: "no reason", account: domain.ProfileInfo{ID: "1"}, actor: "admin", wantKind: domain.ErrorInvalidArgument},
+		{name: "own account", account: domain.ProfileInfo{ID: "1"}, actor: "1", reason: "support", wantKind: domain.ErrorPermissionDenied},
+		{name: "another admin", account: domain.ProfileInfo{ID: "1", Roles: []domain.Role{{RoleName: domain.RoleAdmin}}}, actor: "admin", reason: "support", wantKind: domain.ErrorPermissionDenied},
+		{name: "suspended user", account: domain.ProfileInfo{ID: "1", SuspendedUntil: &suspendedUntil}, actor: "admin", reason: "support", wantKind: domain.ErrorPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newImpersonationFixture(tt.account)
+
+			impersonation, err := f.usecase.Start(context.Background(), tt.actor, "1", tt.reason)
+			if impersonation != nil || domain.AsError(err).Kind != tt.wantKind {
+				t.Fatalf("impersonation = %v, err = %v, want %v", impersonation, err, tt.wantKind)
+			}
+			if len(f.audit.actions) != 1 || f.audit.actions[0] != domain.AuditImpersonationStarted {
+				t.Errorf("audited %v, want the refused attempt", f.audit.actions)
+			}
+		})
+	}
+}
+
+func TestImpersonationStartAndEnd(t *testing.T) {
+	f := newImpersonationFixture(domain.ProfileInfo{ID: "1", Roles: []domain.Role{{RoleName: domain.RoleUser}}})
+	ctx := context.Background()
+
+	impersonation, err := f.usecase.Start(ctx, "admin", "1", "ticket 42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if impersonation.UserId != "1" || impersonation.ActorId != "admin" {
+		t.Errorf("impersonation = %+v", impersonation)
+	}
+
+	token, err := f.authentication.FetchAuthToken(ctx, impersonation.TokenUuid)
+	if err != nil {
+		t.Fatalf("token wasn't stored: %v", err)
+	}
+	if actor, err := f.jwt.ExtractActorId(ctx, string(token)); err != nil || *actor != "admin" {
+		t.Errorf("act claim = %v, %v", actor, err)
+	}
+
+	if err := f.usecase.End(ctx, "other-admin", impersonation.TokenUuid); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.authentication.FetchAuthToken(ctx, impersonation.TokenUuid); err == nil {
+		t.Error("token still valid after the impersonation ended")
+	}
+	if err := f.usecase.End(ctx, "other-admin", impersonation.TokenUuid); domain.AsError(err).Kind != domain.ErrorNotFound {
+		t.Errorf("ending twice: err = %v, want not found", err)
+	}
+
+	want := []string{domain.AuditImpersonationStarted, domain.AuditImpersonationEnded}
+	if len(f.audit.actions) != 2 || f.audit.actions[0] != want[0] || f.audit.actions[1] != want[1] {
+		t.Errorf("audited %v, want %v", f.audit.actions, want)
+	}
+}
+
+func TestEndRefusesOrdinaryTokens(t *testing.T) {
+	f := newImpersonationFixture(domain.ProfileInfo{ID: "1"})
+	ctx := context.Background()
+
+	td, err := f.jwt.CreateToken(ctx, []string{domain.RoleUser}, "1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.usecase.End(ctx, "admin", td.TokenUuid); domain.AsError(err).Kind != domain.ErrorNotFound {
+		t.Errorf("err = %v, want not found", err)
+	}
+	if _, err := f.authentication.FetchAuthToken(ctx, td.TokenUuid); err != nil {
+		t.Errorf("user's own session was revoked: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"auth-service/domain"
+	"auth-service/helper"
 	"auth-service/infrastructure/tracer"
 	"auth-service/repository"
 	"context"
@@ -10,6 +11,7 @@ import (
 	logger "github.com/jelena-vlajkov/logger/logger"
 	"github.com/twinj/uuid"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -34,7 +36,7 @@ func (j *jwtUsecase) RefreshToken(context context.Context, tokenString string) (
 		return nil, nil, err
 	}
 
-	role, err := j.ExtractRole(ctx1, tokenString)
+	roles, err := j.ExtractRoles(ctx1, tokenString)
 
 	if err != nil {
 		tracer.LogError(span, err)
@@ -42,7 +44,7 @@ func (j *jwtUsecase) RefreshToken(context context.Context, tokenString string) (
 	}
 
 	td := &domain.TokenDetails{}
-	_, err = j.CreateAccessToken(ctx1, *role, *userId, td)
+	_, err = j.CreateAccessToken(ctx1, roles, *userId, td)
 
 	if err != nil {
 		tracer.LogError(span, err)
@@ -105,6 +107,67 @@ func (j *jwtUsecase) ExtractRole(context context.Context, tokenString string) (*
 	return nil, err
 }
 
+// ExtractRoles returns the token's roles, lower-cased.
+func (j *jwtUsecase) ExtractRoles(context context.Context, tokenString string) ([]string, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/ExtractRoles")
+	defer span.Finish()
+
+	token, err := verifyToken(tokenString)
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, domain.Unauthenticated(domain.ReasonInvalidToken, "unexpected claims of token")
+	}
+
+	roles := helper.RolesFromClaims(claims)
+	for i := range roles {
+		roles[i] = strings.ToLower(roles[i])
+	}
+
+	return roles, nil
+}
+
+func (j *jwtUsecase) ExtractScopes(context context.Context, tokenString string) ([]string, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/ExtractScopes")
+	defer span.Finish()
+
+	token, err := verifyToken(tokenString)
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, domain.Unauthenticated(domain.ReasonInvalidToken, "unexpected claims of token")
+	}
+
+	return helper.ScopesFromClaims(claims), nil
+}
+
+// ExtractActorId returns the impersonating admin of the token, if any.
+func (j *jwtUsecase) ExtractActorId(context context.Context, tokenString string) (*string, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/ExtractActorId")
+	defer span.Finish()
+
+	token, err := verifyToken(tokenString)
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	actorId := ""
+	if claims, ok := token.Claims.(jwt.MapClaims); ok {
+		actorId, _ = claims[domain.ImpersonationClaim].(string)
+	}
+
+	return &actorId, nil
+}
+
 func (j *jwtUsecase) ExtractRefreshUuid(context context.Context, tokenString string) (*string, error) {
 	span := tracer.StartSpanFromContext(context, "usecase/ExtractRefreshUuid")
 	defer span.Finish()
@@ -154,28 +217,36 @@ func (j *jwtUsecase) DeleteRefreshToken(context context.Context, tokenUuid strin
 	return j.RedisUsecase.DeleteValueByKey(context, refreshToken + tokenUuid)
 }
 type JwtUsecase interface {
-	CreateAccessToken(context context.Context, role string, userId string, td *domain.TokenDetails) (*domain.TokenDetails, error)
+	CreateAccessToken(context context.Context, roles []string, userId string, td *domain.TokenDetails) (*domain.TokenDetails, error)
 	CreateTemporaryToken(context context.Context, role, userId string) (*domain.TemporaryTokenDetails, error)
+	CreatePasswordChangeToken(context context.Context, userId string) (*domain.TokenDetails, error)
 	ValidateToken(context context.Context, tokenString string) (string,error)
-	CreateRefreshToken(context context.Context, userId, role string, td *domain.TokenDetails) (*domain.TokenDetails, error)
-	CreateToken(context context.Context, role, userId string, refresh bool) (*domain.TokenDetails, error)
+	CreateRefreshToken(context context.Context, userId string, roles []string, td *domain.TokenDetails) (*domain.TokenDetails, error)
+	CreateToken(context context.Context, roles []string, userId string, refresh bool) (*domain.TokenDetails, error)
 	ExtractExpiration(context context.Context, tokenString string) (*time.Time, error)
 	ExtractRole(context context.Context, tokenString string) (*string, error)
+	ExtractRoles(context context.Context, tokenString string) ([]string, error)
+	ExtractUserId(context context.Context, tokenString string) (*string, error)
 	RefreshToken(context context.Context, tokenString string) (*string, *string, error)
 	DeleteRefreshToken(context context.Context, tokenUuid string) error
 	ValidateRefreshToken(context context.Context, refreshTokenUuid string) (*string, error)
+	CreateImpersonationToken(context context.Context, roles []string, userId, actorId string, ttl time.Duration) (*domain.TokenDetails, error)
+	CreateClientToken(context context.Context, clientId string, scopes []string, ttl time.Duration) (*domain.TokenDetails, error)
+	ExtractScopes(context context.Context, tokenString string) ([]string, error)
+	ExtractActorId(context context.Context, tokenString string) (*string, error)
 }
-func NewJwtUsecase(usecase RedisUsecase, logger *logger.Logger, authUsecase AuthenticationUsecase) JwtUsecase {
-	return &jwtUsecase{RedisUsecase: usecase, logger: logger, AuthenticationUsecase: authUsecase}
+func NewJwtUsecase(usecase RedisUsecase, logger *logger.Logger, authUsecase AuthenticationUsecase, roleRepository repository.RoleRepository) JwtUsecase {
+	return &jwtUsecase{RedisUsecase: usecase, logger: logger, AuthenticationUsecase: authUsecase, roleRepository: roleRepository}
 }
 
-func (j *jwtUsecase) CreateAccessToken(context context.Context, role string, userId string, td *domain.TokenDetails) (*domain.TokenDetails, error) {
+func (j *jwtUsecase) CreateAccessToken(context context.Context, roles []string, userId string, td *domain.TokenDetails) (*domain.TokenDetails, error) {
 	j.logger.Logger.Infof("creating access token for user %v\n", userId)
 	span := tracer.StartSpanFromContext(context, "CreateAccessToken")
 	defer span.Finish()
 
 	td.AtExpires = time.Now().Add(time.Second * 3600).Unix()
 	td.TokenUuid = uuid.NewV4().String()
+	td.UserId = userId
 
 	var err error
 
@@ -184,8 +255,12 @@ func (j *jwtUsecase) CreateAccessToken(context context.Context, role string, use
 	atClaims["access_uuid"] = td.TokenUuid
 	atClaims["refresh_uuid"] = td.RefreshUuid
 	atClaims["exp"] = td.AtExpires
-	atClaims["role"] = role
+	setRoleClaims(atClaims, roles)
 	atClaims["user_id"] = userId
+	if err = j.setScopeClaim(context, atClaims, roles); err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
 
 
 	at := jwt.NewWithClaims(jwt.SigningMethodHS256, atClaims)
@@ -246,7 +321,7 @@ func (j *jwtUsecase) CreateTemporaryToken(context context.Context, role, userId
 
 	tokenClaims["exp"] = td.Expires
 	tokenClaims["user_id"] = userId
-	tokenClaims["role"] = "temporary_user"
+	tokenClaims["role"] = domain.RoleTemporaryUser
 	tokenClaims["token_uuid"] = td.TokenUuid
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, tokenClaims)
@@ -264,20 +339,136 @@ func (j *jwtUsecase) CreateTemporaryToken(context context.Context, role, userId
 	return td, err
 }
 
-func (j *jwtUsecase) CreateRefreshToken(context context.Context, userId, role string, td *domain.TokenDetails) (*domain.TokenDetails, error) {
+func (j *jwtUsecase) CreatePasswordChangeToken(context context.Context, userId string) (*domain.TokenDetails, error) {
+	j.logger.Logger.Infof("creating password change token for user %v\n", userId)
+	span := tracer.StartSpanFromContext(context, "CreatePasswordChangeToken")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	td := &domain.TokenDetails{UserId: userId}
+	td.AtExpires = time.Now().Add(time.Minute * 15).Unix()
+	td.TokenUuid = uuid.NewV4().String()
+
+	tokenClaims := jwt.MapClaims{}
+	tokenClaims["authorized"] = true
+	tokenClaims["access_uuid"] = td.TokenUuid
+	tokenClaims["exp"] = td.AtExpires
+	tokenClaims["role"] = domain.RolePasswordExpired
+	tokenClaims["user_id"] = userId
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, tokenClaims)
+
+	var err error
+	td.AccessToken, err = token.SignedString([]byte(os.Getenv("ACCESS_SECRET")))
+	if err != nil {
+		j.logger.Logger.Errorf("error while creating password change token for user %v, error: %v\n", userId, err)
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	if err := j.AuthenticationUsecase.SaveAuthToken(ctx1, 0, td); err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	return td, nil
+}
+
+// CreateImpersonationToken creates an access token with an act claim and no refresh token.
+func (j *jwtUsecase) CreateImpersonationToken(context context.Context, roles []string, userId, actorId string, ttl time.Duration) (*domain.TokenDetails, error) {
+	j.logger.Logger.Infof("creating impersonation token for user %v on behalf of %v\n", userId, actorId)
+	span := tracer.StartSpanFromContext(context, "CreateImpersonationToken")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	td := &domain.TokenDetails{UserId: userId}
+	td.AtExpires = time.Now().Add(ttl).Unix()
+	td.TokenUuid = uuid.NewV4().String()
+
+	atClaims := jwt.MapClaims{}
+	atClaims["authorized"] = true
+	atClaims["access_uuid"] = td.TokenUuid
+	atClaims["exp"] = td.AtExpires
+	setRoleClaims(atClaims, roles)
+	atClaims["user_id"] = userId
+	atClaims[domain.ImpersonationClaim] = actorId
+	if err := j.setScopeClaim(ctx1, atClaims, roles); err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	at := jwt.NewWithClaims(jwt.SigningMethodHS256, atClaims)
+
+	var err error
+	td.AccessToken, err = at.SignedString([]byte(os.Getenv("ACCESS_SECRET")))
+	if err != nil {
+		j.logger.Logger.Errorf("error while creating impersonation token for user %v, error: %v\n", userId, err)
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	if err := j.AuthenticationUsecase.SaveAuthToken(ctx1, 0, td); err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	return td, nil
+}
+
+// CreateClientToken creates a scoped access token for an OAuth client, with no refresh token.
+func (j *jwtUsecase) CreateClientToken(context context.Context, clientId string, scopes []string, ttl time.Duration) (*domain.TokenDetails, error) {
+	j.logger.Logger.Infof("creating token for oauth client %v\n", clientId)
+	span := tracer.StartSpanFromContext(context, "CreateClientToken")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	td := &domain.TokenDetails{UserId: domain.OAuthClientSessionPrefix + clientId}
+	td.AtExpires = time.Now().Add(ttl).Unix()
+	td.TokenUuid = uuid.NewV4().String()
+
+	atClaims := jwt.MapClaims{}
+	atClaims["authorized"] = true
+	atClaims["access_uuid"] = td.TokenUuid
+	atClaims["exp"] = td.AtExpires
+	atClaims["client_id"] = clientId
+	atClaims[domain.ScopeClaim] = domain.JoinScopes(scopes)
+
+	at := jwt.NewWithClaims(jwt.SigningMethodHS256, atClaims)
+
+	var err error
+	td.AccessToken, err = at.SignedString([]byte(os.Getenv("ACCESS_SECRET")))
+	if err != nil {
+		j.logger.Logger.Errorf("error while creating token for oauth client %v, error: %v\n", clientId, err)
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	if err := j.AuthenticationUsecase.SaveAuthToken(ctx1, 0, td); err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	return td, nil
+}
+
+func (j *jwtUsecase) CreateRefreshToken(context context.Context, userId string, roles []string, td *domain.TokenDetails) (*domain.TokenDetails, error) {
 	j.logger.Logger.Infof("creating refresh for user %v\n", userId)
 	span := tracer.StartSpanFromContext(context, "CreateRefreshToken")
 	defer span.Finish()
 
 	td.RtExpires = time.Now().Add(time.Hour * 24 * 7).Unix()
 	td.RefreshUuid = uuid.NewV4().String()
+	td.UserId = userId
 
 
 	rtClaims := jwt.MapClaims{}
 	rtClaims["refresh_uuid"] = td.RefreshUuid
 	rtClaims["user_id"] = userId
 	rtClaims["exp"] = td.RtExpires
-	rtClaims["role"] = role
+	setRoleClaims(rtClaims, roles)
 
 	rt := jwt.NewWithClaims(jwt.SigningMethodHS256, rtClaims)
 	refreshTokenString, err := rt.SignedString([]byte(os.Getenv("REFRESH_SECRET")))
@@ -290,7 +481,7 @@ func (j *jwtUsecase) CreateRefreshToken(context context.Context, userId, role st
 	return td, nil
 }
 
-func (j *jwtUsecase) CreateToken(context context.Context, role, userId string, refresh bool) (*domain.TokenDetails, error) {
+func (j *jwtUsecase) CreateToken(context context.Context, roles []string, userId string, refresh bool) (*domain.TokenDetails, error) {
 	j.logger.Logger.Infof("creating token for user %v\n", userId)
 	span := tracer.StartSpanFromContext(context, "CreateToken")
 	defer span.Finish()
@@ -298,10 +489,10 @@ func (j *jwtUsecase) CreateToken(context context.Context, role, userId string, r
 	ctx1 := tracer.ContextWithSpan(context, span)
 	td := &domain.TokenDetails{}
 
-	_, err := j.CreateAccessToken(ctx1, role, userId, td)
+	_, err := j.CreateAccessToken(ctx1, roles, userId, td)
 
 	if refresh {
-		_, err := j.CreateRefreshToken(ctx1, userId, role, td)
+		_, err := j.CreateRefreshToken(ctx1, userId, roles, td)
 		if err != nil {
 			tracer.LogError(span, err)
 			return nil, err
@@ -329,6 +520,25 @@ func (j *jwtUsecase) CreateToken(context context.Context, role, userId string, r
 
 }
 
+// setRoleClaims sets the roles claim and the first role in the role claim.
+func setRoleClaims(claims jwt.MapClaims, roles []string) {
+	claims["roles"] = roles
+	if len(roles) > 0 {
+		claims["role"] = roles[0]
+	}
+}
+
+// setScopeClaim grants the token the current scopes of its roles.
+func (j *jwtUsecase) setScopeClaim(context context.Context, claims jwt.MapClaims, roles []string) error {
+	scopes, err := j.roleRepository.GetScopeNames(context, roles)
+	if err != nil {
+		return err
+	}
+
+	claims[domain.ScopeClaim] = domain.JoinScopes(scopes)
+	return nil
+}
+
 func (j *jwtUsecase) ValidateRefreshToken(context context.Context, refreshTokenUuid string) (*string, error) {
 
 	return nil, nil
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"auth-service/infrastructure/tracer"
+	"auth-service/repository"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"time"
+)
+
+type knownDeviceUsecase struct {
+	KnownDeviceRepository repository.KnownDeviceRepository
+	SecurityEventUsecase  SecurityEventUsecase
+	logger                *logger.Logger
+}
+
+type KnownDeviceUsecase interface {
+	RecordLogin(context context.Context, profileInfoId string) error
+}
+
+func NewKnownDeviceUsecase(knownDeviceRepository repository.KnownDeviceRepository, securityEventUsecase SecurityEventUsecase, logger *logger.Logger) KnownDeviceUsecase {
+	return &knownDeviceUsecase{KnownDeviceRepository: knownDeviceRepository, SecurityEventUsecase: securityEventUsecase, logger: logger}
+}
+
+// RecordLogin publishes a new device event for a device or IP address not seen before.
+func (k *knownDeviceUsecase) RecordLogin(context context.Context, profileInfoId string) error {
+	span := tracer.StartSpanFromContext(context, "usecase/RecordLogin")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	ipAddress, userAgent := helper.ClientInfoFromContext(ctx1)
+	fingerprint := hashNonce(userAgent)
+
+	device, err := k.KnownDeviceRepository.Get(ctx1, profileInfoId, fingerprint, ipAddress)
+	if err == nil {
+		device.LastSeenAt = time.Now()
+		return k.KnownDeviceRepository.Save(ctx1, device)
+	}
+
+	known, err := k.KnownDeviceRepository.CountByProfileInfoId(ctx1, profileInfoId)
+	if err != nil {
+		tracer.LogError(span, err)
+		return err
+	}
+
+	device = &domain.KnownDevice{
+		ProfileInfoId: profileInfoId,
+		Fingerprint:   fingerprint,
+		IpAddress:     ipAddress,
+		LastSeenAt:    time.Now(),
+	}
+	if err := k.KnownDeviceRepository.Save(ctx1, device); err != nil {
+		tracer.LogError(span, err)
+		return err
+	}
+
+	if known > 0 {
+		k.logger.Logger.Warnf("login from a new device for user %v from IP address %v\n", profileInfoId, ipAddress)
+		k.SecurityEventUsecase.Publish(ctx1, domain.SecurityEvent{
+			Type:          domain.SecurityEventNewDeviceLogin,
+			ProfileInfoId: profileInfoId,
+			IpAddress:     ipAddress,
+			UserAgent:     userAgent,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"auth-service/infrastructure/tracer"
+	"auth-service/repository"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+const (
+	loginEventDefaultLimit = 20
+	loginEventMaxLimit     = 100
+)
+
+type loginEventUsecase struct {
+	LoginEventRepository repository.LoginEventRepository
+	logger               *logger.Logger
+}
+
+type LoginEventUsecase interface {
+	Record(context context.Context, event domain.LoginEvent)
+	GetActivity(context context.Context, profileInfoId string, limit int) ([]domain.LoginEvent, error)
+	Search(context context.Context, filter domain.LoginEventFilter) ([]domain.LoginEvent, error)
+}
+
+func NewLoginEventUsecase(loginEventRepository repository.LoginEventRepository, logger *logger.Logger) LoginEventUsecase {
+	return &loginEventUsecase{LoginEventRepository: loginEventRepository, logger: logger}
+}
+
+// Record stores a login attempt, only logging errors.
+func (l *loginEventUsecase) Record(context context.Context, event domain.LoginEvent) {
+	span := tracer.StartSpanFromContext(context, "usecase/RecordLoginEvent")
+	defer span.Finish()
+
+	event.IpAddress, event.UserAgent = helper.ClientInfoFromContext(context)
+	event.ClientId = helper.ClientIdFromContext(context)
+	if event.MfaMethod == "" {
+		event.MfaMethod = domain.MfaNone
+	}
+
+	if err := l.LoginEventRepository.Create(tracer.ContextWithSpan(context, span), &event); err != nil {
+		tracer.LogError(span, err)
+	}
+}
+
+func (l *loginEventUsecase) GetActivity(context context.Context, profileInfoId string, limit int) ([]domain.LoginEvent, error) {
+	return l.Search(context, domain.LoginEventFilter{ProfileInfoId: profileInfoId, Limit: limit})
+}
+
+func (l *loginEventUsecase) Search(context context.Context, filter domain.LoginEventFilter) ([]domain.LoginEvent, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/SearchLoginEvents")
+	defer span.Finish()
+
+	if filter.Limit <= 0 {
+		filter.Limit = loginEventDefaultLimit
+	}
+	if filter.Limit > loginEventMaxLimit {
+		filter.Limit = loginEventMaxLimit
+	}
+
+	return l.LoginEventRepository.Find(tracer.ContextWithSpan(context, span), filter)
+}
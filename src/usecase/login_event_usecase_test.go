@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"auth-service/repository"
+	"context"
+	"errors"
+	"testing"
+
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+type loginEvents struct {
+	repository.LoginEventRepository
+	created []domain.LoginEvent
+	filters []domain.LoginEventFilter
+	err     error
+}
+
+func (l *loginEvents) Create(context context.Context, event *domain.LoginEvent) error {
+	if l.err != nil {
+		return l.err
+	}
+	l.created = append(l.created, *event)
+	return nil
+}
+
+func (l *loginEvents) Find(context context.Context, filter domain.LoginEventFilter) ([]domain.LoginEvent, error) {
+	l.filters = append(l.filters, filter)
+	return nil, nil
+}
+
+func TestRecordLoginEvent(t *testing.T) {
+	events := &loginEvents{}
+	usecase := NewLoginEventUsecase(events, logger.InitializeLogger("auth-service", context.Background()))
+
+	ctx := context.WithValue(context.Background(), helper.ClientIpKey, "203.0.113.7")
+	ctx = context.WithValue(ctx, helper.UserAgentKey, "curl/7.79")
+	usecase.Record(ctx, domain.LoginEvent{Username: "jelena", Reason: domain.LoginInvalidPassword, Method: domain.LoginMethodPassword})
+	usecase.Record(ctx, domain.LoginEvent{ProfileInfoId: "1", Username: "jelena", Success: true, Method: domain.LoginMethodTotp, MfaMethod: domain.MfaTotp})
+
+	if len(events.created) != 2 {
+		t.Fatalf("recorded %v events", len(events.created))
+	}
+	failed, succeeded := events.created[0], events.created[1]
+	if failed.IpAddress != "203.0.113.7" || failed.UserAgent != "curl/7.79" {
+		t.Errorf("client of the attempt = %v, %v", failed.IpAddress, failed.UserAgent)
+	}
+	if failed.Success || failed.Reason != domain.LoginInvalidPassword || failed.MfaMethod != domain.MfaNone {
+		t.Errorf("failed attempt = %+v", failed)
+	}
+	if !succeeded.Success || succeeded.MfaMethod != domain.MfaTotp {
+		t.Errorf("successful attempt = %+v", succeeded)
+	}
+
+	events.err = errors.New("connection refused")
+	usecase.Record(ctx, domain.LoginEvent{Username: "jelena"})
+}
+
+func TestLoginActivityLimit(t *testing.T) {
+	for limit, want := range map[int]int{0: loginEventDefaultLimit, -5: loginEventDefaultLimit, 10: 10, 500: loginEventMaxLimit} {
+		events := &loginEvents{}
+		usecase := NewLoginEventUsecase(events, logger.InitializeLogger("auth-service", context.Background()))
+
+		if _, err := usecase.GetActivity(context.Background(), "1", limit); err != nil {
+			t.Fatal(err)
+		}
+		if filter := events.filters[0]; filter.ProfileInfoId != "1" || filter.Limit != want {
+			t.Errorf("limit %v: filter = %+v, want the user's last %v", limit, filter, want)
+		}
+	}
+}
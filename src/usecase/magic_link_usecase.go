@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"auth-service/infrastructure/tracer"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"github.com/twinj/uuid"
+	"net/url"
+	"os"
+	"time"
+)
+
+const (
+	magicLinkKey     = "magicLink/"
+	magicLinkPurpose = "magic_link"
+	magicNonceLength = 32
+)
+
+var ErrInvalidMagicLink = domain.Unauthenticated(domain.ReasonInvalidLink, "magic link is invalid or expired")
+
+type magicLinkUsecase struct {
+	Config             domain.MagicLinkConfig
+	RedisUsecase       RedisUsecase
+	ProfileInfoUsecase ProfileInfoUsecase
+	MailUsecase        MailUsecase
+	logger             *logger.Logger
+}
+
+type MagicLinkUsecase interface {
+	Enabled() bool
+	Ttl() time.Duration
+	SendLink(context context.Context, email string) (string, error)
+	ConsumeLink(context context.Context, token, nonce string) (*domain.ProfileInfo, error)
+}
+
+func NewMagicLinkUsecase(config domain.MagicLinkConfig, redisUsecase RedisUsecase, profileInfoUsecase ProfileInfoUsecase, mailUsecase MailUsecase, logger *logger.Logger) MagicLinkUsecase {
+	return &magicLinkUsecase{Config: config, RedisUsecase: redisUsecase, ProfileInfoUsecase: profileInfoUsecase, MailUsecase: mailUsecase, logger: logger}
+}
+
+func (m *magicLinkUsecase) Enabled() bool {
+	return m.Config.Enabled
+}
+
+func (m *magicLinkUsecase) Ttl() time.Duration {
+	return m.Config.Ttl
+}
+
+// SendLink returns a fresh browser nonce even when no account matches the email.
+func (m *magicLinkUsecase) SendLink(context context.Context, email string) (string, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/SendMagicLink")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	nonce, err := helper.RandomToken(magicNonceLength)
+	if err != nil {
+		m.logger.Logger.Errorf("error while generating magic link nonce, error: %v\n", err)
+		tracer.LogError(span, err)
+		return "", err
+	}
+
+	if !m.ProfileInfoUsecase.ExistsByUsernameOrEmail(ctx1, "", email) {
+		m.logger.Logger.Warnf("magic link requested for unknown email %v\n", email)
+		return nonce, nil
+	}
+
+	profileInfo, err := m.ProfileInfoUsecase.GetProfileInfoByEmail(ctx1, email)
+	if err != nil {
+		m.logger.Logger.Errorf("error while getting profile info for email %v, error: %v\n", email, err)
+		tracer.LogError(span, err)
+		return "", err
+	}
+
+	linkUuid := uuid.NewV4().String()
+	claims := jwt.MapClaims{}
+	claims["magic_uuid"] = linkUuid
+	claims["user_id"] = profileInfo.ID
+	claims["purpose"] = magicLinkPurpose
+	claims["exp"] = time.Now().Add(m.Config.Ttl).Unix()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(os.Getenv("ACCESS_SECRET")))
+	if err != nil {
+		m.logger.Logger.Errorf("error while signing magic link for user %v, error: %v\n", profileInfo.ID, err)
+		tracer.LogError(span, err)
+		return "", err
+	}
+
+	if err := m.RedisUsecase.AddKeyValueSet(ctx1, magicLinkKey+linkUuid, hashNonce(nonce), m.Config.Ttl); err != nil {
+		m.logger.Logger.Errorf("error while saving magic link to redis, error: %v\n", err)
+		tracer.LogError(span, err)
+		return "", err
+	}
+
+	link := fmt.Sprintf("%s?token=%s", m.Config.LinkUrl, url.QueryEscape(token))
+	// Sent in the background so that response times do not reveal whether the email is registered.
+	go func() {
+		if err := m.MailUsecase.SendMagicLinkMail(detachedContext(), profileInfo.Email, profileInfo.Username, link, int(m.Config.Ttl.Minutes())); err != nil {
+			m.logger.Logger.Errorf("error while sending magic link mail to %v, error: %v\n", profileInfo.Email, err)
+		}
+	}()
+
+	return nonce, nil
+}
+
+// ConsumeLink checks the link against the browser's nonce and deletes it.
+func (m *magicLinkUsecase) ConsumeLink(context context.Context, token, nonce string) (*domain.ProfileInfo, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/ConsumeMagicLink")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	parsed, err := verifyToken(token)
+	if err != nil || !parsed.Valid {
+		m.logger.Logger.Errorf("error while verifying magic link, error: %v\n", err)
+		tracer.LogError(span, ErrInvalidMagicLink)
+		return nil, ErrInvalidMagicLink
+	}
+
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != magicLinkPurpose {
+		tracer.LogError(span, ErrInvalidMagicLink)
+		return nil, ErrInvalidMagicLink
+	}
+	linkUuid, _ := claims["magic_uuid"].(string)
+	userId, _ := claims["user_id"].(string)
+	if linkUuid == "" || userId == "" {
+		tracer.LogError(span, ErrInvalidMagicLink)
+		return nil, ErrInvalidMagicLink
+	}
+
+	consumed, err := m.RedisUsecase.DeleteValueIfEquals(ctx1, magicLinkKey+linkUuid, hashNonce(nonce))
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	if !consumed {
+		m.logger.Logger.Warnf("magic link %v is used, expired or opened from a different browser\n", linkUuid)
+		tracer.LogError(span, ErrInvalidMagicLink)
+		return nil, ErrInvalidMagicLink
+	}
+
+	return m.ProfileInfoUsecase.GetProfileInfoById(ctx1, userId)
+}
+
+// detachedContext is used for work that outlives the request that started it.
+func detachedContext() context.Context {
+	return context.Background()
+}
+
+func hashNonce(nonce string) string {
+	sum := sha256.Sum256([]byte(nonce))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+type knownProfiles struct {
+	ProfileInfoUsecase
+	profiles []domain.ProfileInfo
+}
+
+func (k knownProfiles) find(match func(profile domain.ProfileInfo) bool) (*domain.ProfileInfo, error) {
+	for _, profile := range k.profiles {
+		if match(profile) {
+			return &profile, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (k knownProfiles) ExistsByUsernameOrEmail(context context.Context, username, email string) bool {
+	_, err := k.find(func(profile domain.ProfileInfo) bool { return profile.Username == username || profile.Email == email })
+	return err == nil
+}
+
+func (k knownProfiles) GetProfileInfoByEmail(context context.Context, email string) (domain.ProfileInfo, error) {
+	profile, err := k.find(func(profile domain.ProfileInfo) bool { return profile.Email == email })
+	if err != nil {
+		return domain.ProfileInfo{}, err
+	}
+	return *profile, nil
+}
+
+func (k knownProfiles) GetProfileInfoById(context context.Context, id string) (*domain.ProfileInfo, error) {
+	return k.find(func(profile domain.ProfileInfo) bool { return profile.ID == id })
+}
+
+// linkMails hands over the links of the magic link mails sent.
+type linkMails struct {
+	MailUsecase
+	links chan string
+}
+
+func (l linkMails) SendMagicLinkMail(context context.Context, subjectMail, subjectName, link string, minutes int) error {
+	l.links <- link
+	return nil
+}
+
+func newMagicLinkUsecase() (MagicLinkUsecase, *memoryRedis, linkMails) {
+	redis := newMemoryRedis()
+	mails := linkMails{links: make(chan string, 1)}
+	profiles := knownProfiles{profiles: []domain.ProfileInfo{{ID: "1", Username: "jelena", Email: "jelena@example.com"}}}
+	config := domain.MagicLinkConfig{Enabled: true, Ttl: 15 * time.Minute, LinkUrl: "https://nishtagram.test/magic"}
+
+	return NewMagicLinkUsecase(config, redis, profiles, mails, logger.InitializeLogger("auth-service", context.Background())), redis, mails
+}
+
+func sentLinkToken(t *testing.T, mails linkMails) string {
+	select {
+	case link := <-mails.links:
+		parsed, err := url.Parse(link)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed.Query().Get("token")
+	case <-time.After(time.Second):
+		t.Fatal("no magic link mail sent")
+		return ""
+	}
+}
+
+func TestMagicLinkUnknownEmail(t *testing.T) {
+	usecase, redis, mails := newMagicLinkUsecase()
+
+	nonce, err := usecase.SendLink(context.Background(), "nobody@example.com")
+	if err != nil || nonce == "" {
+		t.Fatalf("SendLink = %q, %v, want a nonce", nonce, err)
+	}
+	if len(redis.values) != 0 {
+		t.Errorf("links stored for an unknown email: %v", redis.values)
+	}
+	select {
+	case link := <-mails.links:
+		t.Errorf("mail sent to an unknown email: %v", link)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestMagicLinkConsume(t *testing.T) {
+	usecase, redis, mails := newMagicLinkUsecase()
+
+	nonce, err := usecase.SendLink(context.Background(), "jelena@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := sentLinkToken(t, mails)
+	for key, ttl := range redis.ttls {
+		if ttl != 15*time.Minute {
+			t.Errorf("%v expires in %v, want 15m", key, ttl)
+		}
+	}
+
+	if _, err := usecase.ConsumeLink(context.Background(), token, "other browser"); !errors.Is(err, ErrInvalidMagicLink) {
+		t.Errorf("link opened in another browser: err = %v", err)
+	}
+
+	profile, err := usecase.ConsumeLink(context.Background(), token, nonce)
+	if err != nil || profile.ID != "1" {
+		t.Fatalf("ConsumeLink = %+v, %v", profile, err)
+	}
+
+	if _, err := usecase.ConsumeLink(context.Background(), token, nonce); !errors.Is(err, ErrInvalidMagicLink) {
+		t.Errorf("link used twice: err = %v", err)
+	}
+}
+
+func TestMagicLinkRejectsOtherTokens(t *testing.T) {
+	usecase, _, _ := newMagicLinkUsecase()
+
+	sign := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(os.Getenv("ACCESS_SECRET")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	expires := time.Now().Add(time.Minute).Unix()
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "malformed", token: "not a token"},
+		{name: "access token", token: sign(jwt.MapClaims{"access_uuid": "uuid", "user_id": "1", "exp": expires})},
+		{name: "unknown link", token: sign(jwt.MapClaims{"magic_uuid": "uuid", "user_id": "1", "purpose": magicLinkPurpose, "exp": expires})},
+		{name: "expired", token: sign(jwt.MapClaims{"magic_uuid": "uuid", "user_id": "1", "purpose": magicLinkPurpose, "exp": time.Now().Add(-time.Minute).Unix()})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := usecase.ConsumeLink(context.Background(), tt.token, "nonce"); !errors.Is(err, ErrInvalidMagicLink) {
+				t.Errorf("err = %v, want %v", err, ErrInvalidMagicLink)
+			}
+		})
+	}
+}
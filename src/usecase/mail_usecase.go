@@ -1,94 +1,128 @@
 package usecase
 
 import (
-	"bytes"
-	"fmt"
-	"html/template"
-	"net/smtp"
+	"auth-service/assets/mail_template"
+	"auth-service/helper"
+	"auth-service/infrastructure/mailer"
+	"auth-service/infrastructure/tracer"
+	"auth-service/repository"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
 )
 
 type MailForActivation struct {
 	Email	string `json:"email"`
 	Token	string `json:"token"`
-	Expires	string `json::"expires"`
+	Expires	string `json:"expires"`
 }
 
-const (
-	emailHost = "smtp.gmail.com"
-	emailFrom = "duke.strategic@gmail.com"
-	emailPassword = "Duke123456"
-	emailPort = "587"
-	adr = emailHost + ":" + emailPort
-)
+// mailUsecase renders mails and hands them to the outbox.
+type mailUsecase struct {
+	OutboxUsecase OutboxUsecase
+	Templates *mail_template.Registry
+	ProfileInfoRepository repository.ProfileInfoRepository
+	logger *logger.Logger
+}
 
-func SendMail(subjectMail string, subjectName string, verCode string) error {
+type MailUsecase interface {
+	PreferredLocale(context context.Context) string
+	SendActivationMail(context context.Context, subjectMail, subjectName, verCode string) error
+	SendResetPasswordMail(context context.Context, subjectMail, verCode string) error
+	SendMagicLinkMail(context context.Context, subjectMail, subjectName, link string, minutes int) error
+	SendEmailChangeCodeMail(context context.Context, subjectMail, subjectName, verCode string) error
+	SendEmailChangeNoticeMail(context context.Context, subjectMail, subjectName, newEmail, cancelLink string) error
+	SendSecurityAlertMail(context context.Context, subjectMail string, data mail_template.SecurityAlertData) error
+}
 
-	emailAuth := smtp.PlainAuth("", emailFrom, emailPassword, emailHost)
+func NewMailUsecase(outboxUsecase OutboxUsecase, templates *mail_template.Registry, profileInfoRepository repository.ProfileInfoRepository, logger *logger.Logger) MailUsecase {
+	return &mailUsecase{OutboxUsecase: outboxUsecase, Templates: templates, ProfileInfoRepository: profileInfoRepository, logger: logger}
+}
 
-	t, _  := template.ParseFiles("assets/mail_template/template.html")
-	var body bytes.Buffer
-	headers := "MIME-version: 1.0;\nContent-Type: text/html;"
-	body.Write([]byte(fmt.Sprintf("Subject: Email verification by Duke Strategic Techologies\n%s\n\n", headers)))
+// PreferredLocale returns the supported locale that best matches the request.
+func (m *mailUsecase) PreferredLocale(context context.Context) string {
+	return m.Templates.MatchLocale(helper.LocaleFromContext(context))
+}
 
-	t.Execute(&body, struct {
-		UserName string
-		Code string
-	}{
+func (m *mailUsecase) SendActivationMail(context context.Context, subjectMail, subjectName, verCode string) error {
+	return m.send(context, mail_template.Activation, subjectMail, mail_template.ActivationData{
 		UserName: subjectName,
-		Code: verCode,
+		Code:     verCode,
 	})
-
-	to := []string{subjectMail}
-
-
-	if err := smtp.SendMail(adr, emailAuth, emailFrom, to, body.Bytes()); err != nil {
-		return  err
-	}
-
-
-
-	return nil
 }
 
-func SendRestartPasswordMail(subjectMail string, verCode string) error {
-
-	emailAuth := smtp.PlainAuth("", emailFrom, emailPassword, emailHost)
-
-	t, _  := template.ParseFiles("assets/mail_template/template_reset.html")
-	var body bytes.Buffer
-	headers := "MIME-version: 1.0;\nContent-Type: text/html;"
-	body.Write([]byte(fmt.Sprintf("Subject: Code for password reset by Duke Strategic Techologies\n%s\n\n", headers)))
-
-	t.Execute(&body, struct {
-		Code string
-	}{
+func (m *mailUsecase) SendResetPasswordMail(context context.Context, subjectMail, verCode string) error {
+	return m.send(context, mail_template.Reset, subjectMail, mail_template.ResetData{
 		Code: verCode,
 	})
+}
 
-	to := []string{subjectMail}
+func (m *mailUsecase) SendMagicLinkMail(context context.Context, subjectMail, subjectName, link string, minutes int) error {
+	return m.send(context, mail_template.MagicLink, subjectMail, mail_template.MagicLinkData{
+		UserName: subjectName,
+		Link:     link,
+		Minutes:  minutes,
+	})
+}
 
+func (m *mailUsecase) SendEmailChangeCodeMail(context context.Context, subjectMail, subjectName, verCode string) error {
+	return m.send(context, mail_template.EmailChange, subjectMail, mail_template.EmailChangeData{
+		UserName: subjectName,
+		NewEmail: subjectMail,
+		Code:     verCode,
+	})
+}
 
-	if err := smtp.SendMail(adr, emailAuth, emailFrom, to, body.Bytes()); err != nil {
-		return  err
-	}
+func (m *mailUsecase) SendEmailChangeNoticeMail(context context.Context, subjectMail, subjectName, newEmail, cancelLink string) error {
+	return m.send(context, mail_template.EmailChangeNotice, subjectMail, mail_template.EmailChangeNoticeData{
+		UserName: subjectName,
+		NewEmail: newEmail,
+		Link:     cancelLink,
+	})
+}
 
+// SendSecurityAlertMail never supersedes earlier alerts.
+func (m *mailUsecase) SendSecurityAlertMail(context context.Context, subjectMail string, data mail_template.SecurityAlertData) error {
+	return m.enqueue(context, mail_template.SecurityAlert, subjectMail, false, data)
+}
 
+// locale prefers the language saved on the recipient's account over the request's.
+func (m *mailUsecase) locale(context context.Context, subjectMail string) string {
+	userLocale := ""
+	if profileInfo, err := m.ProfileInfoRepository.GetProfileInfoByEmail(context, subjectMail); err == nil {
+		userLocale = profileInfo.Locale
+	}
 
-	return nil
+	return m.Templates.MatchLocale(userLocale, helper.LocaleFromContext(context))
 }
 
+func (m *mailUsecase) send(context context.Context, templateName, subjectMail string, data interface{}) error {
+	return m.enqueue(context, templateName, subjectMail, true, data)
+}
 
+func (m *mailUsecase) enqueue(context context.Context, templateName, subjectMail string, supersede bool, data interface{}) error {
+	span := tracer.StartSpanFromContext(context, "usecase/SendMail")
+	defer span.Finish()
 
+	ctx1 := tracer.ContextWithSpan(context, span)
 
-func ParseTemplate(templateFileName string, data interface{}) (string, error) {
-	t, err := template.ParseFiles(templateFileName)
+	rendered, err := m.Templates.Render(templateName, m.locale(ctx1, subjectMail), data)
 	if err != nil {
-		return "", err
+		m.logger.Logger.Errorf("error while rendering mail template %v, error: %v\n", templateName, err)
+		tracer.LogError(span, err)
+		return err
 	}
-	buf := new(bytes.Buffer)
-	if err = t.Execute(buf, data); err != nil {
-		return "", err
+
+	err = m.OutboxUsecase.Enqueue(ctx1, templateName, supersede, mailer.Message{
+		To:       []string{subjectMail},
+		Subject:  rendered.Subject,
+		TextBody: rendered.Text,
+		HtmlBody: rendered.Html,
+	})
+	if err != nil {
+		m.logger.Logger.Errorf("error while queueing mail to %v, error: %v\n", subjectMail, err)
+		tracer.LogError(span, err)
+		return err
 	}
-	body := buf.String()
-	return body, nil
+
+	return nil
 }
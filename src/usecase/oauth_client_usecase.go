@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"auth-service/infrastructure/tracer"
+	"auth-service/repository"
+	"context"
+	"github.com/google/uuid"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"strings"
+	"time"
+)
+
+// Error codes of the OAuth 2.0 token endpoint.
+const (
+	OAuthInvalidRequest       = "invalid_request"
+	OAuthInvalidClient        = "invalid_client"
+	OAuthInvalidScope         = "invalid_scope"
+	OAuthUnsupportedGrantType = "unsupported_grant_type"
+)
+
+const (
+	clientCredentialsGrant = "client_credentials"
+	clientTokenTtl         = time.Hour
+	clientSecretBytes      = 32
+
+	clientNameRequired = "a client name and at least one scope are required"
+	clientNotFound     = "oauth client not found"
+)
+
+// OAuthError is returned by the token endpoint in the OAuth 2.0 format.
+type OAuthError struct {
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	return e.Description
+}
+
+// DescribeError keeps the OAuth error code as the reason.
+func (e *OAuthError) DescribeError() *domain.Error {
+	if e.Code == OAuthInvalidClient {
+		return domain.Unauthenticated(strings.ToUpper(e.Code), e.Description)
+	}
+
+	err := domain.InvalidArgument(e.Description)
+	err.Reason = strings.ToUpper(e.Code)
+	return err
+}
+
+type oauthClientUsecase struct {
+	OAuthClientRepository repository.OAuthClientRepository
+	ScopeRepository       repository.ScopeRepository
+	JwtUsecase            JwtUsecase
+	AuthenticationUsecase AuthenticationUsecase
+	BruteForceUsecase     BruteForceUsecase
+	AuditUsecase          AuditUsecase
+	logger                *logger.Logger
+}
+
+type OAuthClientUsecase interface {
+	Create(context context.Context, actor, name string, scopes []string) (*domain.OAuthClient, string, error)
+	List(context context.Context) ([]domain.OAuthClient, error)
+	Delete(context context.Context, actor, clientId string) error
+	IssueToken(context context.Context, grantType, clientId, clientSecret string, scopes []string) (*domain.TokenDetails, []string, error)
+}
+
+func NewOAuthClientUsecase(oauthClientRepository repository.OAuthClientRepository, scopeRepository repository.ScopeRepository, jwtUsecase JwtUsecase, authenticationUsecase AuthenticationUsecase, bruteForceUsecase BruteForceUsecase, auditUsecase AuditUsecase, logger *logger.Logger) OAuthClientUsecase {
+	return &oauthClientUsecase{OAuthClientRepository: oauthClientRepository, ScopeRepository: scopeRepository, JwtUsecase: jwtUsecase, AuthenticationUsecase: authenticationUsecase, BruteForceUsecase: bruteForceUsecase, AuditUsecase: auditUsecase, logger: logger}
+}
+
+// Create registers a client and returns its secret, which is only stored hashed.
+func (o *oauthClientUsecase) Create(context context.Context, actor, name string, scopes []string) (*domain.OAuthClient, string, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/CreateOAuthClient")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	client, secret, err := o.create(ctx1, actor, name, scopes)
+	target := name
+	if client != nil {
+		target = client.ID
+	}
+	o.AuditUsecase.RecordWithReason(ctx1, actor, target, domain.AuditOAuthClientCreated, "scopes "+domain.JoinScopes(scopes), err)
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, "", err
+	}
+
+	o.logger.Logger.Infof("oauth client %v (%v) created by %v\n", client.ID, name, actor)
+
+	return client, secret, nil
+}
+
+func (o *oauthClientUsecase) create(context context.Context, actor, name string, scopeNames []string) (*domain.OAuthClient, string, error) {
+	scopeNames = normalizeScopes(scopeNames)
+	if name == "" || len(scopeNames) == 0 {
+		return nil, "", domain.InvalidArgument(clientNameRequired)
+	}
+
+	scopes, err := o.ScopeRepository.GetByNames(context, scopeNames)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(scopes) != len(scopeNames) {
+		return nil, "", domain.InvalidArgument(scopeNotFound)
+	}
+
+	secret, err := helper.RandomToken(clientSecretBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	hash, err := helper.Hash(secret)
+	if err != nil {
+		return nil, "", err
+	}
+
+	client := &domain.OAuthClient{
+		ID:         uuid.NewString(),
+		Name:       name,
+		SecretHash: string(hash),
+		Scopes:     scopes,
+		CreatedBy:  actor,
+	}
+	if err := o.OAuthClientRepository.Create(context, client); err != nil {
+		return nil, "", err
+	}
+
+	return client, secret, nil
+}
+
+func (o *oauthClientUsecase) List(context context.Context) ([]domain.OAuthClient, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/ListOAuthClients")
+	defer span.Finish()
+
+	return o.OAuthClientRepository.GetAll(tracer.ContextWithSpan(context, span))
+}
+
+// Delete removes the client and revokes the tokens it was issued.
+func (o *oauthClientUsecase) Delete(context context.Context, actor, clientId string) error {
+	span := tracer.StartSpanFromContext(context, "usecase/DeleteOAuthClient")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	err := o.delete(ctx1, clientId)
+	o.AuditUsecase.Record(ctx1, actor, clientId, domain.AuditOAuthClientDeleted, err)
+	if err != nil {
+		tracer.LogError(span, err)
+		return err
+	}
+
+	o.logger.Logger.Infof("oauth client %v deleted by %v\n", clientId, actor)
+
+	return nil
+}
+
+func (o *oauthClientUsecase) delete(context context.Context, clientId string) error {
+	client, err := o.OAuthClientRepository.GetById(context, clientId)
+	if err != nil {
+		return domain.NotFound(clientNotFound)
+	}
+
+	if err := o.OAuthClientRepository.Delete(context, client); err != nil {
+		return err
+	}
+
+	return o.AuthenticationUsecase.RevokeUserSessions(context, client.SessionId())
+}
+
+// IssueToken implements the client credentials grant.
+func (o *oauthClientUsecase) IssueToken(context context.Context, grantType, clientId, clientSecret string, scopes []string) (*domain.TokenDetails, []string, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/IssueClientToken")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	if grantType != clientCredentialsGrant {
+		return nil, nil, &OAuthError{Code: OAuthUnsupportedGrantType, Description: "only the client_credentials grant is supported"}
+	}
+	if clientId == "" || clientSecret == "" {
+		return nil, nil, &OAuthError{Code: OAuthInvalidRequest, Description: "client_id and client_secret are required"}
+	}
+
+	if err := o.BruteForceUsecase.Check(ctx1, BruteForceClient, clientId); err != nil {
+		return nil, nil, err
+	}
+
+	client, err := o.OAuthClientRepository.GetById(ctx1, clientId)
+	if err != nil || helper.Verify(clientSecret, client.SecretHash) != nil {
+		o.BruteForceUsecase.Fail(ctx1, BruteForceClient, clientId)
+		return nil, nil, &OAuthError{Code: OAuthInvalidClient, Description: "client authentication failed"}
+	}
+	o.BruteForceUsecase.Succeed(ctx1, BruteForceClient, clientId)
+
+	granted := client.ScopeNames()
+	if requested := normalizeScopes(scopes); len(requested) > 0 {
+		for _, scope := range requested {
+			if !containsScope(granted, scope) {
+				return nil, nil, &OAuthError{Code: OAuthInvalidScope, Description: "scope " + scope + " is not granted to the client"}
+			}
+		}
+		granted = requested
+	}
+
+	td, err := o.JwtUsecase.CreateClientToken(ctx1, client.ID, granted, clientTokenTtl)
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, nil, err
+	}
+
+	return td, granted, nil
+}
+
+func normalizeScopes(scopes []string) []string {
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if scope != "" && !containsScope(normalized, scope) {
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/mailer"
+	"auth-service/infrastructure/tracer"
+	"auth-service/repository"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"time"
+)
+
+const (
+	outboxNotRedrivable = "only dead messages can be re-driven"
+	outboxListLimit     = 100
+)
+
+type outboxUsecase struct {
+	Config           domain.OutboxConfig
+	OutboxRepository repository.OutboxRepository
+	Mailer           mailer.Mailer
+	logger           *logger.Logger
+}
+
+type OutboxUsecase interface {
+	Enqueue(context context.Context, category string, supersede bool, message mailer.Message) error
+	ProcessDue(context context.Context) error
+	Run(context context.Context)
+	GetByStatus(context context.Context, status string) ([]domain.OutboxMessage, error)
+	Redrive(context context.Context, id uint) error
+}
+
+func NewOutboxUsecase(config domain.OutboxConfig, outboxRepository repository.OutboxRepository, mailer mailer.Mailer, logger *logger.Logger) OutboxUsecase {
+	return &outboxUsecase{Config: config, OutboxRepository: outboxRepository, Mailer: mailer, logger: logger}
+}
+
+// Enqueue stores a message for the worker, dropping undelivered ones it supersedes.
+func (o *outboxUsecase) Enqueue(context context.Context, category string, supersede bool, message mailer.Message) error {
+	span := tracer.StartSpanFromContext(context, "usecase/EnqueueOutboxMessage")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	for _, recipient := range message.To {
+		if supersede {
+			if err := o.OutboxRepository.Supersede(ctx1, category, recipient); err != nil {
+				tracer.LogError(span, err)
+				return err
+			}
+		}
+
+		outboxMessage := &domain.OutboxMessage{
+			Category:      category,
+			Recipient:     recipient,
+			Subject:       message.Subject,
+			TextBody:      message.TextBody,
+			HtmlBody:      message.HtmlBody,
+			Status:        domain.OutboxPending,
+			NextAttemptAt: time.Now(),
+		}
+		if err := o.OutboxRepository.Create(ctx1, outboxMessage); err != nil {
+			tracer.LogError(span, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *outboxUsecase) Run(context context.Context) {
+	o.logger.Logger.Infof("starting outbox worker, polling every %v\n", o.Config.PollInterval)
+	ticker := time.NewTicker(o.Config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := o.ProcessDue(context); err != nil {
+			o.logger.Logger.Errorf("error while processing outbox, error: %v\n", err)
+		}
+
+		select {
+		case <-context.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (o *outboxUsecase) ProcessDue(context context.Context) error {
+	return o.OutboxRepository.ClaimDue(context, time.Now(), o.Config.BatchSize, func(message *domain.OutboxMessage) {
+		o.deliver(context, message)
+	})
+}
+
+func (o *outboxUsecase) deliver(context context.Context, message *domain.OutboxMessage) {
+	message.Attempts++
+
+	err := o.Mailer.Send(context, mailer.Message{
+		To:       []string{message.Recipient},
+		Subject:  message.Subject,
+		TextBody: message.TextBody,
+		HtmlBody: message.HtmlBody,
+	})
+	if err == nil {
+		now := time.Now()
+		message.Status = domain.OutboxSent
+		message.SentAt = &now
+		message.LastError = ""
+		// Sent mails may contain codes and links, there is no need to keep them around.
+		message.TextBody = ""
+		message.HtmlBody = ""
+		return
+	}
+
+	message.LastError = err.Error()
+	if message.Attempts >= o.Config.MaxAttempts {
+		o.logger.Logger.Errorf("outbox message %v to %v is dead after %v attempts, error: %v\n", message.ID, message.Recipient, message.Attempts, err)
+		message.Status = domain.OutboxDead
+		return
+	}
+
+	message.NextAttemptAt = time.Now().Add(o.backoff(message.Attempts))
+	o.logger.Logger.Warnf("error while sending outbox message %v to %v, attempt %v, retrying at %v, error: %v\n", message.ID, message.Recipient, message.Attempts, message.NextAttemptAt, err)
+}
+
+func (o *outboxUsecase) backoff(attempts int) time.Duration {
+	backoff := o.Config.BaseBackoff
+	for i := 1; i < attempts && backoff < o.Config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > o.Config.MaxBackoff {
+		backoff = o.Config.MaxBackoff
+	}
+
+	return backoff
+}
+
+func (o *outboxUsecase) GetByStatus(context context.Context, status string) ([]domain.OutboxMessage, error) {
+	return o.OutboxRepository.GetByStatus(context, status, outboxListLimit)
+}
+
+// Redrive puts a dead message back in the queue with a fresh attempt budget.
+func (o *outboxUsecase) Redrive(context context.Context, id uint) error {
+	message, err := o.OutboxRepository.GetById(context, id)
+	if err != nil {
+		return err
+	}
+
+	if message.Status != domain.OutboxDead {
+		return domain.InvalidArgument(outboxNotRedrivable)
+	}
+
+	o.logger.Logger.Infof("re-driving outbox message %v to %v\n", message.ID, message.Recipient)
+	message.Status = domain.OutboxPending
+	message.Attempts = 0
+	message.NextAttemptAt = time.Now()
+
+	return o.OutboxRepository.Update(context, message)
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"auth-service/infrastructure/dto"
+	"auth-service/infrastructure/tracer"
+	"context"
+	"crypto/sha1"
+	"fmt"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	ruleMinLength        = "min_length"
+	ruleMaxLength        = "max_length"
+	ruleUpper            = "upper"
+	ruleLower            = "lower"
+	ruleDigit            = "digit"
+	ruleSpecial          = "special"
+	ruleInvalidCharacter = "invalid_character"
+	ruleUsername         = "username"
+	ruleEmail            = "email"
+	ruleBreached         = "breached"
+
+	passwordPolicyError = "password does not satisfy password policy"
+)
+
+type PasswordPolicyError struct {
+	Violations []dto.PasswordViolationDto
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return passwordPolicyError
+}
+
+// DescribeError reports each broken rule as a violation of the password field.
+func (e *PasswordPolicyError) DescribeError() *domain.Error {
+	violations := make([]domain.FieldViolation, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		violations = append(violations, domain.FieldViolation{Field: "password", Description: violation.Message})
+	}
+
+	err := domain.InvalidArgument(e.Error(), violations...)
+	err.Reason = domain.ReasonPasswordPolicy
+	return err
+}
+
+type passwordPolicyUsecase struct {
+	Policy            domain.PasswordPolicy
+	BreachedPasswords *helper.BloomFilter
+	logger            *logger.Logger
+}
+
+type PasswordPolicyUsecase interface {
+	Validate(context context.Context, password, username, email string) error
+	HistoryDepth() int
+	IsExpired(passwordChangedAt time.Time) bool
+}
+
+func NewPasswordPolicyUsecase(policy domain.PasswordPolicy, breachedPasswords *helper.BloomFilter, logger *logger.Logger) PasswordPolicyUsecase {
+	return &passwordPolicyUsecase{Policy: policy, BreachedPasswords: breachedPasswords, logger: logger}
+}
+
+func (p *passwordPolicyUsecase) HistoryDepth() int {
+	return p.Policy.HistoryDepth
+}
+
+// IsExpired reports whether a password is older than the maximum age; an undated one is.
+func (p *passwordPolicyUsecase) IsExpired(passwordChangedAt time.Time) bool {
+	if p.Policy.MaxAgeDays <= 0 {
+		return false
+	}
+	if passwordChangedAt.IsZero() {
+		return true
+	}
+
+	return time.Since(passwordChangedAt) > time.Duration(p.Policy.MaxAgeDays)*24*time.Hour
+}
+
+func (p *passwordPolicyUsecase) Validate(context context.Context, password, username, email string) error {
+	span := tracer.StartSpanFromContext(context, "usecase/ValidatePasswordPolicy")
+	defer span.Finish()
+
+	var violations []dto.PasswordViolationDto
+	violate := func(rule, message string) {
+		violations = append(violations, dto.PasswordViolationDto{Rule: rule, Message: message})
+	}
+
+	var length int
+	var upper, lower, digit, special, invalid bool
+	for _, c := range password {
+		length++
+		switch {
+		case unicode.IsNumber(c):
+			digit = true
+		case unicode.IsUpper(c):
+			upper = true
+		case unicode.IsLower(c):
+			lower = true
+		case unicode.IsPunct(c) || unicode.IsSymbol(c):
+			special = true
+		case unicode.IsLetter(c) || c == ' ':
+		default:
+			invalid = true
+		}
+	}
+
+	if p.Policy.MinLength > 0 && length < p.Policy.MinLength {
+		violate(ruleMinLength, fmt.Sprintf("Password needs to be minimum %d characters long", p.Policy.MinLength))
+	}
+	if p.Policy.MaxLength > 0 && length > p.Policy.MaxLength {
+		violate(ruleMaxLength, fmt.Sprintf("Password can be maximum %d characters long", p.Policy.MaxLength))
+	}
+	if p.Policy.RequireUpper && !upper {
+		violate(ruleUpper, "Password must have minimum 1 uppercase letter")
+	}
+	if p.Policy.RequireLower && !lower {
+		violate(ruleLower, "Password must have minimum 1 lowercase letter")
+	}
+	if p.Policy.RequireDigit && !digit {
+		violate(ruleDigit, "Password must have minimum 1 digit")
+	}
+	if p.Policy.RequireSpecial && !special {
+		violate(ruleSpecial, "Password must have minimum 1 special character")
+	}
+	if invalid {
+		violate(ruleInvalidCharacter, "Password contains characters that are not allowed")
+	}
+
+	lowerPassword := strings.ToLower(password)
+	if p.Policy.ForbidUsername && username != "" && strings.Contains(lowerPassword, strings.ToLower(username)) {
+		violate(ruleUsername, "Password can not contain your username")
+	}
+	if p.Policy.ForbidEmail && email != "" {
+		localPart := strings.ToLower(strings.Split(email, "@")[0])
+		if strings.Contains(lowerPassword, strings.ToLower(email)) || (localPart != "" && strings.Contains(lowerPassword, localPart)) {
+			violate(ruleEmail, "Password can not contain your email address")
+		}
+	}
+
+	if p.isBreached(password) {
+		violate(ruleBreached, "Password has appeared in a known data breach, please choose another one")
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	p.logger.Logger.Errorf("error while validating password, error: %v violations\n", len(violations))
+	err := &PasswordPolicyError{Violations: violations}
+	tracer.LogError(span, err)
+	return err
+}
+
+func (p *passwordPolicyUsecase) isBreached(password string) bool {
+	if p.BreachedPasswords == nil {
+		return false
+	}
+
+	digest := sha1.Sum([]byte(password))
+	return p.BreachedPasswords.ContainsDigest(digest[:])
+}
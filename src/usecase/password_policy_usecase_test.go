@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"context"
+	"crypto/sha1"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+var strictPolicy = domain.PasswordPolicy{
+	MinLength:      8,
+	MaxLength:      20,
+	RequireUpper:   true,
+	RequireLower:   true,
+	RequireDigit:   true,
+	RequireSpecial: true,
+	ForbidUsername: true,
+	ForbidEmail:    true,
+}
+
+func breachedFilter(passwords ...string) *helper.BloomFilter {
+	filter := helper.NewBloomFilter(uint64(len(passwords)), 0.0001)
+	for _, password := range passwords {
+		digest := sha1.Sum([]byte(password))
+		filter.AddDigest(digest[:])
+	}
+	return filter
+}
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    domain.PasswordPolicy
+		breached  *helper.BloomFilter
+		password  string
+		username  string
+		email     string
+		wantRules []string
+	}{
+		{name: "satisfies every rule", policy: strictPolicy, password: "Correct#Horse9", username: "jelena", email: "jelena@mail.com"},
+		{name: "too short", policy: strictPolicy, password: "Ab1!", wantRules: []string{ruleMinLength}},
+		{name: "too long", policy: strictPolicy, password: "Abcdefghijklmnopqrs1!", wantRules: []string{ruleMaxLength}},
+		{name: "length counts characters not bytes", policy: strictPolicy, password: "Šđčćž1!aaaaaaaaaaaaa", wantRules: nil},
+		{name: "missing classes", policy: strictPolicy, password: "abcdefgh", wantRules: []string{ruleUpper, ruleDigit, ruleSpecial}},
+		{name: "only digits", policy: strictPolicy, password: "12345678", wantRules: []string{ruleUpper, ruleLower, ruleSpecial}},
+		{name: "control characters are invalid", policy: strictPolicy, password: "Abcdef1!\x07", wantRules: []string{ruleInvalidCharacter}},
+		{name: "contains username in any case", policy: strictPolicy, password: "My-JELENA-1", username: "jelena", wantRules: []string{ruleUsername}},
+		{name: "contains email local part", policy: strictPolicy, password: "Xjelena.v#1", email: "jelena.v@mail.com", wantRules: []string{ruleEmail}},
+		{name: "username and email allowed when not forbidden", policy: domain.PasswordPolicy{MinLength: 4}, password: "jelena", username: "jelena", email: "jelena@mail.com"},
+		{name: "breached", policy: strictPolicy, breached: breachedFilter("P@ssw0rd!"), password: "P@ssw0rd!", wantRules: []string{ruleBreached}},
+		{name: "not breached", policy: strictPolicy, breached: breachedFilter("P@ssw0rd!"), password: "P@ssw0rd!x"},
+		{name: "empty policy accepts anything printable", policy: domain.PasswordPolicy{}, password: "a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewPasswordPolicyUsecase(tt.policy, tt.breached, logger.InitializeLogger("auth-service", context.Background()))
+			err := usecase.Validate(context.Background(), tt.password, tt.username, tt.email)
+
+			var rules []string
+			var policyErr *PasswordPolicyError
+			if errors.As(err, &policyErr) {
+				for _, violation := range policyErr.Violations {
+					rules = append(rules, violation.Rule)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if !reflect.DeepEqual(rules, tt.wantRules) {
+				t.Errorf("broken rules = %v, want %v", rules, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyIsExpired(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxAgeDays int
+		changedAt  time.Time
+		want       bool
+	}{
+		{name: "no maximum age", maxAgeDays: 0, changedAt: time.Now().AddDate(-5, 0, 0), want: false},
+		{name: "never changed", maxAgeDays: 90, changedAt: time.Time{}, want: true},
+		{name: "never changed without a maximum age", maxAgeDays: 0, changedAt: time.Time{}, want: false},
+		{name: "recently changed", maxAgeDays: 90, changedAt: time.Now().AddDate(0, 0, -89), want: false},
+		{name: "too old", maxAgeDays: 90, changedAt: time.Now().AddDate(0, 0, -91), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewPasswordPolicyUsecase(domain.PasswordPolicy{MaxAgeDays: tt.maxAgeDays}, nil, logger.InitializeLogger("auth-service", context.Background()))
+			if got := usecase.IsExpired(tt.changedAt); got != tt.want {
+				t.Errorf("IsExpired = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyErrorDescribeError(t *testing.T) {
+	err := domain.AsError(&PasswordPolicyError{Violations: nil})
+	if err.Kind != domain.ErrorInvalidArgument || err.Reason != domain.ReasonPasswordPolicy {
+		t.Errorf("kind, reason = %v, %v, want %v, %v", err.Kind, err.Reason, domain.ErrorInvalidArgument, domain.ReasonPasswordPolicy)
+	}
+}
@@ -1,11 +1,15 @@
 package usecase
 
 import (
+	"auth-service/domain"
 	"auth-service/infrastructure/tracer"
 	"context"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrInvalidCredentials doesn't tell an unknown username from a wrong password.
+var ErrInvalidCredentials = domain.Unauthenticated(domain.ReasonInvalidCredentials, "wrong username or password")
+
 func Hash(password string) ([]byte, error) {
 
 	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -17,7 +21,7 @@ func VerifyPassword(context context.Context, password, hashedPassword string) er
 
 	if err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password)); err != nil {
 		tracer.LogError(span, err)
-		return err
+		return ErrInvalidCredentials
 	}
 	return nil
 }
@@ -0,0 +1,337 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/policy"
+	"auth-service/infrastructure/tracer"
+	"context"
+	"errors"
+	"fmt"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"io"
+	"strings"
+)
+
+const (
+	unknownTransport     = "unknown transport, use http or grpc"
+	unknownPolicy        = "unknown policy, use http, grpc or resource"
+	policyIncomplete     = "subject and object are required"
+	policyExists         = "policy already exists"
+	policyNotFound       = "policy not found"
+	inheritanceInvalid   = "role and a different parent role are required"
+	inheritanceExists    = "role inheritance already exists"
+	inheritanceNotFound  = "role inheritance not found"
+	policyManagementLock = "admins can't remove their own access to policy management"
+	routesUnknown        = "routes of the transport aren't known yet"
+	policyCondition      = "resource policies need a condition, the others can't have one"
+	resourcesNotRouted   = "resource policies are checked against resources, not routes"
+
+	policyAdminRole = "ADMIN"
+)
+
+// policyManagement is a route of each transport admins must keep access to.
+var policyManagement = map[string]string{
+	domain.PolicyTransportHttp: "/admin/policies",
+	domain.PolicyTransportGrpc: "/Authentication/AddPolicy",
+}
+
+type policyUsecase struct {
+	Enforcers    []policy.Enforcer
+	Routes       *policy.RouteTable
+	AuditUsecase AuditUsecase
+	logger       *logger.Logger
+}
+
+type PolicyUsecase interface {
+	Reload(context context.Context, actor string) error
+	List(context context.Context, transport string) ([]domain.Policy, []domain.RoleInheritance, error)
+	AddPolicy(context context.Context, actor, transport string, rule domain.Policy) error
+	RemovePolicy(context context.Context, actor, transport string, rule domain.Policy) error
+	AddInheritance(context context.Context, actor, transport string, inheritance domain.RoleInheritance) error
+	RemoveInheritance(context context.Context, actor, transport string, inheritance domain.RoleInheritance) error
+	Explain(context context.Context, transport, subject, object, action string) ([]domain.PolicyExplanation, error)
+	Diff(context context.Context, transport string, proposed io.Reader) ([]domain.AccessChange, error)
+}
+
+func NewPolicyUsecase(enforcers []policy.Enforcer, routes *policy.RouteTable, auditUsecase AuditUsecase, logger *logger.Logger) PolicyUsecase {
+	return &policyUsecase{Enforcers: enforcers, Routes: routes, AuditUsecase: auditUsecase, logger: logger}
+}
+
+// Reload reloads every policy, keeping the last good version of one that fails.
+func (p *policyUsecase) Reload(context context.Context, actor string) error {
+	span := tracer.StartSpanFromContext(context, "usecase/ReloadPolicy")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	var failed error
+	for _, enforcer := range p.Enforcers {
+		err := enforcer.Reload()
+		p.AuditUsecase.Record(ctx1, actor, enforcer.Name(), domain.AuditPolicyReloaded, err)
+		if err != nil && failed == nil {
+			tracer.LogError(span, err)
+			failed = fmt.Errorf("%v policy: %w", enforcer.Name(), err)
+		}
+	}
+
+	return failed
+}
+
+func (p *policyUsecase) List(context context.Context, transport string) ([]domain.Policy, []domain.RoleInheritance, error) {
+	enforcer, err := p.enforcer(transport)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policies := make([]domain.Policy, 0)
+	for _, values := range enforcer.Policies() {
+		switch len(values) {
+		case 3:
+			policies = append(policies, domain.Policy{Subject: values[0], Object: values[1], Action: values[2]})
+		case 4:
+			policies = append(policies, domain.Policy{Subject: values[0], Object: values[1], Action: values[2], Condition: values[3]})
+		}
+	}
+
+	inheritances := make([]domain.RoleInheritance, 0)
+	for _, values := range enforcer.Groupings() {
+		if len(values) == 2 {
+			inheritances = append(inheritances, domain.RoleInheritance{Role: values[0], Parent: values[1]})
+		}
+	}
+
+	return policies, inheritances, nil
+}
+
+func (p *policyUsecase) AddPolicy(context context.Context, actor, transport string, rule domain.Policy) error {
+	span := tracer.StartSpanFromContext(context, "usecase/AddPolicy")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+	rule = normalizePolicy(rule)
+
+	err := p.changePolicy(ctx1, transport, rule, func(enforcer policy.Enforcer) error {
+		if transport == domain.PolicyTransportResource {
+			if err := policy.CheckResourceRule(enforcer, rule); err != nil {
+				return domain.InvalidArgument(fmt.Sprintf("invalid condition: %v", err))
+			}
+		}
+		added, err := enforcer.AddPolicy(ctx1, rule.Values()...)
+		if err == nil && !added {
+			err = domain.InvalidArgument(policyExists)
+		}
+		return err
+	})
+	p.AuditUsecase.Record(ctx1, actor, policyTarget(transport, rule), domain.AuditPolicyAdded, err)
+	if err != nil {
+		tracer.LogError(span, err)
+	}
+
+	return err
+}
+
+func (p *policyUsecase) RemovePolicy(context context.Context, actor, transport string, rule domain.Policy) error {
+	span := tracer.StartSpanFromContext(context, "usecase/RemovePolicy")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+	rule = normalizePolicy(rule)
+
+	err := p.changePolicy(ctx1, transport, rule, func(enforcer policy.Enforcer) error {
+		removed, err := enforcer.RemovePolicy(ctx1, rule.Values()...)
+		if err == nil && !removed {
+			return domain.NotFound(policyNotFound)
+		}
+		if err == nil && !keepsPolicyManagement(enforcer, transport) {
+			_, _ = enforcer.AddPolicy(ctx1, rule.Values()...)
+			return domain.PermissionDenied(domain.ReasonForbidden, policyManagementLock)
+		}
+		return err
+	})
+	p.AuditUsecase.Record(ctx1, actor, policyTarget(transport, rule), domain.AuditPolicyRemoved, err)
+	if err != nil {
+		tracer.LogError(span, err)
+	}
+
+	return err
+}
+
+func (p *policyUsecase) changePolicy(context context.Context, transport string, rule domain.Policy, change func(enforcer policy.Enforcer) error) error {
+	if rule.Subject == "" || rule.Object == "" {
+		return domain.InvalidArgument(policyIncomplete)
+	}
+	if (transport == domain.PolicyTransportResource) != (rule.Condition != "") {
+		return domain.InvalidArgument(policyCondition)
+	}
+
+	enforcer, err := p.enforcer(transport)
+	if err != nil {
+		return err
+	}
+
+	return change(enforcer)
+}
+
+func (p *policyUsecase) AddInheritance(context context.Context, actor, transport string, inheritance domain.RoleInheritance) error {
+	span := tracer.StartSpanFromContext(context, "usecase/AddRoleInheritance")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+	inheritance = normalizeInheritance(inheritance)
+
+	err := p.changeInheritance(ctx1, transport, inheritance, func(enforcer policy.Enforcer) error {
+		added, err := enforcer.AddGrouping(ctx1, inheritance.Role, inheritance.Parent)
+		if err == nil && !added {
+			err = domain.InvalidArgument(inheritanceExists)
+		}
+		return err
+	})
+	p.AuditUsecase.Record(ctx1, actor, inheritanceTarget(transport, inheritance), domain.AuditRoleInheritanceAdded, err)
+	if err != nil {
+		tracer.LogError(span, err)
+	}
+
+	return err
+}
+
+func (p *policyUsecase) RemoveInheritance(context context.Context, actor, transport string, inheritance domain.RoleInheritance) error {
+	span := tracer.StartSpanFromContext(context, "usecase/RemoveRoleInheritance")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+	inheritance = normalizeInheritance(inheritance)
+
+	err := p.changeInheritance(ctx1, transport, inheritance, func(enforcer policy.Enforcer) error {
+		removed, err := enforcer.RemoveGrouping(ctx1, inheritance.Role, inheritance.Parent)
+		if err == nil && !removed {
+			return domain.NotFound(inheritanceNotFound)
+		}
+		if err == nil && !keepsPolicyManagement(enforcer, transport) {
+			_, _ = enforcer.AddGrouping(ctx1, inheritance.Role, inheritance.Parent)
+			return domain.PermissionDenied(domain.ReasonForbidden, policyManagementLock)
+		}
+		return err
+	})
+	p.AuditUsecase.Record(ctx1, actor, inheritanceTarget(transport, inheritance), domain.AuditRoleInheritanceRemoved, err)
+	if err != nil {
+		tracer.LogError(span, err)
+	}
+
+	return err
+}
+
+func (p *policyUsecase) changeInheritance(context context.Context, transport string, inheritance domain.RoleInheritance, change func(enforcer policy.Enforcer) error) error {
+	if inheritance.Role == "" || inheritance.Parent == "" || inheritance.Role == inheritance.Parent {
+		return domain.InvalidArgument(inheritanceInvalid)
+	}
+
+	enforcer, err := p.enforcer(transport)
+	if err != nil {
+		return err
+	}
+
+	return change(enforcer)
+}
+
+// Explain tells whether the subject may call the route and which rule allows it.
+func (p *policyUsecase) Explain(context context.Context, transport, subject, object, action string) ([]domain.PolicyExplanation, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/ExplainPolicy")
+	defer span.Finish()
+
+	if subject == "" || object == "" {
+		return nil, domain.InvalidArgument(policyIncomplete)
+	}
+	if transport == domain.PolicyTransportResource {
+		return nil, domain.InvalidArgument(resourcesNotRouted)
+	}
+
+	enforcer, err := p.enforcer(transport)
+	if err != nil {
+		return nil, err
+	}
+
+	if transport == domain.PolicyTransportGrpc {
+		action = "*"
+	}
+
+	explanations, err := policy.Explain(enforcer, domain.NormalizePolicySubject(subject), object, strings.ToUpper(action), p.Routes.Get(transport))
+	if err != nil {
+		tracer.LogError(span, err)
+	}
+
+	return explanations, err
+}
+
+// Diff compares the transport's policy with a proposed version without applying it.
+func (p *policyUsecase) Diff(context context.Context, transport string, proposed io.Reader) ([]domain.AccessChange, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/DiffPolicy")
+	defer span.Finish()
+
+	if transport == domain.PolicyTransportResource {
+		return nil, domain.InvalidArgument(resourcesNotRouted)
+	}
+
+	enforcer, err := p.enforcer(transport)
+	if err != nil {
+		return nil, err
+	}
+
+	routes := p.Routes.Get(transport)
+	if len(routes) == 0 {
+		return nil, errors.New(routesUnknown)
+	}
+
+	snapshot, err := enforcer.LoadSnapshot(proposed)
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, domain.InvalidArgument(fmt.Sprintf("invalid policy: %v", err))
+	}
+
+	return policy.Diff(enforcer, snapshot, routes)
+}
+
+func (p *policyUsecase) enforcer(transport string) (policy.Enforcer, error) {
+	for _, enforcer := range p.Enforcers {
+		if enforcer.Name() == transport {
+			return enforcer, nil
+		}
+	}
+
+	return nil, domain.InvalidArgument(unknownPolicy)
+}
+
+func keepsPolicyManagement(enforcer policy.Enforcer, transport string) bool {
+	route, ok := policyManagement[transport]
+	if !ok {
+		return true
+	}
+
+	ok, err := enforcer.Enforce(policyAdminRole, route, "POST")
+	return err == nil && ok
+}
+
+// normalizePolicy upper-cases the role and allows every method when none is given.
+func normalizePolicy(rule domain.Policy) domain.Policy {
+	rule.Subject = domain.NormalizePolicySubject(rule.Subject)
+	if rule.Action == "" {
+		rule.Action = "*"
+	}
+	rule.Condition = strings.TrimSpace(rule.Condition)
+
+	return rule
+}
+
+func normalizeInheritance(inheritance domain.RoleInheritance) domain.RoleInheritance {
+	inheritance.Role = strings.ToUpper(inheritance.Role)
+	inheritance.Parent = strings.ToUpper(inheritance.Parent)
+
+	return inheritance
+}
+
+func policyTarget(transport string, rule domain.Policy) string {
+	return fmt.Sprintf("%v: p, %v", transport, strings.Join(rule.Values(), ", "))
+}
+
+func inheritanceTarget(transport string, inheritance domain.RoleInheritance) string {
+	return fmt.Sprintf("%v: g, %v, %v", transport, inheritance.Role, inheritance.Parent)
+}
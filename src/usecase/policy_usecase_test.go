@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/policy"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+// memoryEnforcer keeps a transport's policy in memory instead of Postgres.
+type memoryEnforcer struct {
+	policy.Enforcer
+	name      string
+	enforcer  *casbin.Enforcer
+	reloadErr error
+	reloads   int
+}
+
+func newMemoryEnforcer(t *testing.T, name string, policies, groupings [][]string) *memoryEnforcer {
+	enforcer, err := casbin.NewEnforcer("../http/middleware/rbac_model.conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := enforcer.AddPolicies(policies); err != nil {
+		t.Fatal(err)
+	}
+	if len(groupings) > 0 {
+		if _, err := enforcer.AddGroupingPolicies(groupings); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &memoryEnforcer{name: name, enforcer: enforcer}
+}
+
+func (m *memoryEnforcer) Name() string { return m.name }
+
+func (m *memoryEnforcer) Reload() error {
+	m.reloads++
+	return m.reloadErr
+}
+
+func (m *memoryEnforcer) Enforce(sub, obj, act string) (bool, error) {
+	return m.enforcer.Enforce(sub, obj, act)
+}
+
+func (m *memoryEnforcer) EnforceEx(sub, obj, act string) (bool, []string, error) {
+	return m.enforcer.EnforceEx(sub, obj, act)
+}
+
+func (m *memoryEnforcer) Policies() [][]string  { return m.enforcer.GetPolicy() }
+func (m *memoryEnforcer) Groupings() [][]string { return m.enforcer.GetGroupingPolicy() }
+
+func (m *memoryEnforcer) AddPolicy(context context.Context, values ...string) (bool, error) {
+	return m.enforcer.AddPolicy(values)
+}
+
+func (m *memoryEnforcer) RemovePolicy(context context.Context, values ...string) (bool, error) {
+	return m.enforcer.RemovePolicy(values)
+}
+
+func (m *memoryEnforcer) AddGrouping(context context.Context, values ...string) (bool, error) {
+	return m.enforcer.AddGroupingPolicy(values)
+}
+
+func (m *memoryEnforcer) RemoveGrouping(context context.Context, values ...string) (bool, error) {
+	return m.enforcer.RemoveGroupingPolicy(values)
+}
+
+func newHttpPolicy(t *testing.T) *memoryEnforcer {
+	return newMemoryEnforcer(t, domain.PolicyTransportHttp,
+		[][]string{
+			{"POLICY_ADMIN", "/admin/policies", "*"},
+			{"ADMIN", "/admin/policies", "*"},
+			{"USER", "/changePassword", "POST"},
+		},
+		[][]string{{"ADMIN", "POLICY_ADMIN"}, {"AGENT", "USER"}})
+}
+
+func TestPolicyChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(PolicyUsecase) error
+		wantKind string
+		wantRule []string
+		wantGone []string
+	}{
+		{
+			name: "add policy",
+			change: func(p PolicyUsecase) error {
+				return p.AddPolicy(context.Background(), "admin", domain.PolicyTransportHttp, domain.Policy{Subject: "agent", Object: "/agent"})
+			},
+			wantRule: []string{"AGENT", "/agent", "*"},
+		},
+		{
+			name: "add existing policy",
+			change: func(p PolicyUsecase) error {
+				return p.AddPolicy(context.Background(), "admin", domain.PolicyTransportHttp, domain.Policy{Subject: "user", Object: "/changePassword", Action: "POST"})
+			},
+			wantKind: domain.ErrorInvalidArgument,
+		},
+		{
+			name: "add policy without object",
+			change: func(p PolicyUsecase) error {
+				return p.AddPolicy(context.Background(), "admin", domain.PolicyTransportHttp, domain.Policy{Subject: "user"})
+			},
+			wantKind: domain.ErrorInvalidArgument,
+		},
+		{
+			name: "add policy with a condition to a route policy",
+			change: func(p PolicyUsecase) error {
+				return p.AddPolicy(context.Background(), "admin", domain.PolicyTransportHttp, domain.Policy{Subject: "user", Object: "/x", Condition: "true"})
+			},
+			wantKind: domain.ErrorInvalidArgument,
+		},
+		{
+			name: "add policy to unknown transport",
+			change: func(p PolicyUsecase) error {
+				return p.AddPolicy(context.Background(), "admin", "soap", domain.Policy{Subject: "user", Object: "/x"})
+			},
+			wantKind: domain.ErrorInvalidArgument,
+		},
+		{
+			name: "remove policy",
+			change: func(p PolicyUsecase) error {
+				return p.RemovePolicy(context.Background(), "admin", domain.PolicyTransportHttp, domain.Policy{Subject: "USER", Object: "/changePassword", Action: "POST"})
+			},
+			wantGone: []string{"USER", "/changePassword", "POST"},
+		},
+		{
+			name: "remove missing policy",
+			change: func(p PolicyUsecase) error {
+				return p.RemovePolicy(context.Background(), "admin", domain.PolicyTransportHttp, domain.Policy{Subject: "USER", Object: "/agent"})
+			},
+			wantKind: domain.ErrorNotFound,
+		},
+		{
+			name: "remove one of two rules granting policy management",
+			change: func(p PolicyUsecase) error {
+				return p.RemovePolicy(context.Background(), "admin", domain.PolicyTransportHttp, domain.Policy{Subject: "ADMIN", Object: "/admin/policies"})
+			},
+			wantGone: []string{"ADMIN", "/admin/policies", "*"},
+		},
+		{
+			name: "remove inheritance admins manage policies through",
+			change: func(p PolicyUsecase) error {
+				if err := p.RemovePolicy(context.Background(), "admin", domain.PolicyTransportHttp, domain.Policy{Subject: "ADMIN", Object: "/admin/policies"}); err != nil {
+					return err
+				}
+				return p.RemoveInheritance(context.Background(), "admin", domain.PolicyTransportHttp, domain.RoleInheritance{Role: "admin", Parent: "policy_admin"})
+			},
+			wantKind: domain.ErrorPermissionDenied,
+		},
+		{
+			name: "inherit from itself",
+			change: func(p PolicyUsecase) error {
+				return p.AddInheritance(context.Background(), "admin", domain.PolicyTransportHttp, domain.RoleInheritance{Role: "agent", Parent: "AGENT"})
+			},
+			wantKind: domain.ErrorInvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enforcer := newHttpPolicy(t)
+			audit := &auditTrail{}
+			usecase := NewPolicyUsecase([]policy.Enforcer{enforcer}, nil, audit, logger.InitializeLogger("auth-service", context.Background()))
+
+			err := tt.change(usecase)
+			if tt.wantKind == "" && err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if tt.wantKind != "" && domain.AsError(err).Kind != tt.wantKind {
+				t.Fatalf("err = %v, want %v", err, tt.wantKind)
+			}
+			if tt.wantRule != nil && !enforcer.enforcer.HasPolicy(tt.wantRule) {
+				t.Errorf("%v wasn't added", tt.wantRule)
+			}
+			if tt.wantGone != nil && enforcer.enforcer.HasPolicy(tt.wantGone) {
+				t.Errorf("%v wasn't removed", tt.wantGone)
+			}
+			if ok, _ := enforcer.Enforce("ADMIN", "/admin/policies", "POST"); !ok {
+				t.Error("admins lost access to policy management")
+			}
+			if len(audit.actions) == 0 {
+				t.Error("change wasn't audited")
+			}
+		})
+	}
+}
+
+func TestReloadKeepsGoingAfterAFailure(t *testing.T) {
+	broken := newHttpPolicy(t)
+	broken.reloadErr = errors.New("connection refused")
+	grpc := newMemoryEnforcer(t, domain.PolicyTransportGrpc, [][]string{{"ADMIN", "/Authentication/AddPolicy", "*"}}, nil)
+	audit := &auditTrail{}
+	usecase := NewPolicyUsecase([]policy.Enforcer{broken, grpc}, nil, audit, logger.InitializeLogger("auth-service", context.Background()))
+
+	if err := usecase.Reload(context.Background(), "admin"); !errors.Is(err, broken.reloadErr) {
+		t.Errorf("err = %v, want the http policy's error", err)
+	}
+	if grpc.reloads != 1 {
+		t.Errorf("grpc policy reloaded %v times", grpc.reloads)
+	}
+	if len(audit.actions) != 2 {
+		t.Errorf("audited %v, want both reloads", audit.actions)
+	}
+}
@@ -7,12 +7,19 @@ import (
 	"auth-service/infrastructure/tracer"
 	"auth-service/repository"
 	"context"
+	"errors"
 	logger "github.com/jelena-vlajkov/logger/logger"
+	"time"
 )
 
 type profileInfoUsecase struct {
 	ProfileInfoRepository repository.ProfileInfoRepository
+	PasswordHistoryRepository repository.PasswordHistoryRepository
 	RedisUsecase          RedisUsecase
+	PasswordPolicyUsecase PasswordPolicyUsecase
+	SecurityEventUsecase  SecurityEventUsecase
+	BruteForceUsecase     BruteForceUsecase
+	MailUsecase           MailUsecase
 	logger *logger.Logger
 }
 
@@ -26,7 +33,10 @@ const (
 	updateError              = "error while updating user"
 	redisError               = "error while deleting redis key"
 	passwordsError           = "enter same passwords"
+	invalidOldPass           = "old password is not correct"
+	recentlyUsedPass         = "password was used recently, choose a different one"
 	redisPassResetKeyPattern = "passwordResetRequest"
+	passResetCodeExpiration  = 2 * time.Hour
 )
 
 type ProfileInfoUsecase interface {
@@ -35,12 +45,15 @@ type ProfileInfoUsecase interface {
 	Create(context context.Context, profileInfo *domain.ProfileInfo) (*domain.ProfileInfo, error)
 	ExistsByUsernameOrEmail(context context.Context, username, email string) bool
 	GetProfileInfoById(context context.Context, id string) (*domain.ProfileInfo, error)
-	ResetPassword(ctx context.Context, dto dto.ResetPassDTO) string
+	SendResetMail(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, dto dto.ResetPassDTO) error
+	ChangePassword(ctx context.Context, userId string, dto dto.ChangePasswordDto) error
+	IsPasswordExpired(ctx context.Context, profileInfo domain.ProfileInfo) bool
 	DeleteProfileInfo(ctx context.Context, username string) error
 }
 
-func NewProfileInfoUsecase(p repository.ProfileInfoRepository, r RedisUsecase, logger *logger.Logger) ProfileInfoUsecase {
-	return &profileInfoUsecase{ProfileInfoRepository: p, RedisUsecase: r, logger: logger}
+func NewProfileInfoUsecase(p repository.ProfileInfoRepository, h repository.PasswordHistoryRepository, r RedisUsecase, passwordPolicyUsecase PasswordPolicyUsecase, securityEventUsecase SecurityEventUsecase, bruteForceUsecase BruteForceUsecase, mailUsecase MailUsecase, logger *logger.Logger) ProfileInfoUsecase {
+	return &profileInfoUsecase{ProfileInfoRepository: p, PasswordHistoryRepository: h, RedisUsecase: r, PasswordPolicyUsecase: passwordPolicyUsecase, SecurityEventUsecase: securityEventUsecase, BruteForceUsecase: bruteForceUsecase, MailUsecase: mailUsecase, logger: logger}
 }
 
 func (p *profileInfoUsecase) DeleteProfileInfo(ctx context.Context, username string) error {
@@ -57,7 +70,17 @@ func (p *profileInfoUsecase) GetProfileInfoByUsername(context context.Context, u
 
 func (p *profileInfoUsecase) Create(context context.Context, profileInfo *domain.ProfileInfo) (*domain.ProfileInfo, error) {
 	p.logger.Logger.Infof("creating profile info for email %v\n", profileInfo.Email)
-	return p.ProfileInfoRepository.Create(context, profileInfo)
+	profileInfo.PasswordChangedAt = time.Now()
+	created, err := p.ProfileInfoRepository.Create(context, profileInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.recordPasswordHistory(context, created); err != nil {
+		p.logger.Logger.Errorf("error while recording password history for %v, error: %v\n", created.ID, err)
+	}
+
+	return created, nil
 }
 
 func (p *profileInfoUsecase) GetProfileInfoByEmail(context context.Context, email string) (domain.ProfileInfo, error) {
@@ -69,65 +92,201 @@ func (p *profileInfoUsecase) GetProfileInfoByEmail(context context.Context, emai
 	return p.ProfileInfoRepository.GetProfileInfoByEmail(ctx1, email)
 }
 
-func (p *profileInfoUsecase) ResetPassword(ctx context.Context, dto dto.ResetPassDTO) string {
+// SendResetMail mails a reset code to the user.
+func (p *profileInfoUsecase) SendResetMail(ctx context.Context, email string) error {
+	if !p.ExistsByUsernameOrEmail(ctx, "", email) {
+		p.logger.Logger.Errorf("error while sending reset mail, error: no user with email %v\n", email)
+		return domain.NotFound("user does not exist")
+	}
+
+	account, err := p.ProfileInfoRepository.GetProfileInfoByEmail(ctx, email)
+	if err != nil {
+		return domain.NotFound(userNotFound)
+	}
+
+	code := helper.RandomStringGenerator(8)
+	hash, err := helper.Hash(code)
+	if err != nil {
+		return err
+	}
+
+	if err := p.RedisUsecase.AddKeyValueSet(ctx, redisPassResetKeyPattern+account.Email, hash, passResetCodeExpiration); err != nil {
+		p.logger.Logger.Errorf("error while adding reset code to redis, error: %v\n", err)
+		return err
+	}
+
+	if err := p.MailUsecase.SendResetPasswordMail(ctx, account.Email, code); err != nil {
+		p.logger.Logger.Errorf("error while sending reset mail to %v, error: %v\n", account.Email, err)
+		return err
+	}
+
+	return nil
+}
+
+func (p *profileInfoUsecase) ResetPassword(ctx context.Context, dto dto.ResetPassDTO) error {
 	p.logger.Logger.Infof("reseting password for user %v\n", dto.Email)
 
 	if passwordCompare := dto.Password == dto.ConfirmedPassword; !passwordCompare {
-		return passwordsError
+		return domain.InvalidArgument(passwordsError)
+	}
+
+	if err := p.BruteForceUsecase.Check(ctx, BruteForceReset, dto.Email); err != nil {
+		return err
 	}
 
 	exists := p.ExistsByUsernameOrEmail(ctx, "", dto.Email)
 	if !exists {
 		p.logger.Logger.Errorf("error while reseting password, error: user %v not found\n", dto.Email)
-		return userNotFound
+		return domain.NotFound(userNotFound)
 	}
 	account, err := p.ProfileInfoRepository.GetProfileInfoByEmail(ctx, dto.Email)
 	if err != nil {
-		return userNotFound
+		return domain.NotFound(userNotFound)
 	}
 	key := redisPassResetKeyPattern + dto.Email
 	codeValue, err := p.RedisUsecase.GetValueByKey(ctx, key)
 	if err != nil {
 		p.logger.Logger.Errorf("error while reseting password, error: email not sent to %v\n", dto.Email)
-		return emailNotSent
+		return errors.New(emailNotSent)
 	}
 
 	err = VerifyPassword(ctx, dto.VerificationCode, string(codeValue))
 	if err != nil {
 		p.logger.Logger.Errorf("error while reseting password, error: %v\n", invalidCode)
-		return invalidCode
+		p.BruteForceUsecase.Fail(ctx, BruteForceReset, dto.Email)
+		return domain.InvalidArgument(invalidCode)
+	}
+	p.BruteForceUsecase.Succeed(ctx, BruteForceReset, dto.Email)
+
+	if err := p.updatePassword(ctx, &account, dto.Password); err != nil {
+		return err
+	}
+
+	p.SecurityEventUsecase.Publish(ctx, domain.SecurityEvent{Type: domain.SecurityEventPasswordReset, ProfileInfoId: account.ID})
+
+	err = p.RedisUsecase.DeleteValueByKey(ctx, key)
+
+	if err != nil {
+		return errors.New(redisError)
+	}
+
+	return nil
+
+}
+
+func (p *profileInfoUsecase) ChangePassword(ctx context.Context, userId string, dto dto.ChangePasswordDto) error {
+	p.logger.Logger.Infof("changing password for user %v\n", userId)
+
+	if dto.Password != dto.ConfirmedPassword {
+		return domain.InvalidArgument(passwordsError)
+	}
+
+	account, err := p.ProfileInfoRepository.GetProfileInfoById(ctx, userId)
+	if err != nil {
+		p.logger.Logger.Errorf("error while changing password, error: user %v not found\n", userId)
+		return domain.NotFound(userNotFound)
+	}
+
+	if err := VerifyPassword(ctx, dto.OldPassword, account.Password); err != nil {
+		p.logger.Logger.Errorf("error while changing password, error: %v\n", invalidOldPass)
+		return domain.InvalidArgument(invalidOldPass)
+	}
+
+	if err := p.updatePassword(ctx, account, dto.Password); err != nil {
+		return err
+	}
+
+	p.SecurityEventUsecase.Publish(ctx, domain.SecurityEvent{Type: domain.SecurityEventPasswordChanged, ProfileInfoId: account.ID})
+	return nil
+}
+
+func (p *profileInfoUsecase) IsPasswordExpired(ctx context.Context, profileInfo domain.ProfileInfo) bool {
+	if !p.PasswordPolicyUsecase.IsExpired(profileInfo.PasswordChangedAt) {
+		return false
+	}
+
+	p.logger.Logger.Warnf("password expired for user %v\n", profileInfo.ID)
+	return true
+}
+
+func (p *profileInfoUsecase) updatePassword(ctx context.Context, account *domain.ProfileInfo, password string) error {
+	if err := p.PasswordPolicyUsecase.Validate(ctx, password, account.Username, account.Email); err != nil {
+		return err
 	}
 
-	err = VerifyPassword(ctx, dto.Password, account.Password)
+	err := VerifyPassword(ctx, password, account.Password)
 	if err == nil {
-		p.logger.Logger.Errorf("error while reseting password, error: %v\n", invalidPass)
-		return invalidPass
+		p.logger.Logger.Errorf("error while updating password, error: %v\n", invalidPass)
+		return domain.InvalidArgument(invalidPass)
+	}
+
+	recentlyUsed, err := p.isRecentlyUsed(ctx, account.ID, password)
+	if err != nil {
+		p.logger.Logger.Errorf("error while checking password history, error: %v\n", err)
+		return errors.New(updateError)
+	}
+	if recentlyUsed {
+		p.logger.Logger.Errorf("error while updating password, error: %v\n", recentlyUsedPass)
+		return domain.InvalidArgument(recentlyUsedPass)
 	}
 
-	newPass, err := helper.Hash(dto.Password)
+	newPass, err := helper.Hash(password)
 
 	if err != nil {
-		p.logger.Logger.Errorf("error while reseting password, error: %v\n", hashError)
-		return hashError
+		p.logger.Logger.Errorf("error while updating password, error: %v\n", hashError)
+		return errors.New(hashError)
 	}
 
 	account.Password = string(newPass)
+	account.PasswordChangedAt = time.Now()
+	// A new password is how the owner takes back a locked account.
+	account.LockedAt = nil
 
-	err = p.ProfileInfoRepository.Update(ctx, &account)
+	err = p.ProfileInfoRepository.Update(ctx, account)
 
 	if err != nil {
-		p.logger.Logger.Errorf("error while reseting password, error: %v\n", updateError)
-		return updateError
+		p.logger.Logger.Errorf("error while updating password, error: %v\n", updateError)
+		return errors.New(updateError)
 	}
 
-	err = p.RedisUsecase.DeleteValueByKey(ctx, key)
+	if err := p.recordPasswordHistory(ctx, account); err != nil {
+		p.logger.Logger.Errorf("error while recording password history for %v, error: %v\n", account.ID, err)
+	}
+
+	return nil
+}
 
+func (p *profileInfoUsecase) isRecentlyUsed(ctx context.Context, profileInfoId, password string) (bool, error) {
+	depth := p.PasswordPolicyUsecase.HistoryDepth()
+	if depth <= 0 {
+		return false, nil
+	}
+
+	history, err := p.PasswordHistoryRepository.GetLastByProfileInfoId(ctx, profileInfoId, depth)
 	if err != nil {
-		return redisError
+		return false, err
+	}
+
+	for _, it := range history {
+		if VerifyPassword(ctx, password, it.Password) == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p *profileInfoUsecase) recordPasswordHistory(ctx context.Context, account *domain.ProfileInfo) error {
+	depth := p.PasswordPolicyUsecase.HistoryDepth()
+	if depth <= 0 {
+		return nil
 	}
 
-	return ""
+	history := &domain.PasswordHistory{ProfileInfoId: account.ID, Password: account.Password}
+	if err := p.PasswordHistoryRepository.Create(ctx, history); err != nil {
+		return err
+	}
 
+	return p.PasswordHistoryRepository.DeleteAllExceptLast(ctx, account.ID, depth)
 }
 
 func (p *profileInfoUsecase) ExistsByUsernameOrEmail(context context.Context, username, email string) bool {
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"auth-service/infrastructure/dto"
+	"auth-service/repository"
+	"context"
+	"errors"
+	"testing"
+
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+type accountRepository struct {
+	repository.ProfileInfoRepository
+	account *domain.ProfileInfo
+	updated bool
+}
+
+func (a *accountRepository) GetProfileInfoById(context context.Context, id string) (*domain.ProfileInfo, error) {
+	if a.account == nil || a.account.ID != id {
+		return nil, errors.New("record not found")
+	}
+	copied := *a.account
+	return &copied, nil
+}
+
+func (a *accountRepository) Update(context context.Context, profileInfo *domain.ProfileInfo) error {
+	a.account = profileInfo
+	a.updated = true
+	return nil
+}
+
+// historyRepository keeps the password hashes of one account, newest last.
+type historyRepository struct {
+	repository.PasswordHistoryRepository
+	hashes []string
+	err    error
+}
+
+func (h *historyRepository) Create(context context.Context, history *domain.PasswordHistory) error {
+	h.hashes = append(h.hashes, history.Password)
+	return nil
+}
+
+func (h *historyRepository) GetLastByProfileInfoId(context context.Context, profileInfoId string, limit int) ([]domain.PasswordHistory, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	var history []domain.PasswordHistory
+	for i := len(h.hashes) - 1; i >= 0 && len(history) < limit; i-- {
+		history = append(history, domain.PasswordHistory{ProfileInfoId: profileInfoId, Password: h.hashes[i]})
+	}
+	return history, nil
+}
+
+func (h *historyRepository) DeleteAllExceptLast(context context.Context, profileInfoId string, keep int) error {
+	if len(h.hashes) > keep {
+		h.hashes = h.hashes[len(h.hashes)-keep:]
+	}
+	return nil
+}
+
+type historyPolicy struct {
+	PasswordPolicyUsecase
+	depth int
+}
+
+func (h historyPolicy) Validate(context context.Context, password, username, email string) error {
+	return nil
+}
+
+func (h historyPolicy) HistoryDepth() int {
+	return h.depth
+}
+
+type ignoredEvents struct {
+	SecurityEventUsecase
+}
+
+func (ignoredEvents) Publish(context context.Context, event domain.SecurityEvent) {}
+
+func hashed(t *testing.T, password string) string {
+	hash, err := helper.Hash(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(hash)
+}
+
+func TestChangePasswordHistory(t *testing.T) {
+	current := hashed(t, "current-pass")
+	older := hashed(t, "older-pass")
+	oldest := hashed(t, "oldest-pass")
+
+	tests := []struct {
+		name        string
+		depth       int
+		historyErr  error
+		password    string
+		wantErr     string
+		wantHistory int
+	}{
+		{name: "new password", depth: 3, password: "brand-new-pass", wantHistory: 3},
+		{name: "current password", depth: 3, password: "current-pass", wantErr: invalidPass, wantHistory: 3},
+		{name: "recent password", depth: 3, password: "older-pass", wantErr: recentlyUsedPass, wantHistory: 3},
+		{name: "password older than the history", depth: 2, password: "oldest-pass", wantHistory: 2},
+		{name: "history disabled", depth: 0, password: "older-pass", wantHistory: 3},
+		{name: "history unavailable", depth: 3, historyErr: errors.New("connection refused"), password: "brand-new-pass", wantErr: updateError, wantHistory: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts := &accountRepository{account: &domain.ProfileInfo{ID: "1", Password: current}}
+			history := &historyRepository{hashes: []string{oldest, older, current}, err: tt.historyErr}
+			usecase := NewProfileInfoUsecase(accounts, history, nil, historyPolicy{depth: tt.depth}, ignoredEvents{}, nil, nil,
+				logger.InitializeLogger("auth-service", context.Background()))
+
+			err := usecase.ChangePassword(context.Background(), "1", dto.ChangePasswordDto{OldPassword: "current-pass", Password: tt.password, ConfirmedPassword: tt.password})
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if accounts.updated {
+					t.Error("password updated")
+				}
+			}
+			if len(history.hashes) != tt.wantHistory {
+				t.Errorf("history keeps %v passwords, want %v", len(history.hashes), tt.wantHistory)
+			}
+			if tt.wantErr == "" && tt.depth > 0 && VerifyPassword(context.Background(), tt.password, history.hashes[len(history.hashes)-1]) != nil {
+				t.Error("new password not recorded in the history")
+			}
+		})
+	}
+}
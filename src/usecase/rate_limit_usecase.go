@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"context"
+	"fmt"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"strings"
+	"time"
+)
+
+const rateLimitKey = "rateLimit/"
+
+type RateLimitExceededError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitExceededError) Error() string {
+	return fmt.Sprintf("too many requests, try again in %v", e.RetryAfter.Round(time.Second))
+}
+
+func (e *RateLimitExceededError) DescribeError() *domain.Error {
+	return domain.ResourceExhausted(domain.ReasonRateLimited, e.Error(), e.RetryAfter)
+}
+
+type rateLimitUsecase struct {
+	Config       domain.RateLimitConfig
+	RedisUsecase RedisUsecase
+	logger       *logger.Logger
+}
+
+// RateLimitUsecase limits requests per route in sliding windows kept in Redis.
+type RateLimitUsecase interface {
+	Rules(route, method string) []domain.RateLimitRule
+	Allow(context context.Context, rule domain.RateLimitRule, key string) error
+}
+
+func NewRateLimitUsecase(config domain.RateLimitConfig, redisUsecase RedisUsecase, logger *logger.Logger) RateLimitUsecase {
+	return &rateLimitUsecase{Config: config, RedisUsecase: redisUsecase, logger: logger}
+}
+
+func (r *rateLimitUsecase) Rules(route, method string) []domain.RateLimitRule {
+	if !r.Config.Enabled {
+		return nil
+	}
+
+	var rules []domain.RateLimitRule
+	for _, rule := range r.Config.Rules {
+		if rule.Route == route && (rule.Method == "" || strings.EqualFold(rule.Method, method)) {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+// Allow counts a request against the rule, letting it through when Redis is down.
+func (r *rateLimitUsecase) Allow(context context.Context, rule domain.RateLimitRule, key string) error {
+	if key == "" {
+		return nil
+	}
+
+	redisKey := rateLimitKey + rule.Route + "/" + rule.Method + "/" + rule.Key + "/" + strings.ToLower(key)
+	retryAfter, err := r.RedisUsecase.AddToWindow(context, redisKey, rule.Limit, rule.Window)
+	if err != nil {
+		r.logger.Logger.Errorf("error while rate limiting %v, letting the request through, error: %v\n", rule.Route, err)
+		return nil
+	}
+
+	if retryAfter > 0 {
+		r.logger.Logger.Warnf("rate limit of %v per %v exceeded for %v by %v %v\n", rule.Limit, rule.Window, rule.Route, rule.Key, key)
+		return &RateLimitExceededError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
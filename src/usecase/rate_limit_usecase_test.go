@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+// windowRedis records the sliding windows requests are counted in.
+type windowRedis struct {
+	RedisUsecase
+	keys       []string
+	retryAfter time.Duration
+	err        error
+}
+
+func (w *windowRedis) AddToWindow(context context.Context, key string, limit int, window time.Duration) (time.Duration, error) {
+	w.keys = append(w.keys, key)
+	return w.retryAfter, w.err
+}
+
+var (
+	loginByIp    = domain.RateLimitRule{Route: "/login", Method: "POST", Key: domain.RateLimitByIp, Limit: 10, Window: time.Minute}
+	loginByEmail = domain.RateLimitRule{Route: "/login", Method: "POST", Key: domain.RateLimitByEmail, Limit: 5, Window: time.Hour}
+	loginAny     = domain.RateLimitRule{Route: "/login", Key: domain.RateLimitByIp, Limit: 100, Window: time.Minute}
+	grpcLogin    = domain.RateLimitRule{Route: "/proto.Authentication/Login", Key: domain.RateLimitByEmail, Limit: 5, Window: time.Hour}
+)
+
+func TestRateLimitRules(t *testing.T) {
+	config := domain.RateLimitConfig{Enabled: true, Rules: []domain.RateLimitRule{loginByIp, loginByEmail, loginAny, grpcLogin}}
+
+	tests := []struct {
+		name   string
+		config domain.RateLimitConfig
+		route  string
+		method string
+		want   []domain.RateLimitRule
+	}{
+		{name: "rules of the method and every method", config: config, route: "/login", method: "POST", want: []domain.RateLimitRule{loginByIp, loginByEmail, loginAny}},
+		{name: "method is case insensitive", config: config, route: "/login", method: "post", want: []domain.RateLimitRule{loginByIp, loginByEmail, loginAny}},
+		{name: "other method", config: config, route: "/login", method: "GET", want: []domain.RateLimitRule{loginAny}},
+		{name: "grpc method", config: config, route: "/proto.Authentication/Login", want: []domain.RateLimitRule{grpcLogin}},
+		{name: "grpc call does not match http rules", config: config, route: "/login", want: []domain.RateLimitRule{loginAny}},
+		{name: "route without rules", config: config, route: "/register", method: "POST"},
+		{name: "path prefix is not a match", config: config, route: "/login/other", method: "POST"},
+		{name: "disabled", config: domain.RateLimitConfig{Rules: config.Rules}, route: "/login", method: "POST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewRateLimitUsecase(tt.config, nil, logger.InitializeLogger("auth-service", context.Background()))
+			if got := usecase.Rules(tt.route, tt.method); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rules = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitAllow(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       domain.RateLimitRule
+		key        string
+		retryAfter time.Duration
+		redisErr   error
+		wantKeys   []string
+		wantRetry  time.Duration
+	}{
+		{name: "under the limit", rule: loginByIp, key: "10.0.0.1", wantKeys: []string{"rateLimit//login/POST/ip/10.0.0.1"}},
+		{name: "email is case insensitive", rule: loginByEmail, key: "User@Example.com", wantKeys: []string{"rateLimit//login/POST/email/user@example.com"}},
+		{name: "rule for every method", rule: loginAny, key: "10.0.0.1", wantKeys: []string{"rateLimit//login//ip/10.0.0.1"}},
+		{name: "over the limit", rule: loginByIp, key: "10.0.0.1", retryAfter: 30 * time.Second,
+			wantKeys: []string{"rateLimit//login/POST/ip/10.0.0.1"}, wantRetry: 30 * time.Second},
+		{name: "redis unavailable lets the request through", rule: loginByIp, key: "10.0.0.1", redisErr: errors.New("connection refused"),
+			wantKeys: []string{"rateLimit//login/POST/ip/10.0.0.1"}},
+		{name: "requests without a key are not counted", rule: loginByEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redis := &windowRedis{retryAfter: tt.retryAfter, err: tt.redisErr}
+			config := domain.RateLimitConfig{Enabled: true, Rules: []domain.RateLimitRule{tt.rule}}
+			usecase := NewRateLimitUsecase(config, redis, logger.InitializeLogger("auth-service", context.Background()))
+
+			err := usecase.Allow(context.Background(), tt.rule, tt.key)
+
+			var limitErr *RateLimitExceededError
+			if errors.As(err, &limitErr) != (tt.wantRetry > 0) {
+				t.Fatalf("error = %v, want retry after %v", err, tt.wantRetry)
+			}
+			if tt.wantRetry > 0 && limitErr.RetryAfter != tt.wantRetry {
+				t.Errorf("retry after = %v, want %v", limitErr.RetryAfter, tt.wantRetry)
+			}
+			if tt.wantRetry == 0 && err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(redis.keys, tt.wantKeys) {
+				t.Errorf("windows = %v, want %v", redis.keys, tt.wantKeys)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// memoryRedis keeps keys in memory with the expiration they were set with.
+type memoryRedis struct {
+	RedisUsecase
+	mu     sync.Mutex
+	values map[string]string
+	ttls   map[string]time.Duration
+	sets   map[string][]string
+}
+
+func newMemoryRedis() *memoryRedis {
+	return &memoryRedis{values: map[string]string{}, ttls: map[string]time.Duration{}, sets: map[string][]string{}}
+}
+
+func (m *memoryRedis) AddKeyValueSet(context context.Context, key string, value interface{}, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if bytes, ok := value.([]byte); ok {
+		value = string(bytes)
+	}
+	m.values[key] = fmt.Sprint(value)
+	m.ttls[key] = expiration
+	return nil
+}
+
+func (m *memoryRedis) GetValueByKey(context context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value, ok := m.values[key]
+	if !ok {
+		return nil, redis.Nil
+	}
+	return []byte(value), nil
+}
+
+func (m *memoryRedis) DeleteValueByKey(context context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.values, key)
+	delete(m.ttls, key)
+	delete(m.sets, key)
+	return nil
+}
+
+func (m *memoryRedis) DeleteValueIfEquals(context context.Context, key string, value string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if current, ok := m.values[key]; !ok || current != value {
+		return false, nil
+	}
+	delete(m.values, key)
+	delete(m.ttls, key)
+	return true, nil
+}
+
+func (m *memoryRedis) ExistsByKey(context context.Context, key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.values[key]
+	return ok
+}
+
+func (m *memoryRedis) AddToSet(context context.Context, key string, member string, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sets[key] = append(m.sets[key], member)
+	if expiration > m.ttls[key] {
+		m.ttls[key] = expiration
+	}
+	return nil
+}
+
+func (m *memoryRedis) GetSetMembers(context context.Context, key string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.sets[key], nil
+}
+
+func (m *memoryRedis) Increment(context context.Context, key string, expiration time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count int64
+	fmt.Sscan(m.values[key], &count)
+	count++
+	m.values[key] = fmt.Sprint(count)
+	if count == 1 {
+		m.ttls[key] = expiration
+	}
+	return count, nil
+}
+
+func (m *memoryRedis) TimeToLive(context context.Context, key string) time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.values[key]; !ok {
+		return 0
+	}
+	return m.ttls[key]
+}
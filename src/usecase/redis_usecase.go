@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"github.com/go-redis/redis/v8"
 	logger "github.com/jelena-vlajkov/logger/logger"
+	"math/rand"
+	"strconv"
 	"time"
 )
 
@@ -12,10 +14,47 @@ type RedisUsecase interface {
 	AddKeyValueSet(context context.Context, key string, value interface{},  expiration time.Duration) error
 	GetValueByKey(context context.Context, key string) ([]byte, error)
 	DeleteValueByKey(context context.Context, key string) error
+	DeleteValueIfEquals(context context.Context, key string, value string) (bool, error)
 	ExistsByKey(context context.Context, key string) bool
 	ScanKeyByPattern(ctx context.Context, pattern string) ([]string, error)
+	AddToSet(context context.Context, key string, member string, expiration time.Duration) error
+	GetSetMembers(context context.Context, key string) ([]string, error)
+	Increment(context context.Context, key string, expiration time.Duration) (int64, error)
+	TimeToLive(context context.Context, key string) time.Duration
+	AddToWindow(context context.Context, key string, limit int, window time.Duration) (time.Duration, error)
 }
 
+// slidingWindow returns zero when an entry was added to KEYS[1], or the milliseconds to wait.
+var slidingWindow = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[3]) then
+	local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+	return math.max(tonumber(oldest[2]) + window - now, 1)
+end
+redis.call('ZADD', KEYS[1], now, ARGV[4])
+redis.call('PEXPIRE', KEYS[1], window)
+return 0
+`)
+
+// fixedWindow increments the counter at KEYS[1] and expires it when created.
+var fixedWindow = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// compareAndDelete deletes KEYS[1] when it holds ARGV[1] and returns 1 if it did.
+var compareAndDelete = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
 type redisUsecase struct {
 	RedisClient *redis.Client
 	logger *logger.Logger
@@ -47,6 +86,66 @@ func (r *redisUsecase) DeleteValueByKey(context context.Context, key string) err
 	return err
 }
 
+// DeleteValueIfEquals deletes key when it holds value, reporting whether it did.
+func (r *redisUsecase) DeleteValueIfEquals(context context.Context, key string, value string) (bool, error) {
+	deleted, err := compareAndDelete.Run(context, r.RedisClient, []string{key}, value).Int64()
+	if err != nil {
+		r.logger.Logger.Errorf("error while deleting value from redis, error: %v\n", err)
+		return false, err
+	}
+	return deleted == 1, nil
+}
+
+// AddToSet adds member to the set at key, only ever extending its expiration.
+func (r *redisUsecase) AddToSet(context context.Context, key string, member string, expiration time.Duration) error {
+	if err := r.RedisClient.SAdd(context, key, member).Err(); err != nil {
+		r.logger.Logger.Errorf("error while adding to redis set, error: %v\n", err)
+		return err
+	}
+
+	if ttl := r.RedisClient.TTL(context, key).Val(); ttl < expiration {
+		return r.RedisClient.Expire(context, key, expiration).Err()
+	}
+	return nil
+}
+
+func (r *redisUsecase) GetSetMembers(context context.Context, key string) ([]string, error) {
+	return r.RedisClient.SMembers(context, key).Result()
+}
+
+// Increment increments the counter at key, which expires a fixed time after creation.
+func (r *redisUsecase) Increment(context context.Context, key string, expiration time.Duration) (int64, error) {
+	count, err := fixedWindow.Run(context, r.RedisClient, []string{key}, expiration.Milliseconds()).Int64()
+	if err != nil {
+		r.logger.Logger.Errorf("error while incrementing redis counter, error: %v\n", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// TimeToLive returns the remaining lifetime of key, or zero.
+func (r *redisUsecase) TimeToLive(context context.Context, key string) time.Duration {
+	ttl := r.RedisClient.TTL(context, key).Val()
+	if ttl < 0 {
+		return 0
+	}
+	return ttl
+}
+
+// AddToWindow records an event at key or returns how long until one fits.
+func (r *redisUsecase) AddToWindow(context context.Context, key string, limit int, window time.Duration) (time.Duration, error) {
+	now := time.Now()
+	member := strconv.FormatInt(now.UnixNano(), 10) + "-" + strconv.FormatUint(rand.Uint64(), 36)
+
+	wait, err := slidingWindow.Run(context, r.RedisClient, []string{key}, now.UnixNano()/int64(time.Millisecond), window.Milliseconds(), limit, member).Int64()
+	if err != nil {
+		r.logger.Logger.Errorf("error while adding to redis window, error: %v\n", err)
+		return 0, err
+	}
+
+	return time.Duration(wait) * time.Millisecond, nil
+}
+
 func (r *redisUsecase) ExistsByKey(context context.Context, key string) bool {
 	res := r.RedisClient.Exists(context, key).Val()
 	if res == 0 {
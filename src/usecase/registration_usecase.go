@@ -4,6 +4,7 @@ import (
 	"auth-service/domain"
 	"auth-service/gateway"
 	"auth-service/helper"
+	"auth-service/repository"
 	"bytes"
 	"context"
 	"encoding/gob"
@@ -22,6 +23,10 @@ type registrationUsecase struct {
 	RedisUsecase RedisUsecase
 	ProfileInfoUsecase ProfileInfoUsecase
 	UserGateway gateway.UserGateway
+	PasswordPolicyUsecase PasswordPolicyUsecase
+	MailUsecase MailUsecase
+	BruteForceUsecase BruteForceUsecase
+	RoleRepository repository.RoleRepository
 	logger *logger.Logger
 }
 
@@ -30,7 +35,7 @@ type RegistrationUsecase interface {
 	Register(context context.Context, user domain.User) error
 	ConfirmAccount(context context.Context, code string, email string) error
 	IsAlreadyRegistered(context context.Context, username, email string) bool
-	ResendCode(ctx context.Context ,email string) (error, string, string)
+	ResendCode(ctx context.Context ,email string) error
 	ValidateAgentAccount(context context.Context, code string, email string) error
 	RegisterAgent(context context.Context, user domain.User) error
 	ConfirmAgentAccount(context context.Context, email string, confirm bool) (*domain.User, error)
@@ -38,12 +43,16 @@ type RegistrationUsecase interface {
 	RollbackAgentRegistration(context context.Context, user domain.User) error
 }
 
-func NewRegistrationUsecase(redisUsecase RedisUsecase, profileInfoUsecase ProfileInfoUsecase, gateway gateway.UserGateway, logger *logger.Logger) RegistrationUsecase{
+func NewRegistrationUsecase(redisUsecase RedisUsecase, profileInfoUsecase ProfileInfoUsecase, gateway gateway.UserGateway, passwordPolicyUsecase PasswordPolicyUsecase, mailUsecase MailUsecase, bruteForceUsecase BruteForceUsecase, roleRepository repository.RoleRepository, logger *logger.Logger) RegistrationUsecase{
 	return &registrationUsecase{
 		logger: logger,
 		RedisUsecase: redisUsecase,
 		ProfileInfoUsecase: profileInfoUsecase,
 		UserGateway: gateway,
+		PasswordPolicyUsecase: passwordPolicyUsecase,
+		MailUsecase: mailUsecase,
+		BruteForceUsecase: bruteForceUsecase,
+		RoleRepository: roleRepository,
 		}
 }
 
@@ -51,6 +60,10 @@ func (s *registrationUsecase) Register(context context.Context, user domain.User
 	s.logger.Logger.Infof("registering user with email %v\n", user.Email)
 	redisKey := redisKeyPattern + user.Email
 
+	if err := s.PasswordPolicyUsecase.Validate(context, user.Password, user.Username, user.Email); err != nil {
+		return err
+	}
+
 	confirmationCode := helper.RandomStringGenerator(8)
 	hashedConfirmationCode, err := Hash(confirmationCode)
 	if err != nil {
@@ -67,6 +80,7 @@ func (s *registrationUsecase) Register(context context.Context, user domain.User
 	user.ID = uuid.NewString()
 	user.ConfirmationCode = string(hashedConfirmationCode)
 	user.Password = string(hashedPassword)
+	user.Locale = s.MailUsecase.PreferredLocale(context)
 
 
 	expiration  := 1000000000 * 3600 * 2 //2h
@@ -80,13 +94,21 @@ func (s *registrationUsecase) Register(context context.Context, user domain.User
 		return err
 	}
 
-	go SendMail(user.Email, user.Username, confirmationCode)
+	if err := s.MailUsecase.SendActivationMail(context, user.Email, user.Username, confirmationCode); err != nil {
+		s.logger.Logger.Errorf("error while registering user, error %v\n", err)
+		_ = s.RedisUsecase.DeleteValueByKey(context, redisKey)
+		return err
+	}
+
 	return nil
 }
 
 
 func (s *registrationUsecase) ConfirmAccount(context context.Context, code string, email string) error {
 	s.logger.Logger.Infof("confirming account for email %v\n", email)
+	if err := s.BruteForceUsecase.Check(context, BruteForceConfirmation, email); err != nil {
+		return err
+	}
 	key := redisKeyPattern + email
 	bytes, err := s.RedisUsecase.GetValueByKey(context, key)
 	if err != nil {
@@ -102,13 +124,20 @@ func (s *registrationUsecase) ConfirmAccount(context context.Context, code strin
 	fmt.Println("User id : " + user.ID)
 	if err := helper.Verify(code, user.ConfirmationCode); err != nil {
 		s.logger.Logger.Errorf("error while confirming account, error %v\n", err)
+		s.BruteForceUsecase.Fail(context, BruteForceConfirmation, email)
 		return err
 	}
+	s.BruteForceUsecase.Succeed(context, BruteForceConfirmation, email)
 	if err := s.RedisUsecase.DeleteValueByKey(context, key); err != nil {
 		s.logger.Logger.Errorf("error while confirming account, error %v\n", err)
 		return err
 	}
-	if _, err := s.ProfileInfoUsecase.Create(context, userToProfleInfo(user)); err != nil {
+	role, err := s.RoleRepository.GetByName(context, domain.RoleUser)
+	if err != nil {
+		s.logger.Logger.Errorf("error while confirming account, error %v\n", err)
+		return err
+	}
+	if _, err := s.ProfileInfoUsecase.Create(context, userToProfleInfo(user, role)); err != nil {
 		s.logger.Logger.Errorf("error while confirming account, error %v\n", err)
 		return err
 	}
@@ -174,44 +203,46 @@ func (s *registrationUsecase) IsAlreadyRegistered(context context.Context, usern
 
 	return false
 }
-func userToProfleInfo(user *domain.User) *domain.ProfileInfo{
+func userToProfleInfo(user *domain.User, role *domain.Role) *domain.ProfileInfo{
 	return &domain.ProfileInfo{
 		ID: user.ID,
 		Username: user.Username,
 		Email: user.Email,
 		Password: user.Password,
-		RoleId : 3,
+		Locale: user.Locale,
+		Roles: []domain.Role{*role},
 	}
 
 }
-func agentToProfleInfo(user *domain.User) *domain.ProfileInfo{
+func agentToProfleInfo(user *domain.User, role *domain.Role) *domain.ProfileInfo{
 	return &domain.ProfileInfo{
 		ID: user.ID,
 		Username: user.Username,
 		Email: user.Email,
 		Password: user.Password,
-		RoleId : 2,
+		Locale: user.Locale,
+		Roles: []domain.Role{*role},
 	}
 
 }
 
-func (s *registrationUsecase) ResendCode(ctx context.Context ,email string) (error, string, string) {
+func (s *registrationUsecase) ResendCode(ctx context.Context ,email string) error {
 	s.logger.Logger.Infof("resending code for email %v\n", email)
 	rediskey := redisKeyPattern + email
 
 	if !s.RedisUsecase.ExistsByKey(ctx,rediskey) {
-		return fmt.Errorf("invalid email"), "", ""
+		return domain.InvalidArgument("invalid email")
 	}
 	bytes, err := s.RedisUsecase.GetValueByKey(ctx, rediskey)
 	if err != nil {
-		return err, "", ""
+		return err
 	}
 
 
 	user, err := deserialize(bytes)
 	if err != nil {
 		s.logger.Logger.Errorf("error while confirming account, error %v\n", err)
-		return err, "", ""
+		return err
 	}
 
 	code := helper.RandomStringGenerator(8)
@@ -226,14 +257,14 @@ func (s *registrationUsecase) ResendCode(ctx context.Context ,email string) (err
 	serializedUser, err := serialize(*user)
 	if err != nil {
 		s.logger.Logger.Errorf("error while confirming account, error %v\n", err)
-		return err, "" ,""
+		return err
 	}
 	err = s.RedisUsecase.AddKeyValueSet(ctx, redisKey, serializedUser, time.Duration(expiration))
 	if err != nil {
-		return err, "", ""
+		return err
 	}
 
-	return nil, user.Email, code
+	return s.MailUsecase.SendActivationMail(ctx, user.Email, user.Username, code)
 }
 
 func serialize(value domain.User) ([]byte, error){
@@ -259,6 +290,9 @@ func deserialize(bytesArray []byte) (*domain.User, error){
 
 func (s *registrationUsecase) ValidateAgentAccount(context context.Context, code string, email string) error {
 	s.logger.Logger.Infof("confirming account for email %v\n", email)
+	if err := s.BruteForceUsecase.Check(context, BruteForceConfirmation, email); err != nil {
+		return err
+	}
 	key := agent + email
 	bytes, err := s.RedisUsecase.GetValueByKey(context, key)
 	if err != nil {
@@ -274,8 +308,10 @@ func (s *registrationUsecase) ValidateAgentAccount(context context.Context, code
 
 	if err := helper.Verify(code, user.ConfirmationCode); err != nil {
 		s.logger.Logger.Errorf("error while confirming account, error %v\n", err)
+		s.BruteForceUsecase.Fail(context, BruteForceConfirmation, email)
 		return err
 	}
+	s.BruteForceUsecase.Succeed(context, BruteForceConfirmation, email)
 	if err := s.RedisUsecase.DeleteValueByKey(context, key); err != nil {
 		s.logger.Logger.Errorf("error while confirming account, error %v\n", err)
 		return err
@@ -297,6 +333,10 @@ func (s *registrationUsecase) RegisterAgent(context context.Context, user domain
 	s.logger.Logger.Infof("registering user with email %v\n", user.Email)
 	redisKey := agent + user.Email
 
+	if err := s.PasswordPolicyUsecase.Validate(context, user.Password, user.Username, user.Email); err != nil {
+		return err
+	}
+
 	confirmationCode := helper.RandomStringGenerator(8)
 	hashedConfirmationCode, err := Hash(confirmationCode)
 	if err != nil {
@@ -313,6 +353,7 @@ func (s *registrationUsecase) RegisterAgent(context context.Context, user domain
 	user.ID = uuid.NewString()
 	user.ConfirmationCode = string(hashedConfirmationCode)
 	user.Password = string(hashedPassword)
+	user.Locale = s.MailUsecase.PreferredLocale(context)
 
 
 	expiration  := 1000000000 * 3600 * 2 //2h
@@ -326,7 +367,12 @@ func (s *registrationUsecase) RegisterAgent(context context.Context, user domain
 		return err
 	}
 
-	go SendMail(user.Email, user.Username, confirmationCode)
+	if err := s.MailUsecase.SendActivationMail(context, user.Email, user.Username, confirmationCode); err != nil {
+		s.logger.Logger.Errorf("error while registering user, error %v\n", err)
+		_ = s.RedisUsecase.DeleteValueByKey(context, redisKey)
+		return err
+	}
+
 	return nil
 }
 
@@ -347,7 +393,12 @@ func (s *registrationUsecase) ConfirmAgentAccount(context context.Context, email
 		return nil, err
 	}
 	if confirm {
-		_, err = s.ProfileInfoUsecase.Create(context, agentToProfleInfo(user))
+		role, err := s.RoleRepository.GetByName(context, domain.RoleAgent)
+		if err != nil {
+			s.logger.Logger.Errorf("error while confirming account, error %v\n", err)
+			return nil, err
+		}
+		_, err = s.ProfileInfoUsecase.Create(context, agentToProfleInfo(user, role))
 		if err != nil {
 			s.logger.Logger.Errorf("error while confirming account, error %v\n", err)
 			return nil, err
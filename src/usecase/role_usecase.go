@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/tracer"
+	"auth-service/repository"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"strings"
+)
+
+const (
+	roleRequired       = "a role is required"
+	roleNotFound       = "role not found"
+	roleOwnAccount     = "admins can't change their own roles"
+	roleAlreadyGranted = "user already has this role"
+	roleNotGranted     = "user doesn't have this role"
+	roleLastOne        = "users must keep at least one role"
+)
+
+type roleUsecase struct {
+	ProfileInfoRepository repository.ProfileInfoRepository
+	RoleRepository        repository.RoleRepository
+	AuthenticationUsecase AuthenticationUsecase
+	AuditUsecase          AuditUsecase
+	logger                *logger.Logger
+}
+
+type RoleUsecase interface {
+	Grant(context context.Context, actor, userId, roleName string) (*domain.ProfileInfo, error)
+	Revoke(context context.Context, actor, userId, roleName string) (*domain.ProfileInfo, error)
+}
+
+func NewRoleUsecase(profileInfoRepository repository.ProfileInfoRepository, roleRepository repository.RoleRepository, authenticationUsecase AuthenticationUsecase, auditUsecase AuditUsecase, logger *logger.Logger) RoleUsecase {
+	return &roleUsecase{ProfileInfoRepository: profileInfoRepository, RoleRepository: roleRepository, AuthenticationUsecase: authenticationUsecase, AuditUsecase: auditUsecase, logger: logger}
+}
+
+// Grant adds the role to the user's account.
+func (r *roleUsecase) Grant(context context.Context, actor, userId, roleName string) (*domain.ProfileInfo, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/GrantRole")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+	roleName = strings.ToLower(roleName)
+
+	account, err := r.change(ctx1, actor, userId, roleName, func(account *domain.ProfileInfo, role *domain.Role) error {
+		if account.HasRole(role.RoleName) {
+			return domain.InvalidArgument(roleAlreadyGranted)
+		}
+		return r.ProfileInfoRepository.AddRole(ctx1, account, role)
+	})
+	r.AuditUsecase.RecordWithReason(ctx1, actor, userId, domain.AuditRoleChanged, "granted "+roleName, err)
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	r.logger.Logger.Warnf("role %v granted to %v by %v\n", roleName, userId, actor)
+
+	return account, nil
+}
+
+// Revoke removes the role from the user's account and revokes their sessions.
+func (r *roleUsecase) Revoke(context context.Context, actor, userId, roleName string) (*domain.ProfileInfo, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/RevokeRole")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+	roleName = strings.ToLower(roleName)
+
+	account, err := r.change(ctx1, actor, userId, roleName, func(account *domain.ProfileInfo, role *domain.Role) error {
+		if !account.HasRole(role.RoleName) {
+			return domain.InvalidArgument(roleNotGranted)
+		}
+		if len(account.Roles) == 1 {
+			return domain.InvalidArgument(roleLastOne)
+		}
+		if err := r.ProfileInfoRepository.RemoveRole(ctx1, account, role); err != nil {
+			return err
+		}
+		return r.AuthenticationUsecase.RevokeUserSessions(ctx1, account.ID)
+	})
+	r.AuditUsecase.RecordWithReason(ctx1, actor, userId, domain.AuditRoleChanged, "revoked "+roleName, err)
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	r.logger.Logger.Warnf("role %v revoked from %v by %v\n", roleName, userId, actor)
+
+	return account, nil
+}
+
+func (r *roleUsecase) change(context context.Context, actor, userId, roleName string, apply func(account *domain.ProfileInfo, role *domain.Role) error) (*domain.ProfileInfo, error) {
+	if roleName == "" {
+		return nil, domain.InvalidArgument(roleRequired)
+	}
+	if actor == userId {
+		return nil, domain.PermissionDenied(domain.ReasonForbidden, roleOwnAccount)
+	}
+
+	role, err := r.RoleRepository.GetByName(context, roleName)
+	if err != nil {
+		return nil, domain.NotFound(roleNotFound)
+	}
+
+	account, err := r.ProfileInfoRepository.GetProfileInfoById(context, userId)
+	if err != nil {
+		return nil, domain.NotFound(userNotFound)
+	}
+
+	if err := apply(account, role); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/tracer"
+	"auth-service/repository"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"strings"
+)
+
+const (
+	scopeRequired       = "a role and a scope are required"
+	scopeNotFound       = "scope not found"
+	scopeAlreadyGranted = "role already has this scope"
+	scopeNotGranted     = "role doesn't have this scope"
+)
+
+type scopeUsecase struct {
+	ScopeRepository repository.ScopeRepository
+	RoleRepository  repository.RoleRepository
+	AuditUsecase    AuditUsecase
+	logger          *logger.Logger
+}
+
+type ScopeUsecase interface {
+	List(context context.Context) ([]domain.Scope, []domain.Role, error)
+	AddToRole(context context.Context, actor, roleName, scopeName string) (*domain.Role, error)
+	RemoveFromRole(context context.Context, actor, roleName, scopeName string) (*domain.Role, error)
+}
+
+func NewScopeUsecase(scopeRepository repository.ScopeRepository, roleRepository repository.RoleRepository, auditUsecase AuditUsecase, logger *logger.Logger) ScopeUsecase {
+	return &scopeUsecase{ScopeRepository: scopeRepository, RoleRepository: roleRepository, AuditUsecase: auditUsecase, logger: logger}
+}
+
+// List returns every scope and the roles with the scopes granted to them.
+func (s *scopeUsecase) List(context context.Context) ([]domain.Scope, []domain.Role, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/ListScopes")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	scopes, err := s.ScopeRepository.GetAll(ctx1)
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, nil, err
+	}
+
+	roles, err := s.RoleRepository.GetAll(ctx1)
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, nil, err
+	}
+
+	return scopes, roles, nil
+}
+
+// AddToRole grants the scope to everyone with the role.
+func (s *scopeUsecase) AddToRole(context context.Context, actor, roleName, scopeName string) (*domain.Role, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/AddScopeToRole")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	role, err := s.change(ctx1, roleName, scopeName, func(role *domain.Role, scope *domain.Scope) error {
+		if roleHasScope(*role, scope.Name) {
+			return domain.InvalidArgument(scopeAlreadyGranted)
+		}
+		return s.RoleRepository.AddScope(ctx1, role, scope)
+	})
+	s.AuditUsecase.Record(ctx1, actor, roleScopeTarget(roleName, scopeName), domain.AuditRoleScopeAdded, err)
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// RemoveFromRole takes the scope away from the role.
+func (s *scopeUsecase) RemoveFromRole(context context.Context, actor, roleName, scopeName string) (*domain.Role, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/RemoveScopeFromRole")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	role, err := s.change(ctx1, roleName, scopeName, func(role *domain.Role, scope *domain.Scope) error {
+		if !roleHasScope(*role, scope.Name) {
+			return domain.InvalidArgument(scopeNotGranted)
+		}
+		return s.RoleRepository.RemoveScope(ctx1, role, scope)
+	})
+	s.AuditUsecase.Record(ctx1, actor, roleScopeTarget(roleName, scopeName), domain.AuditRoleScopeRemoved, err)
+	if err != nil {
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	return role, nil
+}
+
+func (s *scopeUsecase) change(context context.Context, roleName, scopeName string, apply func(role *domain.Role, scope *domain.Scope) error) (*domain.Role, error) {
+	if roleName == "" || scopeName == "" {
+		return nil, domain.InvalidArgument(scopeRequired)
+	}
+
+	role, err := s.RoleRepository.GetByName(context, strings.ToLower(roleName))
+	if err != nil {
+		return nil, domain.NotFound(roleNotFound)
+	}
+
+	scopes, err := s.ScopeRepository.GetByNames(context, []string{strings.ToLower(scopeName)})
+	if err != nil || len(scopes) == 0 {
+		return nil, domain.NotFound(scopeNotFound)
+	}
+
+	if err := apply(role, &scopes[0]); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+func roleHasScope(role domain.Role, scopeName string) bool {
+	for _, scope := range role.Scopes {
+		if scope.Name == scopeName {
+			return true
+		}
+	}
+	return false
+}
+
+func roleScopeTarget(roleName, scopeName string) string {
+	return strings.ToLower(roleName) + ": " + strings.ToLower(scopeName)
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/helper"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"sync"
+	"time"
+)
+
+type SecurityEventHandler func(context context.Context, event domain.SecurityEvent)
+
+// securityEventUsecase is an in-process event bus.
+type securityEventUsecase struct {
+	mutex    sync.RWMutex
+	handlers []SecurityEventHandler
+	logger   *logger.Logger
+}
+
+type SecurityEventUsecase interface {
+	Publish(context context.Context, event domain.SecurityEvent)
+	Subscribe(handler SecurityEventHandler)
+}
+
+func NewSecurityEventUsecase(logger *logger.Logger) SecurityEventUsecase {
+	return &securityEventUsecase{logger: logger}
+}
+
+func (s *securityEventUsecase) Subscribe(handler SecurityEventHandler) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.handlers = append(s.handlers, handler)
+}
+
+// Publish hands the event to every subscriber in the background.
+func (s *securityEventUsecase) Publish(context context.Context, event domain.SecurityEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	if event.IpAddress == "" && event.UserAgent == "" {
+		event.IpAddress, event.UserAgent = helper.ClientInfoFromContext(context)
+	}
+	if event.Locale == "" {
+		event.Locale = helper.LocaleFromContext(context)
+	}
+
+	s.logger.Logger.Infof("security event %v for user %v from IP address %v\n", event.Type, event.ProfileInfoId, event.IpAddress)
+
+	s.mutex.RLock()
+	handlers := append([]SecurityEventHandler(nil), s.handlers...)
+	s.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		go func(handler SecurityEventHandler) {
+			defer func() {
+				if r := recover(); r != nil {
+					s.logger.Logger.Errorf("security event handler panicked for event %v, error: %v\n", event.Type, r)
+				}
+			}()
+			handler(helper.WithLocale(detachedContext(), event.Locale), event)
+		}(handler)
+	}
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"auth-service/assets/mail_template"
+	"auth-service/domain"
+	"auth-service/helper"
+	"auth-service/infrastructure/tracer"
+	"auth-service/repository"
+	"context"
+	"errors"
+	"fmt"
+	logger "github.com/jelena-vlajkov/logger/logger"
+	"net/url"
+	"time"
+)
+
+const (
+	accountLockKey = "accountLock/"
+
+	accountLockErr  = "account lock link is invalid or expired"
+	alertTimeFormat = "02 Jan 2006 15:04 MST"
+)
+
+// securityNotificationUsecase mails the account owner about security events.
+type securityNotificationUsecase struct {
+	Config                domain.SecurityNotificationConfig
+	ProfileInfoRepository repository.ProfileInfoRepository
+	RedisUsecase          RedisUsecase
+	AuthenticationUsecase AuthenticationUsecase
+	MailUsecase           MailUsecase
+	AuditUsecase          AuditUsecase
+	logger                *logger.Logger
+}
+
+type SecurityNotificationUsecase interface {
+	Notify(context context.Context, event domain.SecurityEvent)
+	LockAccount(context context.Context, token string) error
+}
+
+func NewSecurityNotificationUsecase(config domain.SecurityNotificationConfig, profileInfoRepository repository.ProfileInfoRepository, redisUsecase RedisUsecase,
+	authenticationUsecase AuthenticationUsecase, mailUsecase MailUsecase, auditUsecase AuditUsecase, logger *logger.Logger) SecurityNotificationUsecase {
+	return &securityNotificationUsecase{
+		Config:                config,
+		ProfileInfoRepository: profileInfoRepository,
+		RedisUsecase:          redisUsecase,
+		AuthenticationUsecase: authenticationUsecase,
+		MailUsecase:           mailUsecase,
+		AuditUsecase:          auditUsecase,
+		logger:                logger,
+	}
+}
+
+// Notify reports security events to the owner, and email changes to the previous address too.
+func (s *securityNotificationUsecase) Notify(context context.Context, event domain.SecurityEvent) {
+	if !s.Config.Enabled {
+		return
+	}
+
+	span := tracer.StartSpanFromContext(context, "usecase/NotifySecurityEvent")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	account, err := s.ProfileInfoRepository.GetProfileInfoById(ctx1, event.ProfileInfoId)
+	if err != nil {
+		s.logger.Logger.Errorf("error while notifying user %v about %v, error: %v\n", event.ProfileInfoId, event.Type, err)
+		tracer.LogError(span, err)
+		return
+	}
+
+	recipients := []string{account.Email}
+	if event.PreviousEmail != "" && event.PreviousEmail != account.Email {
+		recipients = append(recipients, event.PreviousEmail)
+	}
+
+	for _, recipient := range recipients {
+		link, err := s.lockLink(ctx1, account.ID)
+		if err != nil {
+			s.logger.Logger.Errorf("error while creating account lock link for user %v, error: %v\n", account.ID, err)
+			tracer.LogError(span, err)
+			return
+		}
+
+		err = s.MailUsecase.SendSecurityAlertMail(ctx1, recipient, mail_template.SecurityAlertData{
+			UserName:  account.Username,
+			Event:     event.Type,
+			Time:      event.OccurredAt.UTC().Format(alertTimeFormat),
+			IpAddress: event.IpAddress,
+			UserAgent: event.UserAgent,
+			Link:      link,
+		})
+		if err != nil {
+			s.logger.Logger.Errorf("error while sending security alert %v to %v, error: %v\n", event.Type, recipient, err)
+			tracer.LogError(span, err)
+		}
+	}
+}
+
+func (s *securityNotificationUsecase) lockLink(context context.Context, userId string) (string, error) {
+	token, err := helper.RandomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.RedisUsecase.AddKeyValueSet(context, accountLockKey+hashNonce(token), userId, s.Config.LockTtl); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s?token=%s", s.Config.LockUrl, url.QueryEscape(token)), nil
+}
+
+// LockAccount locks the account of a lock link and revokes its sessions.
+func (s *securityNotificationUsecase) LockAccount(context context.Context, token string) error {
+	span := tracer.StartSpanFromContext(context, "usecase/LockAccount")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	key := accountLockKey + hashNonce(token)
+	userId, err := s.RedisUsecase.GetValueByKey(ctx1, key)
+	if err != nil {
+		tracer.LogError(span, err)
+		return errors.New(accountLockErr)
+	}
+
+	account, err := s.ProfileInfoRepository.GetProfileInfoById(ctx1, string(userId))
+	if err != nil {
+		tracer.LogError(span, err)
+		return domain.NotFound(userNotFound)
+	}
+
+	s.logger.Logger.Warnf("locking account of user %v on the owner's request\n", account.ID)
+	if !account.IsLocked() {
+		now := time.Now()
+		account.LockedAt = &now
+		if err := s.ProfileInfoRepository.Update(ctx1, account); err != nil {
+			tracer.LogError(span, err)
+			s.AuditUsecase.Record(ctx1, account.ID, account.ID, domain.AuditAccountLocked, err)
+			return errors.New(updateError)
+		}
+	}
+
+	_ = s.RedisUsecase.DeleteValueByKey(ctx1, key)
+
+	err = s.AuthenticationUsecase.RevokeUserSessions(ctx1, account.ID)
+	s.AuditUsecase.Record(ctx1, account.ID, account.ID, domain.AuditAccountLocked, err)
+	if err != nil {
+		s.logger.Logger.Errorf("error while revoking sessions for user %v, error: %v\n", account.ID, err)
+		tracer.LogError(span, err)
+		return err
+	}
+
+	return nil
+}
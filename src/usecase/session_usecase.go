@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"auth-service/domain"
+	"auth-service/infrastructure/tracer"
+	"context"
+	logger "github.com/jelena-vlajkov/logger/logger"
+)
+
+type sessionUsecase struct {
+	JwtUsecase            JwtUsecase
+	AuthenticationUsecase AuthenticationUsecase
+	ProfileInfoUsecase    ProfileInfoUsecase
+	logger                *logger.Logger
+}
+
+type SessionUsecase interface {
+	IssueTemporaryToken(context context.Context, profileInfo domain.ProfileInfo) (*domain.Session, error)
+	IssueTokens(context context.Context, profileInfo domain.ProfileInfo) (*domain.Session, error)
+}
+
+func NewSessionUsecase(jwtUsecase JwtUsecase, authenticationUsecase AuthenticationUsecase, profileInfoUsecase ProfileInfoUsecase, logger *logger.Logger) SessionUsecase {
+	return &sessionUsecase{JwtUsecase: jwtUsecase, AuthenticationUsecase: authenticationUsecase, ProfileInfoUsecase: profileInfoUsecase, logger: logger}
+}
+
+// IssueTemporaryToken issues the token traded for a session once the TOTP passcode is verified.
+func (s *sessionUsecase) IssueTemporaryToken(context context.Context, profileInfo domain.ProfileInfo) (*domain.Session, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/IssueTemporaryToken")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	temporaryToken, err := s.JwtUsecase.CreateTemporaryToken(ctx1, profileInfo.PrimaryRole(), profileInfo.ID)
+	if err != nil {
+		s.logger.Logger.Errorf("error while creating temporary token, error: %v\n", err)
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	if err := s.AuthenticationUsecase.SaveTemporaryToken(ctx1, temporaryToken); err != nil {
+		s.logger.Logger.Errorf("error while saving temporary token, error %v\n", err)
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	return &domain.Session{UserId: profileInfo.ID, Username: profileInfo.Username, Role: domain.RoleTemporaryUser, TokenUuid: temporaryToken.TokenUuid}, nil
+}
+
+// IssueTokens issues tokens, or only a password change token while the password is expired.
+func (s *sessionUsecase) IssueTokens(context context.Context, profileInfo domain.ProfileInfo) (*domain.Session, error) {
+	span := tracer.StartSpanFromContext(context, "usecase/IssueTokens")
+	defer span.Finish()
+
+	ctx1 := tracer.ContextWithSpan(context, span)
+
+	if s.ProfileInfoUsecase.IsPasswordExpired(ctx1, profileInfo) {
+		token, err := s.JwtUsecase.CreatePasswordChangeToken(ctx1, profileInfo.ID)
+		if err != nil {
+			s.logger.Logger.Errorf("error while creating password change token, error: %v\n", err)
+			tracer.LogError(span, err)
+			return nil, err
+		}
+
+		return &domain.Session{UserId: profileInfo.ID, Username: profileInfo.Username, Role: domain.RolePasswordExpired, TokenUuid: token.TokenUuid}, nil
+	}
+
+	token, err := s.JwtUsecase.CreateToken(ctx1, profileInfo.RoleNames(), profileInfo.ID, true)
+	if err != nil {
+		s.logger.Logger.Errorf("error while creating token, error: %v\n", err)
+		tracer.LogError(span, err)
+		return nil, err
+	}
+
+	return &domain.Session{UserId: profileInfo.ID, Username: profileInfo.Username, Role: profileInfo.PrimaryRole(), Roles: profileInfo.RoleNames(),
+		TokenUuid: token.TokenUuid, RefreshUuid: token.RefreshUuid}, nil
+}